@@ -0,0 +1,300 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bimap provides a bidirectional map, which preserves the uniqueness
+// of its values as well as that of its keys.
+//
+// 💡 NOTE: BiMap is not concurrent-safe.
+//
+// # Structures
+//
+//   - [BiMap]
+//
+// # Operations
+//
+//   - Constructor: [New], [FromMap]
+//   - CRUD operations: [BiMap.Put], [BiMap.ForcePut], [BiMap.GetByKey], [BiMap.GetByValue], [BiMap.DeleteByKey], [BiMap.DeleteByValue], …
+//   - Inverse view: [BiMap.Inverse]
+//   - Conversion: [BiMap.Keys], [BiMap.Values], [BiMap.ToMap], …
+//
+// # JSON
+//
+// [BiMap] implements [encoding/json.Marshaler] and [encoding/json.Unmarshaler],
+// it is encoded as a JSON object from keys to values.
+package bimap
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+// ErrValueConflict is returned when putting a value which is already bound to
+// another key.
+var ErrValueConflict = errors.New("bimap: value is already bound to another key")
+
+// BiMap is a bidirectional map, every key maps to exactly one value and every
+// value maps back to exactly one key.
+//
+// The zero value for BiMap is an empty map ready to use.
+//
+// 💡 NOTE: BiMap is not concurrent-safe.
+type BiMap[K, V comparable] struct {
+	fwd map[K]V
+	inv map[V]K
+}
+
+// New creates an empty BiMap.
+func New[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		fwd: make(map[K]V),
+		inv: make(map[V]K),
+	}
+}
+
+// FromMap creates a BiMap from map m.
+// [ErrValueConflict] is returned if m contains duplicated values.
+func FromMap[K, V comparable](m map[K]V) (*BiMap[K, V], error) {
+	b := &BiMap[K, V]{
+		fwd: make(map[K]V, len(m)),
+		inv: make(map[V]K, len(m)),
+	}
+	for k, v := range m {
+		if _, ok := b.inv[v]; ok {
+			return nil, ErrValueConflict
+		}
+		b.fwd[k] = v
+		b.inv[v] = k
+	}
+	return b, nil
+}
+
+// lazyInit lazily initializes a zero BiMap value.
+func (b *BiMap[K, V]) lazyInit() {
+	if b.fwd == nil {
+		b.fwd = make(map[K]V)
+		b.inv = make(map[V]K)
+	}
+}
+
+// Len returns the number of entries of map.
+func (b *BiMap[K, V]) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.fwd)
+}
+
+// Put associates key k with value v.
+//
+// If k is already present, its old value is replaced.
+// If v is already bound to another key, the map is not modified and
+// [ErrValueConflict] is returned, use [BiMap.ForcePut] if you want to
+// replace it.
+func (b *BiMap[K, V]) Put(k K, v V) error {
+	b.lazyInit()
+	if oldK, ok := b.inv[v]; ok {
+		if oldK == k {
+			return nil
+		}
+		return ErrValueConflict
+	}
+	b.put(k, v)
+	return nil
+}
+
+// ForcePut is a variant of [BiMap.Put], it silently removes the existing
+// entry with value v before putting.
+func (b *BiMap[K, V]) ForcePut(k K, v V) {
+	b.lazyInit()
+	if oldK, ok := b.inv[v]; ok {
+		delete(b.fwd, oldK)
+	}
+	b.put(k, v)
+}
+
+func (b *BiMap[K, V]) put(k K, v V) {
+	if oldV, ok := b.fwd[k]; ok {
+		delete(b.inv, oldV)
+	}
+	b.fwd[k] = v
+	b.inv[v] = k
+}
+
+// GetByKey returns the value associated with key k.
+func (b *BiMap[K, V]) GetByKey(k K) goption.O[V] {
+	if b == nil {
+		return goption.Nil[V]()
+	}
+	v, ok := b.fwd[k]
+	return goption.Of(v, ok)
+}
+
+// GetByValue returns the key associated with value v.
+func (b *BiMap[K, V]) GetByValue(v V) goption.O[K] {
+	if b == nil {
+		return goption.Nil[K]()
+	}
+	k, ok := b.inv[v]
+	return goption.Of(k, ok)
+}
+
+// ContainsKey returns true if key k is present.
+func (b *BiMap[K, V]) ContainsKey(k K) bool {
+	return b.GetByKey(k).IsOK()
+}
+
+// ContainsValue returns true if value v is present.
+func (b *BiMap[K, V]) ContainsValue(v V) bool {
+	return b.GetByValue(v).IsOK()
+}
+
+// DeleteByKey deletes the entry with key k, returns the deleted value.
+func (b *BiMap[K, V]) DeleteByKey(k K) goption.O[V] {
+	o := b.GetByKey(k)
+	o.IfOK(func(v V) {
+		delete(b.fwd, k)
+		delete(b.inv, v)
+	})
+	return o
+}
+
+// DeleteByValue deletes the entry with value v, returns the deleted key.
+func (b *BiMap[K, V]) DeleteByValue(v V) goption.O[K] {
+	o := b.GetByValue(v)
+	o.IfOK(func(k K) {
+		delete(b.fwd, k)
+		delete(b.inv, v)
+	})
+	return o
+}
+
+// Inverse returns the inverse view of map, which maps values to keys.
+//
+// The view shares the same underlying storage with b,
+// so changes to one are immediately visible in the other.
+// If b is nil, return an empty map.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	if b == nil {
+		return New[V, K]()
+	}
+	b.lazyInit()
+	return &BiMap[V, K]{fwd: b.inv, inv: b.fwd}
+}
+
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, range stops the iteration.
+//
+// 💡 NOTE: The iteration order is not specified.
+func (b *BiMap[K, V]) Range(f func(k K, v V) bool) {
+	if b == nil {
+		return
+	}
+	for k, v := range b.fwd {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// Keys returns all keys of map.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (b *BiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, b.Len())
+	b.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values of map.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (b *BiMap[K, V]) Values() []V {
+	values := make([]V, 0, b.Len())
+	b.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// ToMap converts the map into a builtin map from keys to values.
+func (b *BiMap[K, V]) ToMap() map[K]V {
+	m := make(map[K]V, b.Len())
+	b.Range(func(k K, v V) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
+
+// Clone returns a copy of the map.
+func (b *BiMap[K, V]) Clone() *BiMap[K, V] {
+	res := &BiMap[K, V]{
+		fwd: make(map[K]V, b.Len()),
+		inv: make(map[V]K, b.Len()),
+	}
+	b.Range(func(k K, v V) bool {
+		res.fwd[k] = v
+		res.inv[v] = k
+		return true
+	})
+	return res
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// The returned bytes is null or JSON object, keys of object are sorted
+// lexicographically.
+func (b *BiMap[K, V]) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	enc := jsonbuilder.NewDict()
+	for k, v := range b.fwd {
+		if err := enc.Store(k, v); err != nil {
+			return nil, err
+		}
+	}
+	enc.Sort()
+	return enc.Build()
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// [ErrValueConflict] is returned if data contains duplicated values.
+// The original entries are always overridden.
+//
+// 💡 NOTE: Unmarshaling into an inverse view detaches it from the original map.
+func (b *BiMap[K, V]) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	res, err := FromMap(m)
+	if err != nil {
+		return err
+	}
+	*b = *res
+	return nil
+}
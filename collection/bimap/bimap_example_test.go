@@ -0,0 +1,50 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bimap
+
+import (
+	"fmt"
+)
+
+func Example() {
+	type Status int
+	const (
+		Pending Status = iota
+		Running
+		Done
+	)
+
+	codes := New[Status, string]()
+	_ = codes.Put(Pending, "PENDING")
+	_ = codes.Put(Running, "RUNNING")
+	_ = codes.Put(Done, "DONE")
+
+	fmt.Println(codes.GetByKey(Running).Value())  // RUNNING
+	fmt.Println(codes.GetByValue("DONE").Value()) // 2
+	fmt.Println(codes.Put(Done, "RUNNING"))       // bimap: value is already bound to another key
+	fmt.Println(codes.Inverse().Len())            // 3
+
+	codes.ForcePut(Done, "RUNNING")
+	fmt.Println(codes.Len())                // 2
+	fmt.Println(codes.ContainsKey(Running)) // false
+
+	// Output:
+	// RUNNING
+	// 2
+	// bimap: value is already bound to another key
+	// 3
+	// 2
+	// false
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bimap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestNilBiMap(t *testing.T) {
+	var b *BiMap[string, int]
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, goption.Nil[int](), b.GetByKey("a"))
+	assert.Equal(t, goption.Nil[string](), b.GetByValue(1))
+	assert.False(t, b.ContainsKey("a"))
+	assert.False(t, b.ContainsValue(1))
+	assert.Equal(t, goption.Nil[int](), b.DeleteByKey("a"))
+	assert.Equal(t, goption.Nil[string](), b.DeleteByValue(1))
+	assert.Equal(t, []string{}, b.Keys())
+	assert.Equal(t, []int{}, b.Values())
+	assert.Equal(t, map[string]int{}, b.ToMap())
+	assert.Equal(t, 0, b.Clone().Len())
+	assert.Equal(t, 0, b.Inverse().Len())
+	bs, err := json.Marshal(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(bs))
+
+	var zero BiMap[string, int]
+	assert.Nil(t, zero.Put("a", 1))
+	assert.Equal(t, goption.OK("a"), zero.GetByValue(1))
+
+	var zero2 BiMap[string, int]
+	zero2.Inverse().ForcePut(1, "a")
+	assert.Equal(t, goption.OK(1), zero2.GetByKey("a"))
+}
+
+func TestPut(t *testing.T) {
+	b := New[string, int]()
+	assert.Nil(t, b.Put("a", 1))
+	assert.Nil(t, b.Put("b", 2))
+	assert.Nil(t, b.Put("a", 1)) // same entry
+	assert.Equal(t, 2, b.Len())
+
+	// Value conflict.
+	assert.Equal(t, ErrValueConflict, b.Put("c", 1))
+	assert.False(t, b.ContainsKey("c"))
+	assert.Equal(t, goption.OK("a"), b.GetByValue(1))
+
+	// Replacing value of existing key releases the old value.
+	assert.Nil(t, b.Put("a", 3))
+	assert.Equal(t, goption.OK(3), b.GetByKey("a"))
+	assert.False(t, b.ContainsValue(1))
+	assert.Nil(t, b.Put("c", 1))
+	assert.Equal(t, 3, b.Len())
+
+	// Force put removes the conflicted entry.
+	b.ForcePut("d", 2)
+	assert.False(t, b.ContainsKey("b"))
+	assert.Equal(t, goption.OK("d"), b.GetByValue(2))
+	assert.Equal(t, 3, b.Len())
+
+	// Force put onto both an existing key and an existing value.
+	b.ForcePut("a", 1) // a:3, c:1 -> a:1
+	assert.Equal(t, map[string]int{"a": 1, "d": 2}, b.ToMap())
+	assert.Equal(t, map[int]string{1: "a", 2: "d"}, b.Inverse().ToMap())
+}
+
+func TestDelete(t *testing.T) {
+	b := New[string, int]()
+	assert.Nil(t, b.Put("a", 1))
+	assert.Nil(t, b.Put("b", 2))
+
+	assert.Equal(t, goption.OK(1), b.DeleteByKey("a"))
+	assert.Equal(t, goption.Nil[int](), b.DeleteByKey("a"))
+	assert.False(t, b.ContainsValue(1))
+
+	assert.Equal(t, goption.OK("b"), b.DeleteByValue(2))
+	assert.Equal(t, goption.Nil[string](), b.DeleteByValue(2))
+	assert.False(t, b.ContainsKey("b"))
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestInverse(t *testing.T) {
+	b := New[string, int]()
+	inv := b.Inverse()
+	assert.Nil(t, b.Put("a", 1))
+	assert.Equal(t, goption.OK("a"), inv.GetByKey(1))
+
+	assert.Nil(t, inv.Put(2, "b"))
+	assert.Equal(t, goption.OK(2), b.GetByKey("b"))
+	assert.Equal(t, ErrValueConflict, inv.Put(3, "a"))
+
+	inv.DeleteByKey(1)
+	assert.False(t, b.ContainsKey("a"))
+	assert.Equal(t, 1, b.Len())
+	assert.Equal(t, 1, inv.Len())
+
+	assert.Equal(t, b, inv.Inverse().Inverse().Inverse())
+}
+
+func TestConversion(t *testing.T) {
+	b, err := FromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, gslice.SortClone(b.Keys()))
+	assert.Equal(t, []int{1, 2, 3}, gslice.SortClone(b.Values()))
+
+	_, err = FromMap(map[string]int{"a": 1, "b": 1})
+	assert.Equal(t, ErrValueConflict, err)
+
+	cnt := 0
+	b.Range(func(string, int) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 1, cnt)
+
+	c := b.Clone()
+	c.DeleteByKey("a")
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, goption.Nil[string](), c.GetByValue(1))
+}
+
+func TestJSON(t *testing.T) {
+	b := New[string, int]()
+	assert.Nil(t, b.Put("b", 2))
+	assert.Nil(t, b.Put("a", 1))
+	bs, err := json.Marshal(b)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"b":2}`, string(bs))
+
+	b2 := New[string, int]()
+	assert.Nil(t, b2.Put("x", 0))
+	assert.Nil(t, json.Unmarshal(bs, b2))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, b2.ToMap())
+	assert.Equal(t, goption.OK("b"), b2.GetByValue(2))
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), b2))
+	assert.Equal(t, 2, b2.Len())
+	assert.Equal(t, ErrValueConflict, json.Unmarshal([]byte(`{"a":1,"b":1}`), b2))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":"1"}`), b2))
+	assert.Equal(t, 2, b2.Len())
+
+	f := New[float64, int]()
+	f.ForcePut(1.5, 1)
+	_, err = json.Marshal(f)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,547 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitset provides a compact set of non-negative integers.
+//
+// A [BitSet] uses one bit per possible member, which is much smaller than
+// [github.com/bytedance/gg/collection/set.Set] when members are dense small
+// integers such as IDs, flags and permission masks.
+//
+// 💡 NOTE: BitSet is not concurrent-safe.
+//
+// # Structures
+//
+//   - [BitSet]
+//
+// # Operations
+//
+//   - Constructor: [New], [NewWithCap], …
+//   - Bit operations: [BitSet.Set], [BitSet.Clear], [BitSet.Test], [BitSet.Flip], …
+//   - Set operations: [BitSet.Union], [BitSet.Intersect], [BitSet.Difference], [BitSet.SymmetricDiff]
+//     and its variants [BitSet.UnionInplace], [BitSet.IntersectInplace], …
+//   - Predicates: [BitSet.Equal], [BitSet.IsSubset], [BitSet.IsSuperset], …
+//   - Searching: [BitSet.NextSet], [BitSet.NextClear], [BitSet.Rank], [BitSet.Select]
+//   - Conversion: [BitSet.String], [BitSet.ToSlice], …
+//
+// # Serialization
+//
+// [BitSet] implements [encoding.BinaryMarshaler] and [encoding.BinaryUnmarshaler]
+// for compact storage, and [encoding/json.Marshaler] and
+// [encoding/json.Unmarshaler] for human readable form.
+package bitset
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/gg/gvalue"
+	"github.com/bytedance/gg/internal/rtassert"
+)
+
+const (
+	wordBits = 64
+	logWord  = 6 // log2(wordBits)
+)
+
+// BitSet is a set of non-negative integers.
+// The zero value for BitSet is an empty set ready to use.
+//
+// The set grows automatically when a bit beyond its capacity is set.
+type BitSet struct {
+	words []uint64
+}
+
+// New creates a new bitset with initial members.
+//
+// 💡 NOTE: It panics if any of members is negative.
+func New(members ...int) *BitSet {
+	b := &BitSet{}
+	for _, i := range members {
+		b.Set(i)
+	}
+	return b
+}
+
+// NewWithCap creates an empty bitset which can hold members in [0, n)
+// without reallocation.
+func NewWithCap(n int) *BitSet {
+	rtassert.MustNotNeg(n)
+	return &BitSet{words: make([]uint64, wordsFor(n))}
+}
+
+// wordsFor returns the number of words needed to hold n bits.
+func wordsFor(n int) int {
+	return (n + wordBits - 1) >> logWord
+}
+
+// grow ensures word index w is addressable.
+func (b *BitSet) grow(w int) {
+	if w < len(b.words) {
+		return
+	}
+	if w < cap(b.words) {
+		b.words = b.words[:w+1]
+		return
+	}
+	words := make([]uint64, w+1, gvalue.Max(w+1, 2*cap(b.words)))
+	copy(words, b.words)
+	b.words = words
+}
+
+// trim drops trailing zero words.
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+// Set adds i to the set.
+//
+// 💡 NOTE: It panics if i is negative.
+func (b *BitSet) Set(i int) {
+	rtassert.MustNotNeg(i)
+	w := i >> logWord
+	b.grow(w)
+	b.words[w] |= 1 << (uint(i) & (wordBits - 1))
+}
+
+// Clear removes i from the set.
+func (b *BitSet) Clear(i int) {
+	if b == nil || i < 0 {
+		return
+	}
+	w := i >> logWord
+	if w < len(b.words) {
+		b.words[w] &^= 1 << (uint(i) & (wordBits - 1))
+	}
+}
+
+// Test returns whether i is in the set.
+func (b *BitSet) Test(i int) bool {
+	if b == nil || i < 0 {
+		return false
+	}
+	w := i >> logWord
+	return w < len(b.words) && b.words[w]&(1<<(uint(i)&(wordBits-1))) != 0
+}
+
+// Flip toggles the membership of i.
+//
+// 💡 NOTE: It panics if i is negative.
+func (b *BitSet) Flip(i int) {
+	rtassert.MustNotNeg(i)
+	w := i >> logWord
+	b.grow(w)
+	b.words[w] ^= 1 << (uint(i) & (wordBits - 1))
+}
+
+// Reset removes all members from the set, the underlying storage is kept.
+func (b *BitSet) Reset() {
+	if b == nil {
+		return
+	}
+	for i := range b.words {
+		b.words[i] = 0
+	}
+	b.words = b.words[:0]
+}
+
+// Count returns the number of members (population count) of the set.
+func (b *BitSet) Count() int {
+	if b == nil {
+		return 0
+	}
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty returns whether the set has no member.
+func (b *BitSet) IsEmpty() bool {
+	if b == nil {
+		return true
+	}
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Cap returns the number of bits the set can hold without reallocation.
+func (b *BitSet) Cap() int {
+	if b == nil {
+		return 0
+	}
+	return cap(b.words) * wordBits
+}
+
+// NextSet returns the smallest member which is greater than or equal to i.
+// If there is no such member, false is returned.
+//
+// To iterate over all members in ascending order:
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+//		// do something with i
+//	}
+func (b *BitSet) NextSet(i int) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	if i < 0 {
+		i = 0
+	}
+	w := i >> logWord
+	if w >= len(b.words) {
+		return 0, false
+	}
+	word := b.words[w] >> (uint(i) & (wordBits - 1))
+	if word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w<<logWord + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the smallest non-member which is greater than or equal to i.
+//
+// As the set is infinite conceptually, there is always such a non-member.
+func (b *BitSet) NextClear(i int) int {
+	if i < 0 {
+		i = 0
+	}
+	if b == nil {
+		return i
+	}
+	w := i >> logWord
+	if w >= len(b.words) {
+		return i
+	}
+	word := ^b.words[w] >> (uint(i) & (wordBits - 1))
+	if word != 0 {
+		return i + bits.TrailingZeros64(word)
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w<<logWord + bits.TrailingZeros64(^b.words[w])
+		}
+	}
+	return len(b.words) << logWord
+}
+
+// Rank returns the number of members which are less than i.
+func (b *BitSet) Rank(i int) int {
+	if b == nil || i <= 0 {
+		return 0
+	}
+	w := i >> logWord
+	n := 0
+	for j := 0; j < w && j < len(b.words); j++ {
+		n += bits.OnesCount64(b.words[j])
+	}
+	if w < len(b.words) {
+		n += bits.OnesCount64(b.words[w] & (1<<(uint(i)&(wordBits-1)) - 1))
+	}
+	return n
+}
+
+// Select returns the k-th (0-based) smallest member.
+// If k is out of range [0, Count()), false is returned.
+//
+// Select is the inverse of [BitSet.Rank]: b.Rank(b.Select(k)) == k.
+func (b *BitSet) Select(k int) (int, bool) {
+	if b == nil || k < 0 {
+		return 0, false
+	}
+	for w, word := range b.words {
+		n := bits.OnesCount64(word)
+		if k >= n {
+			k -= n
+			continue
+		}
+		// Drop the lowest k set bits.
+		for ; k > 0; k-- {
+			word &= word - 1
+		}
+		return w<<logWord + bits.TrailingZeros64(word), true
+	}
+	return 0, false
+}
+
+// Range calls f sequentially for each member in ascending order.
+// If f returns false, range stops the iteration.
+func (b *BitSet) Range(f func(int) bool) {
+	if b == nil {
+		return
+	}
+	for w, word := range b.words {
+		for word != 0 {
+			if !f(w<<logWord + bits.TrailingZeros64(word)) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// wordsOf returns the underlying words, nil safe.
+func (b *BitSet) wordsOf() []uint64 {
+	if b == nil {
+		return nil
+	}
+	return b.words
+}
+
+// Union returns the union of sets as a new set.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.UnionInplace(other)
+	return res
+}
+
+// Intersect returns the intersection of sets as a new set.
+func (b *BitSet) Intersect(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.IntersectInplace(other)
+	return res
+}
+
+// Difference returns members of set b which are not in set other as a new set.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.DifferenceInplace(other)
+	return res
+}
+
+// SymmetricDiff returns members which are in either of sets but not in both
+// as a new set.
+func (b *BitSet) SymmetricDiff(other *BitSet) *BitSet {
+	res := b.Clone()
+	res.SymmetricDiffInplace(other)
+	return res
+}
+
+// UnionInplace updates set b with the union of itself and set other.
+func (b *BitSet) UnionInplace(other *BitSet) {
+	ow := other.wordsOf()
+	if len(ow) > len(b.words) {
+		b.grow(len(ow) - 1)
+	}
+	for i, w := range ow {
+		b.words[i] |= w
+	}
+}
+
+// IntersectInplace updates set b with the intersection of itself and set other.
+func (b *BitSet) IntersectInplace(other *BitSet) {
+	if b == nil {
+		return
+	}
+	ow := other.wordsOf()
+	for i := range b.words {
+		if i < len(ow) {
+			b.words[i] &= ow[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	b.trim()
+}
+
+// DifferenceInplace removes all members of set other from set b.
+func (b *BitSet) DifferenceInplace(other *BitSet) {
+	if b == nil {
+		return
+	}
+	ow := other.wordsOf()
+	for i := 0; i < len(b.words) && i < len(ow); i++ {
+		b.words[i] &^= ow[i]
+	}
+	b.trim()
+}
+
+// SymmetricDiffInplace updates set b with members which are in either of
+// sets but not in both.
+func (b *BitSet) SymmetricDiffInplace(other *BitSet) {
+	ow := other.wordsOf()
+	if len(ow) > len(b.words) {
+		b.grow(len(ow) - 1)
+	}
+	for i, w := range ow {
+		b.words[i] ^= w
+	}
+	b.trim()
+}
+
+// Equal returns whether set b and other have the same members.
+func (b *BitSet) Equal(other *BitSet) bool {
+	bw, ow := b.wordsOf(), other.wordsOf()
+	if len(bw) > len(ow) {
+		bw, ow = ow, bw
+	}
+	for i, w := range bw {
+		if w != ow[i] {
+			return false
+		}
+	}
+	for _, w := range ow[len(bw):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns whether set other contains all members of set b.
+func (b *BitSet) IsSubset(other *BitSet) bool {
+	bw, ow := b.wordsOf(), other.wordsOf()
+	for i, w := range bw {
+		var o uint64
+		if i < len(ow) {
+			o = ow[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns whether set b contains all members of set other.
+func (b *BitSet) IsSuperset(other *BitSet) bool {
+	return other.IsSubset(b)
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	res := &BitSet{}
+	if bw := b.wordsOf(); len(bw) != 0 {
+		res.words = make([]uint64, len(bw))
+		copy(res.words, bw)
+	}
+	return res
+}
+
+// ToSlice collects all members to slice in ascending order.
+func (b *BitSet) ToSlice() []int {
+	members := make([]int, 0, b.Count())
+	b.Range(func(i int) bool {
+		members = append(members, i)
+		return true
+	})
+	return members
+}
+
+// String implements [fmt.Stringer].
+//
+// Members are printed in ascending order, for example "bitset[1 3 5]".
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	sb.WriteString("bitset[")
+	first := true
+	b.Range(func(i int) bool {
+		if !first {
+			sb.WriteByte(' ')
+		}
+		first = false
+		sb.WriteString(strconv.Itoa(i))
+		return true
+	})
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+//
+// The returned bytes are the underlying 64-bit words in little-endian order,
+// trailing zero words are omitted.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	bw := b.wordsOf()
+	n := len(bw)
+	for n > 0 && bw[n-1] == 0 {
+		n--
+	}
+	data := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], bw[i])
+	}
+	return data, nil
+}
+
+// ErrInvalidBinary is returned by [BitSet.UnmarshalBinary] when the length
+// of data is not a multiple of 8.
+var ErrInvalidBinary = errors.New("bitset: invalid binary length")
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+//
+// 💡 NOTE: Always override original members.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidBinary, len(data))
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	b.trim()
+	return nil
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// NOTE: The returned bytes is null or JSON array. Elements of array are
+// sorted in ascending order.
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.ToSlice())
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// 💡 NOTE: Always override original members.
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+
+	var members []int
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for _, i := range members {
+		if i < 0 {
+			return fmt.Errorf("bitset: negative member: %d", i)
+		}
+	}
+	*b = *New(members...)
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitset
+
+import (
+	"fmt"
+)
+
+func Example() {
+	admins := New(1, 2)
+	editors := New(2, 3, 100)
+
+	fmt.Println(admins.Test(1), admins.Test(3))
+	fmt.Println(admins.Union(editors))
+	fmt.Println(admins.Intersect(editors))
+	fmt.Println(editors.Difference(admins))
+	fmt.Println(admins.SymmetricDiff(editors))
+	fmt.Println(editors.Count())
+
+	// Rank and Select
+	fmt.Println(editors.Rank(100))
+	fmt.Println(editors.Select(2))
+
+	// Iterate over members
+	for i, ok := editors.NextSet(0); ok; i, ok = editors.NextSet(i + 1) {
+		fmt.Print(i, " ")
+	}
+	fmt.Println()
+
+	// Output:
+	// true false
+	// bitset[1 2 3 100]
+	// bitset[2]
+	// bitset[3 100]
+	// bitset[1 3 100]
+	// 3
+	// 2
+	// 100 true
+	// 2 3 100
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitset
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bytedance/gg/collection/set"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestBitSetBasic(t *testing.T) {
+	var b BitSet
+	assert.True(t, b.IsEmpty())
+	assert.False(t, b.Test(0))
+	assert.False(t, b.Test(-1))
+
+	b.Set(0)
+	b.Set(63)
+	b.Set(64)
+	b.Set(1000)
+	assert.Equal(t, 4, b.Count())
+	assert.True(t, b.Test(0))
+	assert.True(t, b.Test(63))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(1000))
+	assert.False(t, b.Test(1))
+	assert.False(t, b.Test(100000))
+	assert.True(t, b.Cap() >= 1001)
+
+	b.Clear(63)
+	b.Clear(100000) // out of range
+	b.Clear(-1)
+	assert.False(t, b.Test(63))
+	assert.Equal(t, 3, b.Count())
+
+	b.Flip(0)
+	b.Flip(1)
+	b.Flip(2000)
+	assert.Equal(t, []int{1, 64, 1000, 2000}, b.ToSlice())
+	assert.Equal(t, "bitset[1 64 1000 2000]", b.String())
+
+	b.Reset()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, "bitset[]", b.String())
+
+	assert.Panic(t, func() { b.Set(-1) })
+	assert.Panic(t, func() { b.Flip(-1) })
+	assert.Panic(t, func() { NewWithCap(-1) })
+
+	c := NewWithCap(130)
+	assert.Equal(t, 192, c.Cap())
+	assert.True(t, c.IsEmpty())
+}
+
+func TestBitSetNil(t *testing.T) {
+	var b *BitSet
+	assert.False(t, b.Test(1))
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, 0, b.Cap())
+	assert.True(t, b.IsEmpty())
+	b.Clear(1)
+	b.Reset()
+	_, ok := b.NextSet(0)
+	assert.False(t, ok)
+	assert.Equal(t, 3, b.NextClear(3))
+	assert.Equal(t, 0, b.Rank(10))
+	_, ok = b.Select(0)
+	assert.False(t, ok)
+	b.Range(func(int) bool { panic("unreachable") })
+	assert.Equal(t, "bitset[]", b.String())
+	assert.True(t, b.Equal(New()))
+	assert.True(t, b.IsSubset(New(1)))
+	assert.True(t, New(1).IsSuperset(b))
+	assert.Equal(t, []int{1}, New(1).Union(b).ToSlice())
+	assert.Equal(t, []int{}, b.Union(nil).ToSlice())
+	b.IntersectInplace(New(1))
+	b.DifferenceInplace(New(1))
+}
+
+func TestBitSetNext(t *testing.T) {
+	b := New(3, 64, 65, 200)
+	var got []int
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		got = append(got, i)
+	}
+	assert.Equal(t, []int{3, 64, 65, 200}, got)
+
+	i, ok := b.NextSet(-5)
+	assert.Equal(t, 3, i)
+	assert.True(t, ok)
+	i, ok = b.NextSet(66)
+	assert.Equal(t, 200, i)
+	assert.True(t, ok)
+	_, ok = b.NextSet(201)
+	assert.False(t, ok)
+	_, ok = b.NextSet(10000)
+	assert.False(t, ok)
+
+	assert.Equal(t, 0, b.NextClear(-1))
+	assert.Equal(t, 4, b.NextClear(3))
+	assert.Equal(t, 66, b.NextClear(64))
+	assert.Equal(t, 201, b.NextClear(200))
+	assert.Equal(t, 10000, b.NextClear(10000))
+
+	full := New()
+	for i := 0; i < 128; i++ {
+		full.Set(i)
+	}
+	assert.Equal(t, 128, full.NextClear(0))
+	assert.Equal(t, 128, full.NextClear(100))
+	full.Clear(100)
+	assert.Equal(t, 100, full.NextClear(1))
+}
+
+func TestBitSetRankSelect(t *testing.T) {
+	b := New(3, 64, 65, 200)
+	assert.Equal(t, 0, b.Rank(-1))
+	assert.Equal(t, 0, b.Rank(3))
+	assert.Equal(t, 1, b.Rank(4))
+	assert.Equal(t, 1, b.Rank(64))
+	assert.Equal(t, 3, b.Rank(66))
+	assert.Equal(t, 3, b.Rank(200))
+	assert.Equal(t, 4, b.Rank(201))
+	assert.Equal(t, 4, b.Rank(100000))
+
+	for k, want := range []int{3, 64, 65, 200} {
+		i, ok := b.Select(k)
+		assert.True(t, ok)
+		assert.Equal(t, want, i)
+		assert.Equal(t, k, b.Rank(i))
+	}
+	_, ok := b.Select(4)
+	assert.False(t, ok)
+	_, ok = b.Select(-1)
+	assert.False(t, ok)
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	a := New(1, 2, 3, 100)
+	b := New(2, 3, 4, 300)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 100, 300}, a.Union(b).ToSlice())
+	assert.Equal(t, []int{2, 3}, a.Intersect(b).ToSlice())
+	assert.Equal(t, []int{1, 100}, a.Difference(b).ToSlice())
+	assert.Equal(t, []int{4, 300}, b.Difference(a).ToSlice())
+	assert.Equal(t, []int{1, 4, 100, 300}, a.SymmetricDiff(b).ToSlice())
+	// Copying operations do not modify operands.
+	assert.Equal(t, []int{1, 2, 3, 100}, a.ToSlice())
+	assert.Equal(t, []int{2, 3, 4, 300}, b.ToSlice())
+
+	{
+		c := a.Clone()
+		c.UnionInplace(b)
+		assert.Equal(t, []int{1, 2, 3, 4, 100, 300}, c.ToSlice())
+	}
+	{
+		c := b.Clone()
+		c.IntersectInplace(a)
+		assert.Equal(t, []int{2, 3}, c.ToSlice())
+		assert.Equal(t, 1, len(c.words)) // trimmed
+	}
+	{
+		c := a.Clone()
+		c.DifferenceInplace(b)
+		assert.Equal(t, []int{1, 100}, c.ToSlice())
+	}
+	{
+		c := a.Clone()
+		c.SymmetricDiffInplace(a)
+		assert.True(t, c.IsEmpty())
+		assert.Equal(t, 0, len(c.words))
+	}
+}
+
+func TestBitSetPredicates(t *testing.T) {
+	a := New(1, 2, 3)
+	assert.True(t, a.Equal(New(3, 2, 1)))
+	assert.False(t, a.Equal(New(1, 2)))
+	assert.False(t, a.Equal(New(1, 2, 3, 1000)))
+
+	// Trailing zero words are ignored.
+	b := New(1, 2, 3, 1000)
+	b.Clear(1000)
+	assert.True(t, a.Equal(b))
+	assert.True(t, b.Equal(a))
+
+	assert.True(t, New().IsSubset(a))
+	assert.True(t, New(1, 3).IsSubset(a))
+	assert.True(t, a.IsSubset(a))
+	assert.True(t, b.IsSubset(a))
+	assert.False(t, New(1, 4).IsSubset(a))
+	assert.False(t, New(1, 1000).IsSubset(a))
+	assert.True(t, a.IsSuperset(New(2)))
+	assert.False(t, a.IsSuperset(New(2, 200)))
+}
+
+func TestBitSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 5000
+	a, b := New(), New()
+	sa, sb := set.New[int](), set.New[int]()
+	for i := 0; i < 2000; i++ {
+		x, y := r.Intn(n), r.Intn(n/2)
+		a.Set(x)
+		sa.Add(x)
+		b.Set(y)
+		sb.Add(y)
+	}
+	sorted := func(s *set.Set[int]) []int {
+		res := s.ToSlice()
+		sort.Ints(res)
+		return res
+	}
+	symDiff := sa.Diff(sb).Union(sb.Diff(sa))
+	assert.Equal(t, sorted(sa), a.ToSlice())
+	assert.Equal(t, sorted(sa.Union(sb)), a.Union(b).ToSlice())
+	assert.Equal(t, sorted(sa.Intersect(sb)), a.Intersect(b).ToSlice())
+	assert.Equal(t, sorted(sa.Diff(sb)), a.Difference(b).ToSlice())
+	assert.Equal(t, sorted(symDiff), a.SymmetricDiff(b).ToSlice())
+
+	members := a.ToSlice()
+	for k, m := range members {
+		assert.Equal(t, k, a.Rank(m))
+		i, ok := a.Select(k)
+		assert.True(t, ok)
+		assert.Equal(t, m, i)
+	}
+}
+
+func TestBitSetBinary(t *testing.T) {
+	b := New(0, 9, 64, 1000)
+	data, err := b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, 16*8, len(data))
+
+	var c BitSet
+	assert.Nil(t, c.UnmarshalBinary(data))
+	assert.True(t, b.Equal(&c))
+
+	// Trailing zero words are omitted.
+	b.Clear(1000)
+	data, err = b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, 2*8, len(data))
+
+	// Always override.
+	assert.Nil(t, c.UnmarshalBinary(data))
+	assert.Equal(t, []int{0, 9, 64}, c.ToSlice())
+
+	var nilSet *BitSet
+	data, err = nilSet.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(data))
+	assert.Nil(t, c.UnmarshalBinary(data))
+	assert.True(t, c.IsEmpty())
+
+	err = c.UnmarshalBinary([]byte{1, 2, 3})
+	assert.True(t, errors.Is(err, ErrInvalidBinary))
+}
+
+func TestBitSetJSON(t *testing.T) {
+	type S struct {
+		B *BitSet `json:"b"`
+	}
+	bs, err := json.Marshal(S{New(5, 1, 300)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":[1,5,300]}`, string(bs))
+
+	bs, err = json.Marshal(S{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":null}`, string(bs))
+
+	bs, err = json.Marshal(New())
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(bs))
+
+	var s S
+	assert.Nil(t, json.Unmarshal([]byte(`{"b":[3,1,3]}`), &s))
+	assert.Equal(t, []int{1, 3}, s.B.ToSlice())
+
+	// Always override.
+	assert.Nil(t, json.Unmarshal([]byte(`[2]`), s.B))
+	assert.Equal(t, []int{2}, s.B.ToSlice())
+
+	// Null is a no-op.
+	assert.Nil(t, json.Unmarshal([]byte(`null`), s.B))
+	assert.Equal(t, []int{2}, s.B.ToSlice())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`[-1]`), s.B))
+	assert.NotNil(t, json.Unmarshal([]byte(`["a"]`), s.B))
+	assert.Equal(t, []int{2}, s.B.ToSlice())
+}
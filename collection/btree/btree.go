@@ -0,0 +1,541 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package btree provides in-memory B-tree based ordered map and set.
+//
+// Compared with [github.com/bytedance/gg/collection/skipmap] and
+// [github.com/bytedance/gg/collection/skipset], which are designed for heavy
+// concurrency, B-tree has no synchronization overhead and better memory
+// locality, it is the better choice for single goroutine use.
+//
+// 💡 NOTE: BTreeMap and BTreeSet are not concurrent-safe.
+//
+// # Structures
+//
+//   - [BTreeMap]
+//   - [BTreeSet]
+//
+// # Operations
+//
+//   - Constructor: [NewMap], [NewMapFunc], [NewMapFromSorted], [NewSet], …
+//   - CRUD operations: [BTreeMap.Store], [BTreeMap.Load], [BTreeMap.Delete], …
+//   - Ordered operations: [BTreeMap.Floor], [BTreeMap.Ceiling], [BTreeMap.Min], [BTreeMap.Max],
+//     [BTreeMap.DeleteMin], [BTreeMap.DeleteMax], …
+//   - Range operations: [BTreeMap.Ascend], [BTreeMap.Descend], [BTreeMap.AscendRange], [BTreeMap.DescendRange], …
+//
+// # Copy-on-write
+//
+// [BTreeMap.Clone] and [BTreeSet.Clone] are O(1): the original and the clone
+// share nodes lazily, and a node is copied when it is modified through either
+// of them.
+package btree
+
+import (
+	"fmt"
+	"sort"
+)
+
+// degree is the minimum degree of B-tree: every node other than the root
+// has [degree-1, 2*degree-1] items.
+const (
+	degree   = 16
+	maxItems = 2*degree - 1
+	minItems = degree - 1
+)
+
+type item[K, V any] struct {
+	key   K
+	value V
+}
+
+// cowCtx identifies the owner of nodes, a node can only be modified in place
+// by the tree with the same context.
+//
+// The field is required: pointers to distinct zero-size variables may be equal.
+type cowCtx struct{ _ byte }
+
+type node[K, V any] struct {
+	items    []item[K, V]
+	children []*node[K, V]
+	cow      *cowCtx
+}
+
+// tree is the underlying B-tree of BTreeMap and BTreeSet.
+type tree[K, V any] struct {
+	root   *node[K, V]
+	length int
+	less   func(a, b K) bool
+	cow    *cowCtx
+}
+
+func newTree[K, V any](less func(a, b K) bool) tree[K, V] {
+	return tree[K, V]{less: less, cow: new(cowCtx)}
+}
+
+func (t *tree[K, V]) newNode() *node[K, V] {
+	return &node[K, V]{cow: t.cow}
+}
+
+// mutableFor returns a node which can be modified by the tree with
+// context cow, n is copied if it is not owned by cow.
+func (n *node[K, V]) mutableFor(cow *cowCtx) *node[K, V] {
+	if n.cow == cow {
+		return n
+	}
+	c := &node[K, V]{cow: cow}
+	c.items = make([]item[K, V], len(n.items), cap(n.items))
+	copy(c.items, n.items)
+	if len(n.children) != 0 {
+		c.children = make([]*node[K, V], len(n.children), cap(n.children))
+		copy(c.children, n.children)
+	}
+	return c
+}
+
+func (n *node[K, V]) mutableChild(i int) *node[K, V] {
+	c := n.children[i].mutableFor(n.cow)
+	n.children[i] = c
+	return c
+}
+
+// find returns the index of the first item whose key is not less than key,
+// and whether the key of the item equals to key.
+func (n *node[K, V]) find(key K, less func(a, b K) bool) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return !less(n.items[i].key, key)
+	})
+	return i, i < len(n.items) && !less(key, n.items[i].key)
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	s = append(s, v)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+func removeAt[T any](s []T, i int) ([]T, T) {
+	v := s[i]
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1], v
+}
+
+func truncate[T any](s []T, n int) []T {
+	var zero T
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:n]
+}
+
+// split splits n at index i, returns the item at i and a new node
+// containing items after i.
+func (n *node[K, V]) split(i int) (item[K, V], *node[K, V]) {
+	it := n.items[i]
+	next := &node[K, V]{cow: n.cow}
+	next.items = append(make([]item[K, V], 0, maxItems), n.items[i+1:]...)
+	n.items = truncate(n.items, i)
+	if len(n.children) != 0 {
+		next.children = append(make([]*node[K, V], 0, maxItems+1), n.children[i+1:]...)
+		n.children = truncate(n.children, i+1)
+	}
+	return it, next
+}
+
+// maybeSplitChild splits the i-th child if it is full,
+// it returns whether the child is split.
+func (n *node[K, V]) maybeSplitChild(i int) bool {
+	if len(n.children[i].items) < maxItems {
+		return false
+	}
+	first := n.mutableChild(i)
+	it, second := first.split(maxItems / 2)
+	n.items = insertAt(n.items, i, it)
+	n.children = insertAt(n.children, i+1, second)
+	return true
+}
+
+// insert inserts it into the subtree rooted at n, n must not be full.
+// It returns whether an existing item is replaced.
+func (n *node[K, V]) insert(it item[K, V], less func(a, b K) bool) bool {
+	i, found := n.find(it.key, less)
+	if found {
+		n.items[i] = it
+		return true
+	}
+	if len(n.children) == 0 {
+		n.items = insertAt(n.items, i, it)
+		return false
+	}
+	if n.maybeSplitChild(i) {
+		switch mid := n.items[i].key; {
+		case less(it.key, mid):
+		case less(mid, it.key):
+			i++
+		default:
+			n.items[i] = it
+			return true
+		}
+	}
+	return n.mutableChild(i).insert(it, less)
+}
+
+func (t *tree[K, V]) store(key K, value V) bool {
+	it := item[K, V]{key, value}
+	if t.root == nil {
+		t.root = t.newNode()
+		t.root.items = append(make([]item[K, V], 0, maxItems), it)
+		t.length++
+		return false
+	}
+	t.root = t.root.mutableFor(t.cow)
+	if len(t.root.items) >= maxItems {
+		mid, second := t.root.split(maxItems / 2)
+		old := t.root
+		t.root = t.newNode()
+		t.root.items = append(make([]item[K, V], 0, maxItems), mid)
+		t.root.children = append(make([]*node[K, V], 0, maxItems+1), old, second)
+	}
+	replaced := t.root.insert(it, t.less)
+	if !replaced {
+		t.length++
+	}
+	return replaced
+}
+
+func (t *tree[K, V]) load(key K) (*item[K, V], bool) {
+	for n := t.root; n != nil; {
+		i, found := n.find(key, t.less)
+		if found {
+			return &n.items[i], true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return nil, false
+}
+
+type removeKind int
+
+const (
+	removeItem removeKind = iota
+	removeMin
+	removeMax
+)
+
+// remove removes an item from the subtree rooted at n.
+func (n *node[K, V]) remove(key K, kind removeKind, less func(a, b K) bool) (item[K, V], bool) {
+	var i int
+	var found bool
+	switch kind {
+	case removeMax:
+		if len(n.children) == 0 {
+			var it item[K, V]
+			n.items, it = removeAt(n.items, len(n.items)-1)
+			return it, true
+		}
+		i = len(n.items)
+	case removeMin:
+		if len(n.children) == 0 {
+			var it item[K, V]
+			n.items, it = removeAt(n.items, 0)
+			return it, true
+		}
+		i = 0
+	default:
+		i, found = n.find(key, less)
+		if len(n.children) == 0 {
+			if !found {
+				return item[K, V]{}, false
+			}
+			var it item[K, V]
+			n.items, it = removeAt(n.items, i)
+			return it, true
+		}
+	}
+	// Make sure the child to descend has enough items.
+	if len(n.children[i].items) <= minItems {
+		n.growChild(i)
+		return n.remove(key, kind, less)
+	}
+	child := n.mutableChild(i)
+	if found {
+		// Replace the item with its predecessor.
+		it := n.items[i]
+		n.items[i], _ = child.remove(key, removeMax, less)
+		return it, true
+	}
+	return child.remove(key, kind, less)
+}
+
+// growChild makes the i-th child have more than minItems items, by stealing
+// an item from its sibling or merging with its sibling.
+func (n *node[K, V]) growChild(i int) {
+	if i > 0 && len(n.children[i-1].items) > minItems {
+		// Steal from left sibling.
+		child, left := n.mutableChild(i), n.mutableChild(i-1)
+		var stolen item[K, V]
+		left.items, stolen = removeAt(left.items, len(left.items)-1)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = stolen
+		if len(left.children) != 0 {
+			var c *node[K, V]
+			left.children, c = removeAt(left.children, len(left.children)-1)
+			child.children = insertAt(child.children, 0, c)
+		}
+	} else if i < len(n.items) && len(n.children[i+1].items) > minItems {
+		// Steal from right sibling.
+		child, right := n.mutableChild(i), n.mutableChild(i+1)
+		var stolen item[K, V]
+		right.items, stolen = removeAt(right.items, 0)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = stolen
+		if len(right.children) != 0 {
+			var c *node[K, V]
+			right.children, c = removeAt(right.children, 0)
+			child.children = append(child.children, c)
+		}
+	} else {
+		// Merge with right sibling.
+		if i >= len(n.items) {
+			i--
+		}
+		child := n.mutableChild(i)
+		var mid item[K, V]
+		var right *node[K, V]
+		n.items, mid = removeAt(n.items, i)
+		n.children, right = removeAt(n.children, i+1)
+		child.items = append(child.items, mid)
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+	}
+}
+
+func (t *tree[K, V]) remove(key K, kind removeKind) (item[K, V], bool) {
+	if t.root == nil || len(t.root.items) == 0 {
+		return item[K, V]{}, false
+	}
+	t.root = t.root.mutableFor(t.cow)
+	it, ok := t.root.remove(key, kind, t.less)
+	if len(t.root.items) == 0 && len(t.root.children) != 0 {
+		t.root = t.root.children[0]
+	}
+	if ok {
+		t.length--
+	}
+	return it, ok
+}
+
+func (t *tree[K, V]) min() (*item[K, V], bool) {
+	n := t.root
+	if n == nil || len(n.items) == 0 {
+		return nil, false
+	}
+	for len(n.children) != 0 {
+		n = n.children[0]
+	}
+	return &n.items[0], true
+}
+
+func (t *tree[K, V]) max() (*item[K, V], bool) {
+	n := t.root
+	if n == nil || len(n.items) == 0 {
+		return nil, false
+	}
+	for len(n.children) != 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return &n.items[len(n.items)-1], true
+}
+
+// floor returns the item with the greatest key less than or equal to key.
+func (t *tree[K, V]) floor(key K) (*item[K, V], bool) {
+	var res *item[K, V]
+	for n := t.root; n != nil; {
+		i, found := n.find(key, t.less)
+		if found {
+			return &n.items[i], true
+		}
+		if i > 0 {
+			res = &n.items[i-1]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return res, res != nil
+}
+
+// ceiling returns the item with the least key greater than or equal to key.
+func (t *tree[K, V]) ceiling(key K) (*item[K, V], bool) {
+	var res *item[K, V]
+	for n := t.root; n != nil; {
+		i, found := n.find(key, t.less)
+		if found {
+			return &n.items[i], true
+		}
+		if i < len(n.items) {
+			res = &n.items[i]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return res, res != nil
+}
+
+// ascend calls f for items in [lo, hi) in ascending order,
+// nil bound means unbounded.
+func (n *node[K, V]) ascend(lo, hi *K, less func(a, b K) bool, f func(*item[K, V]) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = n.find(*lo, less)
+	}
+	for ; i < len(n.items); i++ {
+		if len(n.children) != 0 && !n.children[i].ascend(lo, hi, less, f) {
+			return false
+		}
+		if hi != nil && !less(n.items[i].key, *hi) {
+			return false
+		}
+		if !f(&n.items[i]) {
+			return false
+		}
+	}
+	if len(n.children) != 0 {
+		return n.children[len(n.items)].ascend(lo, hi, less, f)
+	}
+	return true
+}
+
+// descend calls f for items in [lo, hi) in descending order,
+// nil bound means unbounded.
+func (n *node[K, V]) descend(lo, hi *K, less func(a, b K) bool, f func(*item[K, V]) bool) bool {
+	i := len(n.items)
+	if hi != nil {
+		i, _ = n.find(*hi, less)
+	}
+	if len(n.children) != 0 && !n.children[i].descend(lo, hi, less, f) {
+		return false
+	}
+	for i--; i >= 0; i-- {
+		if lo != nil && less(n.items[i].key, *lo) {
+			return false
+		}
+		if !f(&n.items[i]) {
+			return false
+		}
+		if len(n.children) != 0 && !n.children[i].descend(lo, hi, less, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *tree[K, V]) ascend(lo, hi *K, f func(*item[K, V]) bool) {
+	if t.root != nil {
+		t.root.ascend(lo, hi, t.less, f)
+	}
+}
+
+func (t *tree[K, V]) descend(lo, hi *K, f func(*item[K, V]) bool) {
+	if t.root != nil {
+		t.root.descend(lo, hi, t.less, f)
+	}
+}
+
+// clone returns a copy of t, nodes are shared in copy-on-write manner.
+func (t *tree[K, V]) clone() tree[K, V] {
+	// Both trees lose the ownership of existing nodes.
+	t.cow = new(cowCtx)
+	c := *t
+	c.cow = new(cowCtx)
+	return c
+}
+
+func (t *tree[K, V]) clear() {
+	t.root = nil
+	t.length = 0
+}
+
+// buildSorted builds t from items with strictly ascending keys.
+//
+// 💡 NOTE: It panics if keys of items are not strictly ascending.
+func (t *tree[K, V]) buildSorted(items []item[K, V]) {
+	for i := 1; i < len(items); i++ {
+		if !t.less(items[i-1].key, items[i].key) {
+			panic(fmt.Errorf("keys must be strictly ascending: index %d", i))
+		}
+	}
+	t.clear()
+	if len(items) == 0 {
+		return
+	}
+	// Find the minimum height which can hold all items.
+	h := 0
+	for maxSubtree(h) < len(items) {
+		h++
+	}
+	t.root = t.build(items, h, true)
+	t.length = len(items)
+}
+
+// maxSubtree returns the maximum number of items of a subtree with height h.
+func maxSubtree(h int) int {
+	n := maxItems + 1
+	for ; h > 0; h-- {
+		n *= maxItems + 1
+	}
+	return n - 1
+}
+
+// build builds a subtree of height h from sorted items.
+//
+// The items are distributed evenly between children, so every node
+// satisfies the occupancy requirement of B-tree.
+func (t *tree[K, V]) build(items []item[K, V], h int, root bool) *node[K, V] {
+	n := t.newNode()
+	if h == 0 {
+		n.items = append(make([]item[K, V], 0, maxItems), items...)
+		return n
+	}
+	sub := maxSubtree(h - 1)
+	k := (len(items) + 1 + sub) / (sub + 1) // number of children
+	if !root && k < minItems+1 {
+		k = minItems + 1
+	}
+	n.items = make([]item[K, V], 0, maxItems)
+	n.children = make([]*node[K, V], 0, maxItems+1)
+	total := len(items) - (k - 1)
+	pos := 0
+	for j := 0; j < k; j++ {
+		size := total / k
+		if j < total%k {
+			size++
+		}
+		n.children = append(n.children, t.build(items[pos:pos+size], h-1, false))
+		pos += size
+		if j < k-1 {
+			n.items = append(n.items, items[pos])
+			pos++
+		}
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"fmt"
+
+	"github.com/bytedance/gg/collection/tuple"
+)
+
+func Example() {
+	m := NewMap[int, string]()
+	m.Store(30, "thirty")
+	m.Store(10, "ten")
+	m.Store(20, "twenty")
+
+	fmt.Println(m.Load(20))
+	fmt.Println(m.Floor(25).Value())
+	fmt.Println(m.Ceiling(25).Value())
+	fmt.Println(m.Min().Value().First, m.Max().Value().First)
+
+	m.DescendRange(10, 30, func(k int, v string) bool {
+		fmt.Println(k, v)
+		return true
+	})
+
+	// Clone is cheap, the clone is not affected by later modifications.
+	snapshot := m.Clone()
+	m.DeleteMin()
+	fmt.Println(m.Keys(), snapshot.Keys())
+
+	// Output:
+	// twenty true
+	// {20 twenty}
+	// {30 thirty}
+	// 10 30
+	// 20 twenty
+	// 10 ten
+	// [20 30] [10 20 30]
+}
+
+func ExampleNewSetFromSorted() {
+	s := NewSetFromSorted([]string{"apple", "banana", "cherry"})
+	s.Add("blueberry")
+	fmt.Println(s.ToSlice())
+	fmt.Println(s.Ceiling("c").Value())
+
+	m := NewMapFromSorted([]tuple.T2[int, bool]{tuple.Make2(1, true), tuple.Make2(2, false)})
+	fmt.Println(m.Len())
+
+	// Output:
+	// [apple banana blueberry cherry]
+	// cherry
+	// 2
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// BTreeMap is an ordered map based on B-tree.
+//
+// Use [NewMap] or [NewMapFunc] to create a BTreeMap.
+type BTreeMap[K, V any] struct {
+	t tree[K, V]
+}
+
+func lessOrdered[T constraints.Ordered](a, b T) bool {
+	return a < b
+}
+
+// NewMap creates an empty map with keys in ascending order.
+func NewMap[K constraints.Ordered, V any]() *BTreeMap[K, V] {
+	return &BTreeMap[K, V]{newTree[K, V](lessOrdered[K])}
+}
+
+// NewMapFunc creates an empty map with keys ordered by less function.
+func NewMapFunc[K, V any](less func(a, b K) bool) *BTreeMap[K, V] {
+	return &BTreeMap[K, V]{newTree[K, V](less)}
+}
+
+// NewMapFromSorted creates a map from items whose keys are strictly
+// ascending, in O(n) time.
+//
+// 💡 NOTE: It panics if keys are not strictly ascending.
+func NewMapFromSorted[K constraints.Ordered, V any](items []tuple.T2[K, V]) *BTreeMap[K, V] {
+	return NewMapFuncFromSorted(lessOrdered[K], items)
+}
+
+// NewMapFuncFromSorted is a variant of [NewMapFromSorted], keys are ordered
+// by less function.
+func NewMapFuncFromSorted[K, V any](less func(a, b K) bool, items []tuple.T2[K, V]) *BTreeMap[K, V] {
+	m := NewMapFunc[K, V](less)
+	its := make([]item[K, V], len(items))
+	for i, it := range items {
+		its[i] = item[K, V]{it.First, it.Second}
+	}
+	m.t.buildSorted(its)
+	return m
+}
+
+func toTuple[K, V any](it *item[K, V], ok bool) goption.O[tuple.T2[K, V]] {
+	if !ok {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	return goption.OK(tuple.Make2(it.key, it.value))
+}
+
+// Len returns the number of items in the map.
+func (m *BTreeMap[K, V]) Len() int {
+	return m.t.length
+}
+
+// Store sets the value for a key.
+func (m *BTreeMap[K, V]) Store(key K, value V) {
+	m.t.store(key, value)
+}
+
+// Load returns the value stored in the map for a key, or zero value if no
+// value is present.
+// The ok result indicates whether value was found in the map.
+func (m *BTreeMap[K, V]) Load(key K) (value V, ok bool) {
+	it, ok := m.t.load(key)
+	if !ok {
+		return value, false
+	}
+	return it.value, true
+}
+
+// Contains returns whether the key is in the map.
+func (m *BTreeMap[K, V]) Contains(key K) bool {
+	_, ok := m.t.load(key)
+	return ok
+}
+
+// Delete deletes the value for a key.
+// It returns whether the key was present.
+func (m *BTreeMap[K, V]) Delete(key K) bool {
+	_, ok := m.t.remove(key, removeItem)
+	return ok
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *BTreeMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	it, ok := m.t.remove(key, removeItem)
+	return it.value, ok
+}
+
+// Min returns the item with the least key.
+func (m *BTreeMap[K, V]) Min() goption.O[tuple.T2[K, V]] {
+	return toTuple(m.t.min())
+}
+
+// Max returns the item with the greatest key.
+func (m *BTreeMap[K, V]) Max() goption.O[tuple.T2[K, V]] {
+	return toTuple(m.t.max())
+}
+
+// Floor returns the item with the greatest key less than or equal to key.
+func (m *BTreeMap[K, V]) Floor(key K) goption.O[tuple.T2[K, V]] {
+	return toTuple(m.t.floor(key))
+}
+
+// Ceiling returns the item with the least key greater than or equal to key.
+func (m *BTreeMap[K, V]) Ceiling(key K) goption.O[tuple.T2[K, V]] {
+	return toTuple(m.t.ceiling(key))
+}
+
+// DeleteMin removes and returns the item with the least key.
+func (m *BTreeMap[K, V]) DeleteMin() goption.O[tuple.T2[K, V]] {
+	it, ok := m.t.remove(*new(K), removeMin)
+	return toTuple(&it, ok)
+}
+
+// DeleteMax removes and returns the item with the greatest key.
+func (m *BTreeMap[K, V]) DeleteMax() goption.O[tuple.T2[K, V]] {
+	it, ok := m.t.remove(*new(K), removeMax)
+	return toTuple(&it, ok)
+}
+
+// Ascend calls f sequentially for each key and value in ascending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The map must not be modified during the iteration.
+func (m *BTreeMap[K, V]) Ascend(f func(key K, value V) bool) {
+	m.t.ascend(nil, nil, func(it *item[K, V]) bool { return f(it.key, it.value) })
+}
+
+// Descend calls f sequentially for each key and value in descending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The map must not be modified during the iteration.
+func (m *BTreeMap[K, V]) Descend(f func(key K, value V) bool) {
+	m.t.descend(nil, nil, func(it *item[K, V]) bool { return f(it.key, it.value) })
+}
+
+// AscendRange calls f sequentially for each key in range [lo, hi) and its
+// value in ascending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The map must not be modified during the iteration.
+func (m *BTreeMap[K, V]) AscendRange(lo, hi K, f func(key K, value V) bool) {
+	m.t.ascend(&lo, &hi, func(it *item[K, V]) bool { return f(it.key, it.value) })
+}
+
+// DescendRange calls f sequentially for each key in range [lo, hi) and its
+// value in descending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The map must not be modified during the iteration.
+func (m *BTreeMap[K, V]) DescendRange(lo, hi K, f func(key K, value V) bool) {
+	m.t.descend(&lo, &hi, func(it *item[K, V]) bool { return f(it.key, it.value) })
+}
+
+// Keys returns all keys in ascending order.
+func (m *BTreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.t.ascend(nil, nil, func(it *item[K, V]) bool {
+		keys = append(keys, it.key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in ascending order of keys.
+func (m *BTreeMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	m.t.ascend(nil, nil, func(it *item[K, V]) bool {
+		values = append(values, it.value)
+		return true
+	})
+	return values
+}
+
+// Clear removes all items from the map.
+func (m *BTreeMap[K, V]) Clear() {
+	m.t.clear()
+}
+
+// Clone returns a copy of the map in O(1) time.
+//
+// The map and its clone share nodes until they are modified, modifications
+// on one of them are invisible to the other.
+//
+// 💡 NOTE: Values are copied using assignment (=).
+func (m *BTreeMap[K, V]) Clone() *BTreeMap[K, V] {
+	return &BTreeMap[K, V]{m.t.clone()}
+}
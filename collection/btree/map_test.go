@@ -0,0 +1,355 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+// checkTree verifies the invariants of B-tree.
+func checkTree[K, V any](t *testing.T, tr *tree[K, V]) {
+	t.Helper()
+	if tr.root == nil {
+		assert.Equal(t, 0, tr.length)
+		return
+	}
+	leafDepth := -1
+	count := 0
+	var check func(n *node[K, V], depth int, lo, hi *K)
+	check = func(n *node[K, V], depth int, lo, hi *K) {
+		if n != tr.root {
+			assert.True(t, len(n.items) >= minItems)
+		}
+		assert.True(t, len(n.items) <= maxItems)
+		count += len(n.items)
+		for i, it := range n.items {
+			if i > 0 {
+				assert.True(t, tr.less(n.items[i-1].key, it.key))
+			}
+			if lo != nil {
+				assert.True(t, tr.less(*lo, it.key))
+			}
+			if hi != nil {
+				assert.True(t, tr.less(it.key, *hi))
+			}
+		}
+		if len(n.children) == 0 {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			assert.Equal(t, leafDepth, depth)
+			return
+		}
+		assert.Equal(t, len(n.items)+1, len(n.children))
+		for i, c := range n.children {
+			clo, chi := lo, hi
+			if i > 0 {
+				clo = &n.items[i-1].key
+			}
+			if i < len(n.items) {
+				chi = &n.items[i].key
+			}
+			check(c, depth+1, clo, chi)
+		}
+	}
+	check(tr.root, 0, nil, nil)
+	assert.Equal(t, tr.length, count)
+}
+
+func TestBTreeMap(t *testing.T) {
+	m := NewMap[int, string]()
+	assert.Equal(t, 0, m.Len())
+	assert.False(t, m.Min().IsOK())
+	assert.False(t, m.Max().IsOK())
+	assert.False(t, m.Floor(1).IsOK())
+	assert.False(t, m.Ceiling(1).IsOK())
+	assert.False(t, m.DeleteMin().IsOK())
+	assert.False(t, m.DeleteMax().IsOK())
+	assert.False(t, m.Delete(1))
+
+	for i := 0; i < 100; i += 10 {
+		m.Store(i, "v")
+	}
+	m.Store(50, "fifty")
+	assert.Equal(t, 10, m.Len())
+	v, ok := m.Load(50)
+	assert.True(t, ok)
+	assert.Equal(t, "fifty", v)
+	_, ok = m.Load(55)
+	assert.False(t, ok)
+	assert.True(t, m.Contains(0))
+	assert.False(t, m.Contains(1))
+
+	assert.Equal(t, goption.OK(tuple.Make2(0, "v")), m.Min())
+	assert.Equal(t, goption.OK(tuple.Make2(90, "v")), m.Max())
+	assert.Equal(t, goption.OK(tuple.Make2(50, "fifty")), m.Floor(50))
+	assert.Equal(t, goption.OK(tuple.Make2(50, "fifty")), m.Floor(59))
+	assert.Equal(t, goption.OK(tuple.Make2(50, "fifty")), m.Ceiling(41))
+	assert.False(t, m.Floor(-1).IsOK())
+	assert.False(t, m.Ceiling(91).IsOK())
+
+	v, ok = m.LoadAndDelete(50)
+	assert.True(t, ok)
+	assert.Equal(t, "fifty", v)
+	_, ok = m.LoadAndDelete(50)
+	assert.False(t, ok)
+
+	assert.Equal(t, goption.OK(tuple.Make2(0, "v")), m.DeleteMin())
+	assert.Equal(t, goption.OK(tuple.Make2(90, "v")), m.DeleteMax())
+	assert.Equal(t, []int{10, 20, 30, 40, 60, 70, 80}, m.Keys())
+	assert.Equal(t, 7, len(m.Values()))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, []int{}, m.Keys())
+}
+
+func TestBTreeMapRange(t *testing.T) {
+	m := NewMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Store(i*2, i)
+	}
+	collect := func(it func(f func(int, int) bool)) []int {
+		var res []int
+		it(func(k, _ int) bool {
+			res = append(res, k)
+			return true
+		})
+		return res
+	}
+	all := collect(m.Ascend)
+	assert.Equal(t, 1000, len(all))
+	assert.True(t, sort.IntsAreSorted(all))
+	desc := collect(m.Descend)
+	for i := range desc {
+		assert.Equal(t, all[len(all)-1-i], desc[i])
+	}
+
+	for _, c := range [][2]int{{0, 0}, {0, 1}, {1, 2}, {1, 3}, {100, 201}, {-10, 10}, {1990, 3000}, {500, 400}} {
+		lo, hi := c[0], c[1]
+		var want []int
+		for _, k := range all {
+			if k >= lo && k < hi {
+				want = append(want, k)
+			}
+		}
+		asc := collect(func(f func(int, int) bool) { m.AscendRange(lo, hi, f) })
+		assert.Equal(t, want, asc)
+		desc := collect(func(f func(int, int) bool) { m.DescendRange(lo, hi, f) })
+		assert.Equal(t, len(want), len(desc))
+		for i := range desc {
+			assert.Equal(t, want[len(want)-1-i], desc[i])
+		}
+	}
+
+	// Early stop.
+	n := 0
+	m.AscendRange(10, 1000, func(int, int) bool { n++; return n < 3 })
+	assert.Equal(t, 3, n)
+	n = 0
+	m.DescendRange(10, 1000, func(int, int) bool { n++; return n < 3 })
+	assert.Equal(t, 3, n)
+	n = 0
+	m.Descend(func(int, int) bool { n++; return n < 100 })
+	assert.Equal(t, 100, n)
+}
+
+func TestBTreeMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewMap[int, int]()
+	ref := map[int]int{}
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(2000)
+		switch r.Intn(5) {
+		case 0, 1:
+			m.Store(k, i)
+			ref[k] = i
+		case 2:
+			_, ok := ref[k]
+			assert.Equal(t, ok, m.Delete(k))
+			delete(ref, k)
+		case 3:
+			o := m.DeleteMin()
+			if len(ref) == 0 {
+				assert.False(t, o.IsOK())
+				break
+			}
+			minK := o.Value().First
+			for k := range ref {
+				assert.True(t, minK <= k)
+			}
+			assert.Equal(t, ref[minK], o.Value().Second)
+			delete(ref, minK)
+		case 4:
+			o := m.DeleteMax()
+			if len(ref) == 0 {
+				assert.False(t, o.IsOK())
+				break
+			}
+			maxK := o.Value().First
+			for k := range ref {
+				assert.True(t, maxK >= k)
+			}
+			delete(ref, maxK)
+		}
+		assert.Equal(t, len(ref), m.Len())
+		if i%1000 == 0 {
+			checkTree(t, &m.t)
+		}
+	}
+	checkTree(t, &m.t)
+
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, keys, m.Keys())
+	for q := -1; q <= 2001; q++ {
+		i := sort.SearchInts(keys, q)
+		if i < len(keys) {
+			assert.Equal(t, keys[i], m.Ceiling(q).Value().First)
+		} else {
+			assert.False(t, m.Ceiling(q).IsOK())
+		}
+		if i < len(keys) && keys[i] == q {
+			assert.Equal(t, q, m.Floor(q).Value().First)
+		} else if i > 0 {
+			assert.Equal(t, keys[i-1], m.Floor(q).Value().First)
+		} else {
+			assert.False(t, m.Floor(q).IsOK())
+		}
+	}
+}
+
+func TestBTreeMapClone(t *testing.T) {
+	m := NewMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Store(i, i)
+	}
+	c := m.Clone()
+	for i := 0; i < 1000; i += 2 {
+		m.Delete(i)
+		c.Store(i, -i)
+	}
+	m.Store(5000, 5000)
+	c2 := c.Clone()
+	c2.Clear()
+	c2.Store(1, 1)
+
+	assert.Equal(t, 501, m.Len())
+	assert.Equal(t, 1000, c.Len())
+	assert.Equal(t, 1, c2.Len())
+	for i := 0; i < 1000; i++ {
+		v, ok := m.Load(i)
+		assert.Equal(t, i%2 == 1, ok)
+		if ok {
+			assert.Equal(t, i, v)
+		}
+		v, ok = c.Load(i)
+		assert.True(t, ok)
+		if i%2 == 0 {
+			assert.Equal(t, -i, v)
+		} else {
+			assert.Equal(t, i, v)
+		}
+	}
+	assert.False(t, c.Contains(5000))
+	checkTree(t, &m.t)
+	checkTree(t, &c.t)
+	checkTree(t, &c2.t)
+}
+
+func TestNewMapFromSorted(t *testing.T) {
+	for _, n := range []int{0, 1, 2, maxItems - 1, maxItems, maxItems + 1, 100, 1023, 1024, 1025, 40000} {
+		items := make([]tuple.T2[int, int], n)
+		for i := range items {
+			items[i] = tuple.Make2(i, i*i)
+		}
+		m := NewMapFromSorted(items)
+		checkTree(t, &m.t)
+		assert.Equal(t, n, m.Len())
+		if n > 0 {
+			assert.Equal(t, n-1, m.Max().Value().First)
+			v, _ := m.Load(n / 2)
+			assert.Equal(t, (n/2)*(n/2), v)
+		}
+		// The tree is still valid after modifications.
+		for i := 0; i < n; i += 3 {
+			m.Delete(i)
+		}
+		m.Store(-1, 0)
+		checkTree(t, &m.t)
+	}
+
+	assert.Panic(t, func() {
+		NewMapFromSorted([]tuple.T2[int, int]{tuple.Make2(1, 1), tuple.Make2(1, 1)})
+	})
+	assert.Panic(t, func() {
+		NewMapFromSorted([]tuple.T2[int, int]{tuple.Make2(2, 1), tuple.Make2(1, 1)})
+	})
+}
+
+func TestNewMapFunc(t *testing.T) {
+	m := NewMapFunc[string, int](func(a, b string) bool { return len(a) < len(b) })
+	m.Store("aaa", 3)
+	m.Store("a", 1)
+	m.Store("bb", 2)
+	m.Store("cc", 22) // same length as "bb"
+	assert.Equal(t, []string{"a", "cc", "aaa"}, m.Keys())
+	assert.Equal(t, []int{1, 22, 3}, m.Values())
+}
+
+func TestBTreeMapCloneRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	m := NewMap[int, int]()
+	type snapshot struct {
+		m    *BTreeMap[int, int]
+		keys []int
+	}
+	var snapshots []snapshot
+	for i := 0; i < 10000; i++ {
+		k := r.Intn(1000)
+		if r.Intn(2) == 0 {
+			m.Store(k, i)
+		} else {
+			m.Delete(k)
+		}
+		if i%500 == 0 {
+			c := m.Clone()
+			snapshots = append(snapshots, snapshot{c, c.Keys()})
+			// Modifying a clone of clone does not affect the clone.
+			cc := c.Clone()
+			for j := 0; j < 200; j++ {
+				cc.Delete(r.Intn(1000))
+				cc.Store(r.Intn(1000), j)
+			}
+			cc.DeleteMin()
+			cc.DeleteMax()
+			checkTree(t, &cc.t)
+		}
+	}
+	for _, s := range snapshots {
+		assert.Equal(t, s.keys, s.m.Keys())
+		checkTree(t, &s.m.t)
+	}
+	checkTree(t, &m.t)
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// BTreeSet is an ordered set based on B-tree.
+//
+// Use [NewSet] or [NewSetFunc] to create a BTreeSet.
+type BTreeSet[T any] struct {
+	t tree[T, struct{}]
+}
+
+// NewSet creates a set with initial members in ascending order.
+func NewSet[T constraints.Ordered](members ...T) *BTreeSet[T] {
+	return NewSetFunc(lessOrdered[T], members...)
+}
+
+// NewSetFunc creates a set with initial members ordered by less function.
+func NewSetFunc[T any](less func(a, b T) bool, members ...T) *BTreeSet[T] {
+	s := &BTreeSet[T]{newTree[T, struct{}](less)}
+	for _, v := range members {
+		s.Add(v)
+	}
+	return s
+}
+
+// NewSetFromSorted creates a set from strictly ascending members, in O(n) time.
+//
+// 💡 NOTE: It panics if members are not strictly ascending.
+func NewSetFromSorted[T constraints.Ordered](members []T) *BTreeSet[T] {
+	return NewSetFuncFromSorted(lessOrdered[T], members)
+}
+
+// NewSetFuncFromSorted is a variant of [NewSetFromSorted], members are
+// ordered by less function.
+func NewSetFuncFromSorted[T any](less func(a, b T) bool, members []T) *BTreeSet[T] {
+	s := NewSetFunc(less)
+	its := make([]item[T, struct{}], len(members))
+	for i, v := range members {
+		its[i].key = v
+	}
+	s.t.buildSorted(its)
+	return s
+}
+
+func toMember[T any](it *item[T, struct{}], ok bool) goption.O[T] {
+	if !ok {
+		return goption.Nil[T]()
+	}
+	return goption.OK(it.key)
+}
+
+// Len returns the number of members in the set.
+func (s *BTreeSet[T]) Len() int {
+	return s.t.length
+}
+
+// Add adds v to the set.
+// It returns whether v was not in the set.
+func (s *BTreeSet[T]) Add(v T) bool {
+	return !s.t.store(v, struct{}{})
+}
+
+// Remove removes v from the set.
+// It returns whether v was in the set.
+func (s *BTreeSet[T]) Remove(v T) bool {
+	_, ok := s.t.remove(v, removeItem)
+	return ok
+}
+
+// Contains returns whether v is in the set.
+func (s *BTreeSet[T]) Contains(v T) bool {
+	_, ok := s.t.load(v)
+	return ok
+}
+
+// Min returns the least member.
+func (s *BTreeSet[T]) Min() goption.O[T] {
+	return toMember(s.t.min())
+}
+
+// Max returns the greatest member.
+func (s *BTreeSet[T]) Max() goption.O[T] {
+	return toMember(s.t.max())
+}
+
+// Floor returns the greatest member less than or equal to v.
+func (s *BTreeSet[T]) Floor(v T) goption.O[T] {
+	return toMember(s.t.floor(v))
+}
+
+// Ceiling returns the least member greater than or equal to v.
+func (s *BTreeSet[T]) Ceiling(v T) goption.O[T] {
+	return toMember(s.t.ceiling(v))
+}
+
+// DeleteMin removes and returns the least member.
+func (s *BTreeSet[T]) DeleteMin() goption.O[T] {
+	it, ok := s.t.remove(*new(T), removeMin)
+	return toMember(&it, ok)
+}
+
+// DeleteMax removes and returns the greatest member.
+func (s *BTreeSet[T]) DeleteMax() goption.O[T] {
+	it, ok := s.t.remove(*new(T), removeMax)
+	return toMember(&it, ok)
+}
+
+// Ascend calls f sequentially for each member in ascending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The set must not be modified during the iteration.
+func (s *BTreeSet[T]) Ascend(f func(T) bool) {
+	s.t.ascend(nil, nil, func(it *item[T, struct{}]) bool { return f(it.key) })
+}
+
+// Descend calls f sequentially for each member in descending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The set must not be modified during the iteration.
+func (s *BTreeSet[T]) Descend(f func(T) bool) {
+	s.t.descend(nil, nil, func(it *item[T, struct{}]) bool { return f(it.key) })
+}
+
+// AscendRange calls f sequentially for each member in range [lo, hi) in
+// ascending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The set must not be modified during the iteration.
+func (s *BTreeSet[T]) AscendRange(lo, hi T, f func(T) bool) {
+	s.t.ascend(&lo, &hi, func(it *item[T, struct{}]) bool { return f(it.key) })
+}
+
+// DescendRange calls f sequentially for each member in range [lo, hi) in
+// descending order.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The set must not be modified during the iteration.
+func (s *BTreeSet[T]) DescendRange(lo, hi T, f func(T) bool) {
+	s.t.descend(&lo, &hi, func(it *item[T, struct{}]) bool { return f(it.key) })
+}
+
+// ToSlice collects all members to slice in ascending order.
+func (s *BTreeSet[T]) ToSlice() []T {
+	members := make([]T, 0, s.Len())
+	s.Ascend(func(v T) bool {
+		members = append(members, v)
+		return true
+	})
+	return members
+}
+
+// Clear removes all members from the set.
+func (s *BTreeSet[T]) Clear() {
+	s.t.clear()
+}
+
+// Clone returns a copy of the set in O(1) time.
+//
+// The set and its clone share nodes until they are modified, modifications
+// on one of them are invisible to the other.
+func (s *BTreeSet[T]) Clone() *BTreeSet[T] {
+	return &BTreeSet[T]{s.t.clone()}
+}
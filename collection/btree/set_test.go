@@ -0,0 +1,89 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btree
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestBTreeSet(t *testing.T) {
+	s := NewSet(5, 3, 1)
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Add(4))
+	assert.False(t, s.Add(4))
+	assert.True(t, s.Contains(4))
+	assert.False(t, s.Contains(2))
+	assert.Equal(t, []int{1, 3, 4, 5}, s.ToSlice())
+
+	assert.Equal(t, goption.OK(1), s.Min())
+	assert.Equal(t, goption.OK(5), s.Max())
+	assert.Equal(t, goption.OK(1), s.Floor(2))
+	assert.Equal(t, goption.OK(3), s.Ceiling(2))
+	assert.Equal(t, goption.Nil[int](), s.Floor(0))
+	assert.Equal(t, goption.Nil[int](), s.Ceiling(6))
+
+	var got []int
+	s.AscendRange(2, 5, func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, []int{3, 4}, got)
+	got = nil
+	s.DescendRange(2, 5, func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, []int{4, 3}, got)
+	got = nil
+	s.Descend(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	assert.Equal(t, []int{5, 4, 3, 1}, got)
+
+	assert.True(t, s.Remove(3))
+	assert.False(t, s.Remove(3))
+	assert.Equal(t, goption.OK(1), s.DeleteMin())
+	assert.Equal(t, goption.OK(5), s.DeleteMax())
+	assert.Equal(t, []int{4}, s.ToSlice())
+
+	c := s.Clone()
+	c.Add(10)
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, []int{4, 10}, c.ToSlice())
+	assert.Equal(t, goption.Nil[int](), s.DeleteMin())
+	assert.Equal(t, goption.Nil[int](), s.DeleteMax())
+}
+
+func TestNewSetFromSorted(t *testing.T) {
+	members := make([]int, 5000)
+	for i := range members {
+		members[i] = i * 3
+	}
+	s := NewSetFromSorted(members)
+	checkTree(t, &s.t)
+	assert.Equal(t, members, s.ToSlice())
+
+	desc := NewSetFuncFromSorted(func(a, b string) bool { return a > b }, []string{"c", "b", "a"})
+	assert.Equal(t, []string{"c", "b", "a"}, desc.ToSlice())
+	assert.Panic(t, func() { NewSetFromSorted([]int{1, 1}) })
+
+	f := NewSetFunc(func(a, b string) bool { return a > b }, "a", "c", "b")
+	assert.Equal(t, []string{"c", "b", "a"}, f.ToSlice())
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"github.com/bytedance/gg/collection/list"
+)
+
+// ARC is an Adaptive Replacement Cache, which balances between recency and
+// frequency automatically.
+//
+// Entries accessed once are kept in a recency list (T1), entries accessed at
+// least twice are kept in a frequency list (T2). Keys of evicted entries are
+// remembered in ghost lists (B1 and B2), a hit on ghost lists adapts the
+// target size of T1.
+//
+// See also: "ARC: A Self-Tuning, Low Overhead Replacement Cache" by
+// Nimrod Megiddo and Dharmendra S. Modha.
+//
+// 💡 NOTE: ARC is not concurrent-safe.
+type ARC[K comparable, V any] struct {
+	cache[K, V]
+}
+
+// NewARC creates an ARC cache.
+//
+// 💡 NOTE: It panics if [Options.Capacity] is not positive.
+func NewARC[K comparable, V any](opts Options[K, V]) *ARC[K, V] {
+	return &ARC[K, V]{newCache[K, V](opts, newARCPolicy[K, V](opts.Capacity))}
+}
+
+type ghost[K comparable] struct {
+	key      K
+	weight   int64
+	frequent bool // whether the ghost is in B2
+}
+
+// arcPolicy implements ARC, all sizes are measured in weight.
+// Lists are ordered from the most recently used (front) to the least
+// recently used (back).
+type arcPolicy[K comparable, V any] struct {
+	capacity int64
+	p        int64 // target weight of T1
+
+	t1, t2   list.List[*entry[K, V]]
+	t1w, t2w int64
+
+	b1, b2   list.List[ghost[K]]
+	b1w, b2w int64
+	ghosts   map[K]*list.Element[ghost[K]]
+}
+
+func newARCPolicy[K comparable, V any](capacity int64) *arcPolicy[K, V] {
+	p := &arcPolicy[K, V]{capacity: capacity}
+	p.clear()
+	return p
+}
+
+func (p *arcPolicy[K, V]) add(e *entry[K, V]) {
+	g, ok := p.ghosts[e.key]
+	if !ok {
+		e.frequent = false
+		e.elem = p.t1.PushFront(e)
+		p.t1w += e.weight
+		p.trim()
+		return
+	}
+
+	// Ghost hit: adapt the target weight of T1 and admit the entry into T2.
+	if g.Value.frequent {
+		delta := e.weight
+		if p.b2w > 0 && p.b1w > p.b2w {
+			delta *= p.b1w / p.b2w
+		}
+		p.p -= delta
+		if p.p < 0 {
+			p.p = 0
+		}
+	} else {
+		delta := e.weight
+		if p.b1w > 0 && p.b2w > p.b1w {
+			delta *= p.b2w / p.b1w
+		}
+		p.p += delta
+		if p.p > p.capacity {
+			p.p = p.capacity
+		}
+	}
+	p.removeGhost(g)
+	e.frequent = true
+	e.elem = p.t2.PushFront(e)
+	p.t2w += e.weight
+	p.trim()
+}
+
+func (p *arcPolicy[K, V]) hit(e *entry[K, V]) {
+	if e.frequent {
+		p.t2.MoveToFront(e.elem)
+		return
+	}
+	p.t1.Remove(e.elem)
+	p.t1w -= e.weight
+	e.frequent = true
+	e.elem = p.t2.PushFront(e)
+	p.t2w += e.weight
+}
+
+func (p *arcPolicy[K, V]) update(e *entry[K, V], delta int64) {
+	if e.frequent {
+		p.t2w += delta
+	} else {
+		p.t1w += delta
+	}
+	p.hit(e)
+}
+
+func (p *arcPolicy[K, V]) remove(e *entry[K, V], evicted bool) {
+	if e.frequent {
+		p.t2.Remove(e.elem)
+		p.t2w -= e.weight
+	} else {
+		p.t1.Remove(e.elem)
+		p.t1w -= e.weight
+	}
+	e.elem = nil
+	if !evicted {
+		return
+	}
+
+	// Remember the key of evicted entry.
+	g := ghost[K]{key: e.key, weight: e.weight, frequent: e.frequent}
+	if g.frequent {
+		p.ghosts[g.key] = p.b2.PushFront(g)
+		p.b2w += g.weight
+	} else {
+		p.ghosts[g.key] = p.b1.PushFront(g)
+		p.b1w += g.weight
+	}
+	p.trim()
+}
+
+func (p *arcPolicy[K, V]) victim() *entry[K, V] {
+	if p.t1.Len() > 0 && (p.t1w > p.p || p.t2.Len() == 0) {
+		return p.t1.Back().Value
+	}
+	return p.t2.Back().Value
+}
+
+func (p *arcPolicy[K, V]) clear() {
+	p.p = 0
+	p.t1.Init()
+	p.t2.Init()
+	p.t1w, p.t2w = 0, 0
+	p.b1.Init()
+	p.b2.Init()
+	p.b1w, p.b2w = 0, 0
+	p.ghosts = make(map[K]*list.Element[ghost[K]])
+}
+
+func (p *arcPolicy[K, V]) removeGhost(g *list.Element[ghost[K]]) {
+	delete(p.ghosts, g.Value.key)
+	if g.Value.frequent {
+		p.b2.Remove(g)
+		p.b2w -= g.Value.weight
+	} else {
+		p.b1.Remove(g)
+		p.b1w -= g.Value.weight
+	}
+}
+
+// trim keeps the ghost lists bounded:
+//
+//   - |T1| + |B1| <= c
+//   - |T1| + |T2| + |B1| + |B2| <= 2c
+func (p *arcPolicy[K, V]) trim() {
+	for p.b1.Len() > 0 && p.t1w+p.b1w > p.capacity {
+		p.removeGhost(p.b1.Back())
+	}
+	for p.b2.Len() > 0 && p.t1w+p.t2w+p.b1w+p.b2w > 2*p.capacity {
+		p.removeGhost(p.b2.Back())
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestARCScanResistance(t *testing.T) {
+	arc := NewARC(Options[int, int]{Capacity: 100})
+	lru := NewLRU(Options[int, int]{Capacity: 100})
+	for _, c := range []Cache[int, int]{arc, lru} {
+		// Hot keys are accessed twice.
+		for i := 0; i < 50; i++ {
+			c.Store(i, i)
+			c.Load(i)
+		}
+		// A long scan of keys which are accessed only once.
+		for i := 1000; i < 2000; i++ {
+			c.Store(i, i)
+		}
+	}
+	hot := func(c Cache[int, int]) int {
+		n := 0
+		for i := 0; i < 50; i++ {
+			if c.Contains(i) {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 50, hot(arc))
+	assert.Equal(t, 0, hot(lru))
+}
+
+func TestARCAdaptation(t *testing.T) {
+	c := NewARC(Options[int, int]{Capacity: 4})
+	p := c.policy.(*arcPolicy[int, int])
+
+	c.Store(1, 1)
+	c.Store(2, 2)
+	c.Load(1) // T1: 2, T2: 1
+	assert.Equal(t, 1, p.t1.Len())
+	assert.Equal(t, 1, p.t2.Len())
+
+	c.Store(3, 3)
+	c.Store(4, 4)
+	c.Store(5, 5) // evict 2 from T1 into B1
+	assert.False(t, c.Contains(2))
+	assert.Equal(t, 1, p.b1.Len())
+	assert.Equal(t, int64(0), p.p)
+
+	// Ghost hit on B1 increases the target of T1, and admits into T2.
+	c.Store(2, 2)
+	assert.Equal(t, int64(1), p.p)
+	assert.True(t, c.Contains(2))
+	assert.True(t, c.items[2].frequent)
+
+	// Evict from T2 into B2, then ghost hit on B2 decreases the target.
+	c.Load(3)
+	c.Load(4)
+	c.Load(5) // all in T2
+	c.Store(6, 6)
+	c.Store(7, 7) // evict 6 from T1
+	c.Store(8, 8) // evict 7 from T1
+	assert.True(t, p.t1w <= p.p+1)
+	for k := range p.ghosts {
+		c.Store(k, k)
+	}
+	assert.True(t, p.p >= 0 && p.p <= 4)
+	assert.Equal(t, int64(4), c.Weight())
+	assert.True(t, p.t1w+p.b1w <= 4)
+	assert.True(t, p.t1w+p.t2w+p.b1w+p.b2w <= 8)
+
+	// Deleted entries are not remembered.
+	n := p.b1.Len() + p.b2.Len()
+	for _, k := range []int{1, 2, 3, 4, 5, 6, 7, 8} {
+		c.Delete(k)
+	}
+	assert.Equal(t, n, p.b1.Len()+p.b2.Len())
+	assert.Equal(t, int64(0), p.t1w+p.t2w)
+
+	c.Clear()
+	assert.Equal(t, 0, len(p.ghosts))
+	assert.Equal(t, int64(0), p.p)
+}
+
+func TestARCWeightUpdate(t *testing.T) {
+	c := NewARC(Options[string, int]{
+		Capacity: 10,
+		Weigher:  func(_ string, v int) int64 { return int64(v) },
+	})
+	p := c.policy.(*arcPolicy[string, int])
+	c.Store("a", 2)
+	c.Store("a", 3) // T1 -> T2
+	assert.Equal(t, int64(0), p.t1w)
+	assert.Equal(t, int64(3), p.t2w)
+	c.Store("a", 1)
+	assert.Equal(t, int64(1), p.t2w)
+}
@@ -0,0 +1,386 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides bounded in-memory caches with different eviction
+// policies.
+//
+// # Structures
+//
+//   - [LRU]: evicts the least recently used entry
+//   - [LFU]: evicts the least frequently used entry, ties are broken by recency
+//   - [ARC]: Adaptive Replacement Cache, balances between recency and frequency
+//   - [Sharded]: a concurrent-safe wrapper which spreads keys over multiple caches
+//
+// All of them implement the [Cache] interface.
+//
+// 💡 NOTE: [LRU], [LFU] and [ARC] are not concurrent-safe,
+// use [Sharded] if you need.
+//
+// # Capacity
+//
+// The capacity of cache is measured in number of entries by default.
+// If [Options.Weigher] is set, it is measured in total weight of entries.
+//
+// # Expiration
+//
+// Entries can have a time-to-live, see [Options.TTL] and [Cache.StoreWithTTL].
+// Expired entries are removed lazily when they are accessed,
+// or explicitly by [Cache.RemoveExpired].
+//
+// The clock can be injected by [Options.Now] for testing.
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bytedance/gg/collection/list"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gresult"
+)
+
+// Cache is the common interface of caches in this package.
+type Cache[K comparable, V any] interface {
+	// Load returns the value stored in the cache for a key.
+	// A hit refreshes the position of the entry in the eviction policy.
+	Load(key K) goption.O[V]
+	// Store sets the value for a key with the default TTL.
+	Store(key K, value V)
+	// StoreWithTTL sets the value for a key with a specific TTL,
+	// zero or negative TTL means never expire.
+	StoreWithTTL(key K, value V, ttl time.Duration)
+	// GetOrLoad returns the cached value for a key if present, otherwise
+	// calls loader and stores the loaded value.
+	// Errors returned by loader are not cached.
+	GetOrLoad(key K, loader func(K) (V, error)) gresult.R[V]
+	// Delete deletes the value for a key.
+	// If key is not present in cache, return false.
+	Delete(key K) bool
+	// Contains returns true if key is present and not expired,
+	// without changing the eviction policy.
+	Contains(key K) bool
+	// Len returns the number of entries, including expired ones which have
+	// not been removed yet.
+	Len() int
+	// Weight returns the total weight of entries.
+	Weight() int64
+	// RemoveExpired removes all expired entries and returns their count.
+	RemoveExpired() int
+	// Clear removes all entries.
+	Clear()
+	// Stats returns the statistics of cache.
+	Stats() Stats
+}
+
+// EvictReason is the reason why an entry is removed from cache.
+type EvictReason int
+
+const (
+	// EvictCapacity means the entry is evicted for exceeding the capacity.
+	EvictCapacity EvictReason = iota + 1
+	// EvictExpired means the entry is expired.
+	EvictExpired
+	// EvictDeleted means the entry is deleted by [Cache.Delete] or [Cache.Clear].
+	EvictDeleted
+)
+
+// String implements [fmt.Stringer].
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("EvictReason(%d)", int(r))
+	}
+}
+
+// Options is the options for creating a cache.
+type Options[K comparable, V any] struct {
+	// Capacity is the maximum number of entries,
+	// or the maximum total weight if Weigher is set.
+	// It must be positive.
+	Capacity int64
+
+	// Weigher returns the weight of an entry, which must not be negative.
+	// If it is nil, every entry weighs 1.
+	Weigher func(key K, value V) int64
+
+	// TTL is the default time-to-live of entries stored by [Cache.Store].
+	// Zero means never expire.
+	TTL time.Duration
+
+	// Now returns the current time, [time.Now] is used if it is nil.
+	Now func() time.Time
+
+	// OnEvict is called synchronously after an entry is removed from cache,
+	// except the entry is replaced by a new value of the same key.
+	//
+	// 💡 NOTE: OnEvict must not access the cache.
+	OnEvict func(key K, value V, reason EvictReason)
+}
+
+// Stats is the statistics of cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // count of entries evicted for capacity or expiration
+}
+
+// HitRate returns the ratio of hits to total lookups,
+// zero is returned if there is no lookup.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+func (s Stats) add(other Stats) Stats {
+	return Stats{
+		Hits:      s.Hits + other.Hits,
+		Misses:    s.Misses + other.Misses,
+		Evictions: s.Evictions + other.Evictions,
+	}
+}
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	weight   int64
+	expireAt int64 // in unix nanoseconds, 0 means never expire
+
+	// Policy specific fields.
+	elem     *list.Element[*entry[K, V]]  // position of entry in the list of policy
+	bucket   *list.Element[*bucket[K, V]] // frequency bucket of entry, used by LFU
+	frequent bool                         // whether the entry is in T2 list, used by ARC
+}
+
+// policy is the eviction policy of cache.
+type policy[K comparable, V any] interface {
+	// add is called when a new entry e is stored.
+	add(e *entry[K, V])
+	// hit is called when entry e is accessed.
+	hit(e *entry[K, V])
+	// update is called after the weight of entry e is changed by delta.
+	update(e *entry[K, V], delta int64)
+	// remove is called when entry e is removed,
+	// evicted reports whether it is removed for capacity.
+	remove(e *entry[K, V], evicted bool)
+	// victim returns the next entry to evict.
+	victim() *entry[K, V]
+	// clear resets the policy.
+	clear()
+}
+
+// cache implements the common logic of caches, the eviction order is decided
+// by its policy.
+type cache[K comparable, V any] struct {
+	items  map[K]*entry[K, V]
+	policy policy[K, V]
+	opts   Options[K, V]
+	weight int64
+	stats  Stats
+}
+
+func newCache[K comparable, V any](opts Options[K, V], p policy[K, V]) cache[K, V] {
+	if opts.Capacity <= 0 {
+		panic(fmt.Errorf("capacity must be positive: %d", opts.Capacity))
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return cache[K, V]{
+		items:  make(map[K]*entry[K, V]),
+		policy: p,
+		opts:   opts,
+	}
+}
+
+func (c *cache[K, V]) weigh(key K, value V) int64 {
+	if c.opts.Weigher == nil {
+		return 1
+	}
+	return c.opts.Weigher(key, value)
+}
+
+func (c *cache[K, V]) expired(e *entry[K, V], now int64) bool {
+	return e.expireAt != 0 && now >= e.expireAt
+}
+
+func (c *cache[K, V]) now() int64 {
+	return c.opts.Now().UnixNano()
+}
+
+// Load returns the value stored in the cache for a key.
+func (c *cache[K, V]) Load(key K) goption.O[V] {
+	e, ok := c.items[key]
+	if ok && e.expireAt != 0 && c.expired(e, c.now()) {
+		c.removeEntry(e, EvictExpired)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return goption.Nil[V]()
+	}
+	c.stats.Hits++
+	c.policy.hit(e)
+	return goption.OK(e.value)
+}
+
+// Contains returns true if key is present and not expired.
+// It does not change the eviction policy and statistics.
+func (c *cache[K, V]) Contains(key K) bool {
+	e, ok := c.items[key]
+	return ok && !c.expired(e, c.now())
+}
+
+// Store sets the value for a key with the default TTL.
+func (c *cache[K, V]) Store(key K, value V) {
+	c.StoreWithTTL(key, value, c.opts.TTL)
+}
+
+// StoreWithTTL sets the value for a key with a specific TTL,
+// zero or negative TTL means never expire.
+func (c *cache[K, V]) StoreWithTTL(key K, value V, ttl time.Duration) {
+	var expireAt int64
+	if ttl > 0 {
+		expireAt = c.now() + int64(ttl)
+	}
+	w := c.weigh(key, value)
+	if w > c.opts.Capacity {
+		c.reject(key, value)
+		return
+	}
+	if e, ok := c.items[key]; ok {
+		delta := w - e.weight
+		e.value = value
+		e.weight = w
+		e.expireAt = expireAt
+		c.weight += delta
+		c.policy.update(e, delta)
+		c.evict(0)
+		return
+	}
+
+	// Make room before admitting the new entry, so that it will not be
+	// chosen as victim.
+	c.evict(w)
+	e := &entry[K, V]{key: key, value: value, weight: w, expireAt: expireAt}
+	c.items[key] = e
+	c.weight += w
+	c.policy.add(e)
+}
+
+// reject rejects an entry heavier than the capacity without evicting others,
+// the old entry of key is evicted first and then the new one.
+func (c *cache[K, V]) reject(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		c.removeEntry(e, EvictCapacity)
+	}
+	c.stats.Evictions++
+	if c.opts.OnEvict != nil {
+		c.opts.OnEvict(key, value, EvictCapacity)
+	}
+}
+
+// evict evicts entries until there is room for extra weight.
+func (c *cache[K, V]) evict(extra int64) {
+	for c.weight+extra > c.opts.Capacity && len(c.items) > 0 {
+		c.removeEntry(c.policy.victim(), EvictCapacity)
+	}
+}
+
+// GetOrLoad returns the cached value for a key if present, otherwise calls
+// loader and stores the loaded value.
+func (c *cache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) gresult.R[V] {
+	if o := c.Load(key); o.IsOK() {
+		return gresult.OK(o.Value())
+	}
+	v, err := loader(key)
+	if err != nil {
+		return gresult.Err[V](err)
+	}
+	c.Store(key, v)
+	return gresult.OK(v)
+}
+
+// Delete deletes the value for a key.
+func (c *cache[K, V]) Delete(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.removeEntry(e, EvictDeleted)
+	}
+	return ok
+}
+
+// Len returns the number of entries, including expired ones which have not
+// been removed yet.
+func (c *cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Weight returns the total weight of entries.
+func (c *cache[K, V]) Weight() int64 {
+	return c.weight
+}
+
+// RemoveExpired removes all expired entries and returns their count.
+// The complexity is O(n).
+func (c *cache[K, V]) RemoveExpired() int {
+	now := c.now()
+	n := 0
+	for _, e := range c.items {
+		if c.expired(e, now) {
+			c.removeEntry(e, EvictExpired)
+			n++
+		}
+	}
+	return n
+}
+
+// Clear removes all entries.
+// The statistics is kept.
+func (c *cache[K, V]) Clear() {
+	items := c.items
+	c.items = make(map[K]*entry[K, V])
+	c.weight = 0
+	c.policy.clear()
+	if c.opts.OnEvict != nil {
+		for _, e := range items {
+			c.opts.OnEvict(e.key, e.value, EvictDeleted)
+		}
+	}
+}
+
+// Stats returns the statistics of cache.
+func (c *cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *cache[K, V]) removeEntry(e *entry[K, V], reason EvictReason) {
+	delete(c.items, e.key)
+	c.weight -= e.weight
+	c.policy.remove(e, reason == EvictCapacity)
+	if reason != EvictDeleted {
+		c.stats.Evictions++
+	}
+	if c.opts.OnEvict != nil {
+		c.opts.OnEvict(e.key, e.value, reason)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+func Example() {
+	c := NewLRU(Options[string, int]{
+		Capacity: 2,
+		OnEvict: func(k string, v int, reason EvictReason) {
+			fmt.Printf("evict %s=%d: %s\n", k, v, reason)
+		},
+	})
+	c.Store("a", 1)
+	c.Store("b", 2)
+	c.Load("a")     // refresh a
+	c.Store("c", 3) // evict b
+
+	fmt.Println(c.Load("a").Value())
+	fmt.Println(c.Load("b").IsOK())
+
+	v := c.GetOrLoad("d", func(k string) (int, error) { return 4, nil })
+	fmt.Println(v.Value())
+	fmt.Println(c.Stats())
+
+	// Output:
+	// evict b=2: capacity
+	// 1
+	// false
+	// evict c=3: capacity
+	// 4
+	// {2 2 2}
+}
+
+func ExampleOptions_ttl() {
+	now := time.Unix(0, 0)
+	c := NewLFU(Options[string, string]{
+		Capacity: 100,
+		TTL:      time.Minute,
+		Now:      func() time.Time { return now },
+	})
+	c.Store("session", "alice")
+	c.StoreWithTTL("token", "xyz", time.Hour)
+
+	now = now.Add(2 * time.Minute)
+	fmt.Println(c.Load("session").IsOK())
+	fmt.Println(c.Load("token").Value())
+
+	// Output:
+	// false
+	// xyz
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gresult"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+var (
+	_ Cache[int, int] = (*LRU[int, int])(nil)
+	_ Cache[int, int] = (*LFU[int, int])(nil)
+	_ Cache[int, int] = (*ARC[int, int])(nil)
+	_ Cache[int, int] = (*Sharded[int, int])(nil)
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+type evicted struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+var constructors = map[string]func(Options[string, int]) Cache[string, int]{
+	"LRU": func(o Options[string, int]) Cache[string, int] { return NewLRU(o) },
+	"LFU": func(o Options[string, int]) Cache[string, int] { return NewLFU(o) },
+	"ARC": func(o Options[string, int]) Cache[string, int] { return NewARC(o) },
+	"Sharded": func(o Options[string, int]) Cache[string, int] {
+		return NewSharded(1, func() Cache[string, int] { return NewLRU(o) })
+	},
+}
+
+func TestCapacity(t *testing.T) {
+	assert.Panic(t, func() { NewLRU(Options[string, int]{}) })
+	assert.Panic(t, func() { NewLFU(Options[string, int]{Capacity: -1}) })
+
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var evicts []evicted
+			c := newCache(Options[string, int]{
+				Capacity: 3,
+				OnEvict: func(k string, v int, r EvictReason) {
+					evicts = append(evicts, evicted{k, v, r})
+				},
+			})
+			c.Store("a", 1)
+			c.Store("b", 2)
+			c.Store("c", 3)
+			assert.Equal(t, 3, c.Len())
+			assert.Equal(t, int64(3), c.Weight())
+			assert.Equal(t, goption.OK(1), c.Load("a"))
+
+			c.Store("d", 4)
+			assert.Equal(t, 3, c.Len())
+			assert.Equal(t, 1, len(evicts))
+			assert.Equal(t, EvictCapacity, evicts[0].reason)
+			assert.False(t, c.Contains(evicts[0].key))
+			assert.True(t, c.Contains("d"))
+
+			// Update does not evict.
+			c.StoreWithTTL("d", 40, 2*time.Minute)
+			assert.Equal(t, goption.OK(40), c.Load("d"))
+			assert.Equal(t, 1, len(evicts))
+
+			assert.True(t, c.Delete("d"))
+			assert.False(t, c.Delete("d"))
+			assert.Equal(t, evicted{"d", 40, EvictDeleted}, evicts[1])
+			assert.Equal(t, 2, c.Len())
+
+			c.Clear()
+			assert.Equal(t, 0, c.Len())
+			assert.Equal(t, int64(0), c.Weight())
+			assert.Equal(t, 4, len(evicts))
+			assert.Equal(t, uint64(1), c.Stats().Evictions)
+
+			c.Store("x", 1)
+			assert.Equal(t, goption.OK(1), c.Load("x"))
+		})
+	}
+}
+
+func TestWeight(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var evicts []evicted
+			c := newCache(Options[string, int]{
+				Capacity: 10,
+				Weigher:  func(_ string, v int) int64 { return int64(v) },
+				OnEvict: func(k string, v int, r EvictReason) {
+					evicts = append(evicts, evicted{k, v, r})
+				},
+			})
+			c.Store("a", 4)
+			c.Store("b", 5)
+			assert.Equal(t, int64(9), c.Weight())
+			c.Store("c", 2)
+			assert.Equal(t, 2, c.Len())
+			assert.Equal(t, int64(7), c.Weight())
+			assert.Equal(t, evicted{"a", 4, EvictCapacity}, evicts[0])
+
+			// Heavier than capacity.
+			c.Store("d", 11)
+			assert.False(t, c.Contains("d"))
+			assert.Equal(t, evicted{"d", 11, EvictCapacity}, evicts[len(evicts)-1])
+			assert.True(t, c.Contains("b"))
+			assert.True(t, c.Contains("c"))
+			assert.Equal(t, 2, c.Len())
+			assert.Equal(t, int64(7), c.Weight())
+
+			// Growing weight by update.
+			c.Store("e", 1)
+			c.Store("e", 9)
+			assert.True(t, c.Contains("e"))
+			assert.True(t, c.Weight() <= 10)
+
+			// Growing weight above capacity by update.
+			n := len(evicts)
+			c.Store("e", 11)
+			assert.False(t, c.Contains("e"))
+			assert.Equal(t, 0, c.Len())
+			assert.Equal(t, int64(0), c.Weight())
+			assert.Equal(t, []evicted{{"e", 9, EvictCapacity}, {"e", 11, EvictCapacity}}, evicts[n:])
+			c.Store("f", 3)
+			n = len(evicts)
+			c.Store("f", 11)
+			assert.Equal(t, []evicted{{"f", 3, EvictCapacity}, {"f", 11, EvictCapacity}}, evicts[n:])
+			c.Store("g", 10)
+			assert.True(t, c.Contains("g"))
+			assert.Equal(t, int64(10), c.Weight())
+		})
+	}
+}
+
+func TestTTL(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(1000, 0)}
+			var evicts []evicted
+			c := newCache(Options[string, int]{
+				Capacity: 10,
+				TTL:      time.Minute,
+				Now:      clock.Now,
+				OnEvict: func(k string, v int, r EvictReason) {
+					evicts = append(evicts, evicted{k, v, r})
+				},
+			})
+			c.Store("a", 1)                   // expires at +1m
+			c.StoreWithTTL("b", 2, time.Hour) // expires at +1h
+			c.StoreWithTTL("c", 3, 0)         // never expires
+			c.StoreWithTTL("d", 4, 2*time.Minute)
+
+			clock.Advance(59 * time.Second)
+			assert.Equal(t, goption.OK(1), c.Load("a"))
+			clock.Advance(time.Second)
+			assert.False(t, c.Contains("a"))
+			assert.Equal(t, 4, c.Len()) // not removed yet
+			assert.Equal(t, goption.Nil[int](), c.Load("a"))
+			assert.Equal(t, 3, c.Len())
+			assert.Equal(t, []evicted{{"a", 1, EvictExpired}}, evicts)
+
+			// Refresh TTL by storing again.
+			c.StoreWithTTL("d", 40, 2*time.Minute)
+			clock.Advance(90 * time.Second)
+			assert.Equal(t, goption.OK(40), c.Load("d"))
+
+			clock.Advance(24 * time.Hour)
+			assert.Equal(t, 2, c.RemoveExpired())
+			assert.Equal(t, 1, c.Len())
+			assert.Equal(t, goption.OK(3), c.Load("c"))
+			assert.Equal(t, uint64(3), c.Stats().Evictions)
+		})
+	}
+}
+
+func TestStatsAndGetOrLoad(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			c := newCache(Options[string, int]{Capacity: 10})
+			assert.Equal(t, 0.0, c.Stats().HitRate())
+
+			loads := 0
+			loader := func(k string) (int, error) {
+				loads++
+				if k == "bad" {
+					return 0, errors.New("bad key")
+				}
+				return len(k), nil
+			}
+			assert.Equal(t, gresult.OK(3), c.GetOrLoad("abc", loader))
+			assert.Equal(t, gresult.OK(3), c.GetOrLoad("abc", loader))
+			assert.Equal(t, 1, loads)
+			assert.True(t, c.GetOrLoad("bad", loader).IsErr())
+			assert.True(t, c.GetOrLoad("bad", loader).IsErr())
+			assert.Equal(t, 3, loads)
+			assert.False(t, c.Contains("bad"))
+
+			c.Load("abc")
+			st := c.Stats()
+			assert.Equal(t, uint64(2), st.Hits)
+			assert.Equal(t, uint64(3), st.Misses)
+			assert.Equal(t, 0.4, st.HitRate())
+		})
+	}
+}
+
+func TestEvictReason(t *testing.T) {
+	assert.Equal(t, "capacity", EvictCapacity.String())
+	assert.Equal(t, "expired", EvictExpired.String())
+	assert.Equal(t, "deleted", EvictDeleted.String())
+	assert.Equal(t, "EvictReason(0)", EvictReason(0).String())
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"github.com/bytedance/gg/collection/list"
+)
+
+// LFU is a cache which evicts the least frequently used entry,
+// the least recently used one is evicted if there are multiple candidates.
+//
+// All operations are O(1).
+//
+// 💡 NOTE: LFU is not concurrent-safe.
+type LFU[K comparable, V any] struct {
+	cache[K, V]
+}
+
+// NewLFU creates a LFU cache.
+//
+// 💡 NOTE: It panics if [Options.Capacity] is not positive.
+func NewLFU[K comparable, V any](opts Options[K, V]) *LFU[K, V] {
+	return &LFU[K, V]{newCache[K, V](opts, newLFUPolicy[K, V]())}
+}
+
+// bucket holds entries with the same access frequency,
+// from the most recently used (front) to the least recently used (back).
+type bucket[K comparable, V any] struct {
+	freq    uint64
+	entries list.List[*entry[K, V]]
+}
+
+// lfuPolicy keeps buckets in a list ordered by frequency ascending.
+type lfuPolicy[K comparable, V any] struct {
+	buckets *list.List[*bucket[K, V]]
+}
+
+func newLFUPolicy[K comparable, V any]() *lfuPolicy[K, V] {
+	return &lfuPolicy[K, V]{buckets: list.New[*bucket[K, V]]()}
+}
+
+// moveTo moves entry e into the bucket with frequency freq, which is placed
+// after mark, or at the front of buckets if mark is nil.
+func (p *lfuPolicy[K, V]) moveTo(e *entry[K, V], freq uint64, mark *list.Element[*bucket[K, V]]) {
+	var target *list.Element[*bucket[K, V]]
+	if mark == nil {
+		target = p.buckets.Front()
+	} else {
+		target = mark.Next()
+	}
+	if target == nil || target.Value.freq != freq {
+		b := &bucket[K, V]{freq: freq}
+		b.entries.Init()
+		if mark == nil {
+			target = p.buckets.PushFront(b)
+		} else {
+			target = p.buckets.InsertAfter(b, mark)
+		}
+	}
+	e.bucket = target
+	e.elem = target.Value.entries.PushFront(e)
+}
+
+// detach removes entry e from its bucket, the bucket is removed if it
+// becomes empty.
+func (p *lfuPolicy[K, V]) detach(e *entry[K, V]) {
+	b := e.bucket
+	b.Value.entries.Remove(e.elem)
+	if b.Value.entries.Len() == 0 {
+		p.buckets.Remove(b)
+	}
+	e.elem = nil
+	e.bucket = nil
+}
+
+func (p *lfuPolicy[K, V]) add(e *entry[K, V]) {
+	p.moveTo(e, 1, nil)
+}
+
+func (p *lfuPolicy[K, V]) hit(e *entry[K, V]) {
+	cur := e.bucket
+	freq := cur.Value.freq + 1
+	mark := cur
+	if cur.Value.entries.Len() == 1 {
+		// The current bucket will be removed, insert after its previous one.
+		mark = cur.Prev()
+	}
+	p.detach(e)
+	p.moveTo(e, freq, mark)
+}
+
+func (p *lfuPolicy[K, V]) update(e *entry[K, V], _ int64) {
+	p.hit(e)
+}
+
+func (p *lfuPolicy[K, V]) remove(e *entry[K, V], _ bool) {
+	p.detach(e)
+}
+
+func (p *lfuPolicy[K, V]) victim() *entry[K, V] {
+	return p.buckets.Front().Value.entries.Back().Value
+}
+
+func (p *lfuPolicy[K, V]) clear() {
+	p.buckets.Init()
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestLFU(t *testing.T) {
+	var evicts []string
+	c := NewLFU(Options[string, int]{
+		Capacity: 3,
+		OnEvict:  func(k string, _ int, _ EvictReason) { evicts = append(evicts, k) },
+	})
+	c.Store("a", 1)
+	c.Store("b", 2)
+	c.Store("c", 3)
+	c.Load("a")
+	c.Load("a")
+	c.Load("b") // a:3 b:2 c:1
+	c.Store("d", 4)
+	assert.Equal(t, []string{"c"}, evicts) // a:3 b:2 d:1
+
+	c.Load("d")
+	c.Load("d") // a:3 d:3 b:2
+	c.Store("e", 5)
+	assert.Equal(t, []string{"c", "b"}, evicts) // a:3 d:3 e:1
+
+	// Ties are broken by recency.
+	c.Load("e")
+	c.Load("e")     // a:3 d:3 e:3, a is the least recently used
+	c.Store("f", 6) // evict a
+	assert.Equal(t, []string{"c", "b", "a"}, evicts)
+
+	// Deleting keeps the buckets consistent.
+	c.Delete("d")
+	c.Delete("e")
+	c.Delete("f")
+	assert.Equal(t, 0, c.Len())
+	c.Store("g", 7)
+	c.Load("g")
+	assert.True(t, c.Contains("g"))
+}
+
+func TestLFUBuckets(t *testing.T) {
+	c := NewLFU(Options[int, int]{Capacity: 100})
+	p := c.policy.(*lfuPolicy[int, int])
+	for i := 0; i < 10; i++ {
+		c.Store(i, i)
+		for j := 0; j < i; j++ {
+			c.Load(i)
+		}
+	}
+	// Buckets are ordered by frequency and never empty.
+	var freqs []uint64
+	for b := p.buckets.Front(); b != nil; b = b.Next() {
+		assert.True(t, b.Value.entries.Len() > 0)
+		freqs = append(freqs, b.Value.freq)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, freqs)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, p.victim().value)
+		c.Delete(p.victim().key)
+	}
+	assert.Equal(t, 0, p.buckets.Len())
+}
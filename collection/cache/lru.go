@@ -0,0 +1,68 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"github.com/bytedance/gg/collection/list"
+)
+
+// LRU is a cache which evicts the least recently used entry.
+//
+// 💡 NOTE: LRU is not concurrent-safe.
+type LRU[K comparable, V any] struct {
+	cache[K, V]
+}
+
+// NewLRU creates a LRU cache.
+//
+// 💡 NOTE: It panics if [Options.Capacity] is not positive.
+func NewLRU[K comparable, V any](opts Options[K, V]) *LRU[K, V] {
+	return &LRU[K, V]{newCache[K, V](opts, newLRUPolicy[K, V]())}
+}
+
+// lruPolicy keeps entries in a list from the most recently used (front) to
+// the least recently used (back).
+type lruPolicy[K comparable, V any] struct {
+	l *list.List[*entry[K, V]]
+}
+
+func newLRUPolicy[K comparable, V any]() *lruPolicy[K, V] {
+	return &lruPolicy[K, V]{l: list.New[*entry[K, V]]()}
+}
+
+func (p *lruPolicy[K, V]) add(e *entry[K, V]) {
+	e.elem = p.l.PushFront(e)
+}
+
+func (p *lruPolicy[K, V]) hit(e *entry[K, V]) {
+	p.l.MoveToFront(e.elem)
+}
+
+func (p *lruPolicy[K, V]) update(e *entry[K, V], _ int64) {
+	p.l.MoveToFront(e.elem)
+}
+
+func (p *lruPolicy[K, V]) remove(e *entry[K, V], _ bool) {
+	p.l.Remove(e.elem)
+	e.elem = nil
+}
+
+func (p *lruPolicy[K, V]) victim() *entry[K, V] {
+	return p.l.Back().Value
+}
+
+func (p *lruPolicy[K, V]) clear() {
+	p.l.Init()
+}
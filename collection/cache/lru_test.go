@@ -0,0 +1,39 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestLRU(t *testing.T) {
+	var evicts []string
+	c := NewLRU(Options[string, int]{
+		Capacity: 3,
+		OnEvict:  func(k string, _ int, _ EvictReason) { evicts = append(evicts, k) },
+	})
+	c.Store("a", 1)
+	c.Store("b", 2)
+	c.Store("c", 3)
+	c.Load("a")     // b c a
+	c.Store("b", 2) // c a b
+	c.Store("d", 4) // evict c
+	c.Contains("a") // does not refresh
+	c.Store("e", 5) // evict a
+	c.Store("f", 6) // evict b
+	assert.Equal(t, []string{"c", "a", "b"}, evicts)
+}
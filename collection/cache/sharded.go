@@ -0,0 +1,172 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gresult"
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+// Sharded is a concurrent-safe cache, which spreads keys over multiple caches
+// by hash of key, each of them is protected by its own mutex.
+type Sharded[K comparable, V any] struct {
+	shards []shard[K, V]
+	hash   func(K) uint64
+}
+
+type shard[K comparable, V any] struct {
+	mu sync.Mutex
+	c  Cache[K, V]
+	_  [40]byte // avoid false sharing
+}
+
+// NewSharded creates a sharded cache with n shards,
+// each shard is created by newCache.
+//
+// The capacity of each shard is decided by newCache, so the total capacity
+// is n times the capacity of shard.
+//
+// 💡 NOTE: It panics if n is not positive.
+//
+// 💡 HINT: Keys are hashed by a built-in deterministic hash function, which
+// is fast for strings and integers, other types are hashed through
+// reflection. Use [NewShardedFunc] if it matters.
+func NewSharded[K comparable, V any](n int, newCache func() Cache[K, V]) *Sharded[K, V] {
+	return NewShardedFunc(n, newCache, func(k K) uint64 { return hashing.Sum64(k, 0) })
+}
+
+// NewShardedFunc is a variant of [NewSharded], keys are hashed by hash.
+func NewShardedFunc[K comparable, V any](n int, newCache func() Cache[K, V], hash func(K) uint64) *Sharded[K, V] {
+	if n <= 0 {
+		panic(fmt.Errorf("number of shards must be positive: %d", n))
+	}
+	s := &Sharded[K, V]{
+		shards: make([]shard[K, V], n),
+		hash:   hash,
+	}
+	for i := range s.shards {
+		s.shards[i].c = newCache()
+	}
+	return s
+}
+
+func (s *Sharded[K, V]) shard(key K) *shard[K, V] {
+	return &s.shards[s.hash(key)%uint64(len(s.shards))]
+}
+
+// Load implements [Cache.Load].
+func (s *Sharded[K, V]) Load(key K) goption.O[V] {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.c.Load(key)
+}
+
+// Contains implements [Cache.Contains].
+func (s *Sharded[K, V]) Contains(key K) bool {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.c.Contains(key)
+}
+
+// Store implements [Cache.Store].
+func (s *Sharded[K, V]) Store(key K, value V) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.c.Store(key, value)
+}
+
+// StoreWithTTL implements [Cache.StoreWithTTL].
+func (s *Sharded[K, V]) StoreWithTTL(key K, value V, ttl time.Duration) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.c.StoreWithTTL(key, value, ttl)
+}
+
+// GetOrLoad implements [Cache.GetOrLoad].
+//
+// The loader is called without holding lock, so it may be called more than
+// once for the same key by concurrent callers, the last stored value wins.
+func (s *Sharded[K, V]) GetOrLoad(key K, loader func(K) (V, error)) gresult.R[V] {
+	if o := s.Load(key); o.IsOK() {
+		return gresult.OK(o.Value())
+	}
+	v, err := loader(key)
+	if err != nil {
+		return gresult.Err[V](err)
+	}
+	s.Store(key, v)
+	return gresult.OK(v)
+}
+
+// Delete implements [Cache.Delete].
+func (s *Sharded[K, V]) Delete(key K) bool {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.c.Delete(key)
+}
+
+// forEach calls f with each shard locked.
+func (s *Sharded[K, V]) forEach(f func(c Cache[K, V])) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		f(sh.c)
+		sh.mu.Unlock()
+	}
+}
+
+// Len implements [Cache.Len].
+func (s *Sharded[K, V]) Len() int {
+	n := 0
+	s.forEach(func(c Cache[K, V]) { n += c.Len() })
+	return n
+}
+
+// Weight implements [Cache.Weight].
+func (s *Sharded[K, V]) Weight() int64 {
+	var w int64
+	s.forEach(func(c Cache[K, V]) { w += c.Weight() })
+	return w
+}
+
+// RemoveExpired implements [Cache.RemoveExpired].
+func (s *Sharded[K, V]) RemoveExpired() int {
+	n := 0
+	s.forEach(func(c Cache[K, V]) { n += c.RemoveExpired() })
+	return n
+}
+
+// Clear implements [Cache.Clear].
+func (s *Sharded[K, V]) Clear() {
+	s.forEach(func(c Cache[K, V]) { c.Clear() })
+}
+
+// Stats implements [Cache.Stats], it returns the sum of statistics of all
+// shards.
+func (s *Sharded[K, V]) Stats() Stats {
+	var st Stats
+	s.forEach(func(c Cache[K, V]) { st = st.add(c.Stats()) })
+	return st
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gresult"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestSharded(t *testing.T) {
+	assert.Panic(t, func() {
+		NewSharded(0, func() Cache[string, int] { return NewLRU(Options[string, int]{Capacity: 1}) })
+	})
+
+	c := NewSharded(8, func() Cache[string, int] {
+		return NewLRU(Options[string, int]{Capacity: 100})
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := strconv.Itoa(i % 200)
+				switch i % 4 {
+				case 0:
+					c.Store(k, i)
+				case 1:
+					c.Load(k)
+				case 2:
+					c.GetOrLoad(k, func(k string) (int, error) { return len(k), nil })
+				case 3:
+					if g == 0 {
+						c.Delete(k)
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.True(t, c.Len() <= 200)
+	assert.Equal(t, int64(c.Len()), c.Weight())
+	st := c.Stats()
+	assert.Equal(t, uint64(4000), st.Hits+st.Misses)
+
+	c.StoreWithTTL("x", 1, 0)
+	assert.True(t, c.Contains("x"))
+	assert.Equal(t, goption.OK(1), c.Load("x"))
+	assert.True(t, c.Delete("x"))
+	assert.Equal(t, 0, c.RemoveExpired())
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestShardedFunc(t *testing.T) {
+	type key struct{ a, b int }
+	c := NewShardedFunc(4, func() Cache[key, int] {
+		return NewLFU(Options[key, int]{Capacity: 1})
+	}, func(k key) uint64 { return uint64(k.a) })
+
+	// Each shard holds one entry.
+	for i := 0; i < 4; i++ {
+		c.Store(key{i, 0}, i)
+	}
+	assert.Equal(t, 4, c.Len())
+	c.Store(key{4, 0}, 4) // same shard with key{0, 0}
+	assert.Equal(t, 4, c.Len())
+	assert.False(t, c.Contains(key{0, 0}))
+	assert.Equal(t, gresult.OK(1), c.GetOrLoad(key{1, 0}, nil))
+}
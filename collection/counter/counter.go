@@ -0,0 +1,359 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package counter provides a multiset that counts occurrences of comparable
+// elements.
+//
+// 💡 NOTE: Counter is not concurrent-safe.
+//
+// # Structures
+//
+//   - [Counter]
+//
+// # Operations
+//
+//   - Constructor: [New], [FromSlice], [FromMap]
+//   - Counting operations: [Counter.Add], [Counter.AddN], [Counter.Subtract], [Counter.SubtractN], [Counter.Count], [Counter.Total], …
+//   - Selection: [Counter.MostCommon]
+//   - Multiset operations: [Counter.Union], [Counter.Intersect], [Counter.Sum], [Counter.Diff]
+//   - Conversion: [Counter.ToMap], [Counter.Elements], …
+//
+// # Counts are always positive
+//
+// An element with count less than or equal to zero is removed from counter,
+// so [Counter.Len] is always the number of distinct elements with positive
+// count.
+//
+// # JSON
+//
+// [Counter] implements [encoding/json.Marshaler] and [encoding/json.Unmarshaler],
+// it is encoded as a JSON object from elements to counts.
+package counter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/internal/heapsort"
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+// Counter is a multiset that counts occurrences of elements.
+//
+// The zero value for Counter is an empty counter ready to use.
+//
+// 💡 NOTE: Counter is not concurrent-safe.
+type Counter[T comparable] struct {
+	m     map[T]int
+	total int
+}
+
+// New creates an empty counter.
+func New[T comparable]() *Counter[T] {
+	return &Counter[T]{m: make(map[T]int)}
+}
+
+// FromSlice creates a counter which counts elements of slice s.
+func FromSlice[T comparable](s []T) *Counter[T] {
+	c := New[T]()
+	for _, v := range s {
+		c.m[v]++
+	}
+	c.total = len(s)
+	return c
+}
+
+// FromMap creates a counter from map m, such as the result of
+// [github.com/bytedance/gg/gslice.CountValues].
+//
+// Elements with non-positive count are ignored.
+func FromMap[T comparable](m map[T]int) *Counter[T] {
+	c := New[T]()
+	for v, n := range m {
+		c.AddN(v, n)
+	}
+	return c
+}
+
+// lazyInit lazily initializes a zero Counter value.
+func (c *Counter[T]) lazyInit() {
+	if c.m == nil {
+		c.m = make(map[T]int)
+	}
+}
+
+// Len returns the number of distinct elements.
+func (c *Counter[T]) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.m)
+}
+
+// Total returns the sum of all counts.
+// The complexity is O(1).
+func (c *Counter[T]) Total() int {
+	if c == nil {
+		return 0
+	}
+	return c.total
+}
+
+// Count returns the count of element v, zero is returned if v is absent.
+func (c *Counter[T]) Count(v T) int {
+	if c == nil {
+		return 0
+	}
+	return c.m[v]
+}
+
+// Contains returns true if count of element v is positive.
+func (c *Counter[T]) Contains(v T) bool {
+	return c.Count(v) > 0
+}
+
+// Add increases count of element v by one.
+func (c *Counter[T]) Add(v T) {
+	c.AddN(v, 1)
+}
+
+// AddN increases count of element v by n, and returns the new count.
+//
+// 💡 NOTE: A negative n decreases the count, see [Counter.SubtractN].
+func (c *Counter[T]) AddN(v T, n int) int {
+	c.lazyInit()
+	old := c.m[v]
+	nc := old + n
+	if nc <= 0 {
+		delete(c.m, v)
+		c.total -= old
+		return 0
+	}
+	c.m[v] = nc
+	c.total += n
+	return nc
+}
+
+// Subtract decreases count of element v by one.
+func (c *Counter[T]) Subtract(v T) {
+	c.SubtractN(v, 1)
+}
+
+// SubtractN decreases count of element v by n, and returns the new count.
+// The element is removed when its count drops to zero or below.
+func (c *Counter[T]) SubtractN(v T, n int) int {
+	return c.AddN(v, -n)
+}
+
+// Remove removes element v and returns its count.
+func (c *Counter[T]) Remove(v T) int {
+	if c == nil {
+		return 0
+	}
+	n, ok := c.m[v]
+	if ok {
+		delete(c.m, v)
+		c.total -= n
+	}
+	return n
+}
+
+// Range calls f sequentially for each element and its count.
+// If f returns false, range stops the iteration.
+//
+// 💡 NOTE: The iteration order is not specified.
+func (c *Counter[T]) Range(f func(v T, n int) bool) {
+	if c == nil {
+		return
+	}
+	for v, n := range c.m {
+		if !f(v, n) {
+			return
+		}
+	}
+}
+
+// MostCommon returns the n most common elements and their counts, from the
+// most common to the least.
+// All elements are returned if n is greater than [Counter.Len].
+//
+// It takes O(m*log(n)) time by partial heap sort, where m is [Counter.Len].
+//
+// 💡 NOTE: Elements with equal counts are ordered arbitrarily.
+func (c *Counter[T]) MostCommon(n int) tuple.S2[T, int] {
+	if n > c.Len() {
+		n = c.Len()
+	}
+	if n <= 0 {
+		return tuple.S2[T, int]{}
+	}
+
+	res := make(tuple.S2[T, int], 0, c.Len())
+	for v, cnt := range c.m {
+		res = append(res, tuple.Make2(v, cnt))
+	}
+	heapsort.PartialSortBy(res, n, func(a, b tuple.T2[T, int]) bool {
+		return a.Second > b.Second
+	})
+	return res[:n:n]
+}
+
+// Union returns a new counter whose counts are the maximum of counts in c and
+// other.
+func (c *Counter[T]) Union(other *Counter[T]) *Counter[T] {
+	res := c.Clone()
+	other.Range(func(v T, n int) bool {
+		if old := res.m[v]; n > old {
+			res.m[v] = n
+			res.total += n - old
+		}
+		return true
+	})
+	return res
+}
+
+// Intersect returns a new counter whose counts are the minimum of counts in c
+// and other.
+func (c *Counter[T]) Intersect(other *Counter[T]) *Counter[T] {
+	res := New[T]()
+	c.Range(func(v T, n int) bool {
+		if on := other.Count(v); on > 0 {
+			if on < n {
+				n = on
+			}
+			res.m[v] = n
+			res.total += n
+		}
+		return true
+	})
+	return res
+}
+
+// Sum returns a new counter whose counts are the sum of counts in c and other.
+func (c *Counter[T]) Sum(other *Counter[T]) *Counter[T] {
+	res := c.Clone()
+	other.Range(func(v T, n int) bool {
+		res.m[v] += n
+		res.total += n
+		return true
+	})
+	return res
+}
+
+// Diff returns a new counter whose counts are counts in c minus counts in
+// other, only positive counts are kept.
+func (c *Counter[T]) Diff(other *Counter[T]) *Counter[T] {
+	res := New[T]()
+	c.Range(func(v T, n int) bool {
+		if n -= other.Count(v); n > 0 {
+			res.m[v] = n
+			res.total += n
+		}
+		return true
+	})
+	return res
+}
+
+// Equal returns whether counter c and other have the same counts.
+func (c *Counter[T]) Equal(other *Counter[T]) bool {
+	if c.Len() != other.Len() || c.Total() != other.Total() {
+		return false
+	}
+	if c.Len() == 0 {
+		return true
+	}
+	for v, n := range c.m {
+		if other.m[v] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Elements returns all distinct elements.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (c *Counter[T]) Elements() []T {
+	res := make([]T, 0, c.Len())
+	c.Range(func(v T, _ int) bool {
+		res = append(res, v)
+		return true
+	})
+	return res
+}
+
+// ToMap converts the counter into a builtin map from elements to counts.
+func (c *Counter[T]) ToMap() map[T]int {
+	res := make(map[T]int, c.Len())
+	c.Range(func(v T, n int) bool {
+		res[v] = n
+		return true
+	})
+	return res
+}
+
+// Clone returns a copy of the counter.
+func (c *Counter[T]) Clone() *Counter[T] {
+	return &Counter[T]{m: c.ToMap(), total: c.Total()}
+}
+
+// String implements [fmt.Stringer].
+//
+// Experimental: This API is experimental and may change in the future.
+func (c *Counter[T]) String() string {
+	entries := make([]string, 0, c.Len())
+	c.Range(func(v T, n int) bool {
+		entries = append(entries, fmt.Sprintf("%v:%d", v, n))
+		return true
+	})
+	heapsort.Sort(entries)
+	return fmt.Sprintf("counter[%s]", strings.Join(entries, " "))
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// The returned bytes is null or JSON object, keys of object are sorted
+// lexicographically.
+func (c *Counter[T]) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	enc := jsonbuilder.NewDict()
+	for v, n := range c.m {
+		if err := enc.Store(v, n); err != nil {
+			return nil, err
+		}
+	}
+	enc.Sort()
+	return enc.Build()
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// Elements with non-positive count are ignored.
+// The original counts are always overridden.
+func (c *Counter[T]) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+	var m map[T]int
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*c = *FromMap(m)
+	return nil
+}
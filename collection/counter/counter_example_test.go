@@ -0,0 +1,49 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package counter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func Example() {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog the end")
+	c := FromSlice(words)
+
+	fmt.Println(c.Len())         // 9
+	fmt.Println(c.Total())       // 11
+	fmt.Println(c.Count("the"))  // 3
+	fmt.Println(c.MostCommon(1)) // [{the 3}]
+
+	c.SubtractN("the", 2)
+	c.Remove("end")
+	other := FromSlice([]string{"fox", "fox", "cat"})
+	fmt.Println(c.Intersect(other))        // counter[fox:1]
+	fmt.Println(c.Sum(other).Count("fox")) // 3
+
+	bs, _ := json.Marshal(other)
+	fmt.Println(string(bs)) // {"cat":1,"fox":2}
+
+	// Output:
+	// 9
+	// 11
+	// 3
+	// [{the 3}]
+	// counter[fox:1]
+	// 3
+	// {"cat":1,"fox":2}
+}
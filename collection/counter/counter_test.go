@@ -0,0 +1,169 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package counter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/assert"
+	"github.com/bytedance/gg/internal/fastrand"
+)
+
+func TestNilCounter(t *testing.T) {
+	var c *Counter[string]
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.Total())
+	assert.Equal(t, 0, c.Count("a"))
+	assert.False(t, c.Contains("a"))
+	assert.Equal(t, 0, c.Remove("a"))
+	assert.Equal(t, tuple.S2[string, int]{}, c.MostCommon(3))
+	assert.Equal(t, []string{}, c.Elements())
+	assert.Equal(t, map[string]int{}, c.ToMap())
+	assert.True(t, c.Equal(New[string]()))
+	assert.Equal(t, "counter[]", c.String())
+	assert.Equal(t, 0, c.Union(c).Len())
+	assert.Equal(t, 0, c.Intersect(c).Len())
+	assert.Equal(t, 0, c.Sum(c).Len())
+	assert.Equal(t, 0, c.Diff(c).Len())
+	bs, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(bs))
+
+	var zero Counter[string]
+	zero.Add("a")
+	assert.Equal(t, 1, zero.Count("a"))
+}
+
+func TestCounting(t *testing.T) {
+	c := FromSlice([]string{"a", "b", "a", "c", "a"})
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, 5, c.Total())
+	assert.Equal(t, 3, c.Count("a"))
+	assert.Equal(t, 0, c.Count("z"))
+
+	c.Add("b")
+	assert.Equal(t, 5, c.AddN("c", 4))
+	assert.Equal(t, 10, c.Total())
+
+	c.Subtract("a")
+	assert.Equal(t, 2, c.Count("a"))
+	assert.Equal(t, 0, c.SubtractN("b", 5))
+	assert.False(t, c.Contains("b"))
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, 7, c.Total())
+
+	assert.Equal(t, 0, c.AddN("z", -1))
+	assert.False(t, c.Contains("z"))
+	assert.Equal(t, 7, c.Total())
+
+	assert.Equal(t, 5, c.Remove("c"))
+	assert.Equal(t, 0, c.Remove("c"))
+	assert.Equal(t, 2, c.Total())
+	assert.Equal(t, map[string]int{"a": 2}, c.ToMap())
+
+	m := FromMap(gslice.CountValues([]int{1, 2, 2}))
+	assert.Equal(t, 3, m.Total())
+	assert.Equal(t, 1, FromMap(map[int]int{1: 1, 2: 0, 3: -1}).Len())
+}
+
+func TestMostCommon(t *testing.T) {
+	c := FromMap(map[string]int{"a": 5, "b": 1, "c": 3, "d": 4, "e": 2})
+	assert.Equal(t, tuple.S2[string, int]{}, c.MostCommon(0))
+	assert.Equal(t, tuple.S2[string, int]{}, c.MostCommon(-1))
+	assert.Equal(t, tuple.S2[string, int]{tuple.Make2("a", 5)}, c.MostCommon(1))
+	assert.Equal(t,
+		tuple.S2[string, int]{tuple.Make2("a", 5), tuple.Make2("d", 4), tuple.Make2("c", 3)},
+		c.MostCommon(3))
+	assert.Equal(t, []string{"a", "d", "c", "e", "b"}, gslice.Map(c.MostCommon(10), func(kv tuple.T2[string, int]) string { return kv.First }))
+
+	// Compare with sorting.
+	for i := 0; i < 10; i++ {
+		c := New[int]()
+		for j := 0; j < 1000; j++ {
+			c.AddN(j, int(fastrand.Uint32n(100000))+1)
+		}
+		counts := gslice.Map(gslice.Map(c.Elements(), c.Count), func(n int) int { return -n })
+		gslice.Sort(counts)
+		for _, n := range []int{1, 10, 999, 1000} {
+			got := gslice.Map(c.MostCommon(n), func(kv tuple.T2[int, int]) int { return -kv.Second })
+			assert.Equal(t, counts[:n], got)
+		}
+	}
+}
+
+func TestMultisetOperations(t *testing.T) {
+	c1 := FromMap(map[string]int{"a": 3, "b": 1, "c": 2})
+	c2 := FromMap(map[string]int{"a": 1, "b": 4, "d": 5})
+
+	u := c1.Union(c2)
+	assert.Equal(t, map[string]int{"a": 3, "b": 4, "c": 2, "d": 5}, u.ToMap())
+	assert.Equal(t, 14, u.Total())
+
+	i := c1.Intersect(c2)
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, i.ToMap())
+	assert.Equal(t, 2, i.Total())
+
+	s := c1.Sum(c2)
+	assert.Equal(t, map[string]int{"a": 4, "b": 5, "c": 2, "d": 5}, s.ToMap())
+	assert.Equal(t, 16, s.Total())
+
+	d := c1.Diff(c2)
+	assert.Equal(t, map[string]int{"a": 2, "c": 2}, d.ToMap())
+	assert.Equal(t, 4, d.Total())
+
+	// Operands are not modified.
+	assert.Equal(t, map[string]int{"a": 3, "b": 1, "c": 2}, c1.ToMap())
+	assert.Equal(t, map[string]int{"a": 1, "b": 4, "d": 5}, c2.ToMap())
+
+	assert.True(t, c1.Equal(c1.Clone()))
+	assert.False(t, c1.Equal(c2))
+	assert.False(t, c1.Equal(FromMap(map[string]int{"a": 3, "b": 1, "c": 1})))
+	assert.False(t, c1.Equal(FromMap(map[string]int{"a": 3, "b": 1, "d": 2})))
+}
+
+func TestRange(t *testing.T) {
+	c := FromSlice([]int{1, 2, 2, 3})
+	cnt := 0
+	c.Range(func(int, int) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, []int{1, 2, 3}, gslice.SortClone(c.Elements()))
+	assert.Equal(t, "counter[1:1 2:2 3:1]", c.String())
+}
+
+func TestJSON(t *testing.T) {
+	c := FromSlice([]string{"b", "a", "b"})
+	bs, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"b":2}`, string(bs))
+
+	c2 := FromSlice([]string{"x"})
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":1,"b":2,"c":0}`), c2))
+	assert.True(t, c.Equal(c2))
+	assert.Equal(t, 3, c2.Total())
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), c2))
+	assert.Equal(t, 3, c2.Total())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":"1"}`), c2))
+
+	f := FromSlice([]float64{1.5})
+	_, err = json.Marshal(f)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deque implements a double-ended queue based on growable ring buffer.
+//
+// Deque can be used as a FIFO queue, a LIFO stack, or a sliding-window buffer
+// (see [NewFixed]).
+//
+// 💡 NOTE: Deque is not concurrent-safe.
+//
+// To iterate over a deque (where d is a *Deque):
+//
+//	for i := 0; i < d.Len(); i++ {
+//		// do something with d.At(i)
+//	}
+package deque
+
+import (
+	"fmt"
+
+	"github.com/bytedance/gg/goption"
+)
+
+const minCapacity = 16
+
+// Deque is a double-ended queue based on ring buffer.
+// The zero value for Deque is an empty growable deque ready to use.
+type Deque[T any] struct {
+	buf   []T
+	head  int // index of the front element in buf
+	len   int
+	cap   int  // initial capacity, the buffer never shrinks below it
+	fixed bool // fixed-capacity mode, overwrite the oldest entry when full
+}
+
+// New creates an empty growable deque.
+func New[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// NewWithCap creates an empty growable deque with initial capacity.
+// The deque never shrinks below the initial capacity.
+func NewWithCap[T any](capacity int) *Deque[T] {
+	return &Deque[T]{buf: make([]T, capacity), cap: capacity}
+}
+
+// NewFixed creates an empty deque with fixed capacity.
+//
+// When the deque is full, pushing onto one end overwrites the element at the
+// other end: [Deque.PushBack] drops the front element and [Deque.PushFront]
+// drops the back element.
+//
+// 💡 NOTE: Capacity must be positive, otherwise NewFixed panics.
+func NewFixed[T any](capacity int) *Deque[T] {
+	if capacity <= 0 {
+		panic(fmt.Errorf("capacity must be positive: %d", capacity))
+	}
+	return &Deque[T]{buf: make([]T, capacity), fixed: true}
+}
+
+// Len returns the number of elements of deque.
+// The complexity is O(1).
+func (d *Deque[T]) Len() int {
+	if d == nil {
+		return 0
+	}
+	return d.len
+}
+
+// Cap returns the capacity of underlying buffer.
+func (d *Deque[T]) Cap() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.buf)
+}
+
+// index returns the index of buf of the i-th element.
+func (d *Deque[T]) index(i int) int {
+	i += d.head
+	if i >= len(d.buf) {
+		i -= len(d.buf)
+	}
+	return i
+}
+
+// PushBack inserts value v at the back of deque.
+func (d *Deque[T]) PushBack(v T) {
+	if d.len == len(d.buf) {
+		if d.fixed {
+			d.buf[d.head] = v
+			d.head = d.index(1)
+			return
+		}
+		d.resize(d.grownCap())
+	}
+	d.buf[d.index(d.len)] = v
+	d.len++
+}
+
+// PushFront inserts value v at the front of deque.
+func (d *Deque[T]) PushFront(v T) {
+	if d.len == len(d.buf) {
+		if d.fixed {
+			d.head = d.index(len(d.buf) - 1)
+			d.buf[d.head] = v
+			return
+		}
+		d.resize(d.grownCap())
+	}
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes and returns the front element of deque.
+func (d *Deque[T]) PopFront() goption.O[T] {
+	if d.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // avoid memory leaks
+	d.head = d.index(1)
+	d.len--
+	d.shrinkIfNeeded()
+	return goption.OK(v)
+}
+
+// PopBack removes and returns the back element of deque.
+func (d *Deque[T]) PopBack() goption.O[T] {
+	if d.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	var zero T
+	i := d.index(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero // avoid memory leaks
+	d.len--
+	d.shrinkIfNeeded()
+	return goption.OK(v)
+}
+
+// Front returns the front element of deque without removing it.
+func (d *Deque[T]) Front() goption.O[T] {
+	if d.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	return goption.OK(d.buf[d.head])
+}
+
+// Back returns the back element of deque without removing it.
+func (d *Deque[T]) Back() goption.O[T] {
+	if d.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	return goption.OK(d.buf[d.index(d.len-1)])
+}
+
+func (d *Deque[T]) checkIndex(i int) {
+	if i < 0 || i >= d.Len() {
+		panic(fmt.Errorf("index out of range [%d] with length %d", i, d.Len()))
+	}
+}
+
+// At returns the i-th element from the front of deque.
+//
+// 💡 NOTE: At panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex(i)
+	return d.buf[d.index(i)]
+}
+
+// Set sets the i-th element from the front of deque to value v.
+//
+// 💡 NOTE: Set panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex(i)
+	d.buf[d.index(i)] = v
+}
+
+// Rotate rotates the deque n steps to the back: the back element is moved to
+// the front for each step. If n is negative, rotates to the front.
+//
+// The complexity is O(min(|n|, Len-|n|)).
+func (d *Deque[T]) Rotate(n int) {
+	if d.Len() <= 1 {
+		return
+	}
+	n %= d.len
+	if n < 0 {
+		n += d.len
+	}
+	if n == 0 {
+		return
+	}
+	if d.len == len(d.buf) {
+		// Buffer is full, just move the head.
+		d.head = d.index(len(d.buf) - n)
+		return
+	}
+	var zero T
+	if n <= d.len/2 {
+		for ; n > 0; n-- {
+			back := d.index(d.len - 1)
+			d.head = d.index(len(d.buf) - 1)
+			d.buf[d.head] = d.buf[back]
+			d.buf[back] = zero
+		}
+	} else {
+		for n = d.len - n; n > 0; n-- {
+			d.buf[d.index(d.len)] = d.buf[d.head]
+			d.buf[d.head] = zero
+			d.head = d.index(1)
+		}
+	}
+}
+
+// Clear removes all elements of deque.
+// The capacity of deque is kept.
+func (d *Deque[T]) Clear() {
+	if d == nil {
+		return
+	}
+	var zero T
+	for i := 0; i < d.len; i++ {
+		d.buf[d.index(i)] = zero // avoid memory leaks
+	}
+	d.head = 0
+	d.len = 0
+}
+
+// Range calls f sequentially for each element from the front to the back.
+// If f returns false, range stops the iteration.
+func (d *Deque[T]) Range(f func(v T) bool) {
+	for i := 0; i < d.Len(); i++ {
+		if !f(d.buf[d.index(i)]) {
+			return
+		}
+	}
+}
+
+// ToSlice collects all elements from the front to the back.
+func (d *Deque[T]) ToSlice() []T {
+	res := make([]T, d.Len())
+	if d.Len() == 0 {
+		return res
+	}
+	n := copy(res, d.buf[d.head:])
+	copy(res[n:], d.buf[:d.len-n])
+	return res
+}
+
+func (d *Deque[T]) grownCap() int {
+	if len(d.buf) < minCapacity {
+		return minCapacity
+	}
+	return len(d.buf) * 2
+}
+
+// shrinkIfNeeded halves the buffer when it is at most a quarter used,
+// but never below the initial capacity.
+func (d *Deque[T]) shrinkIfNeeded() {
+	floor := minCapacity
+	if d.cap > floor {
+		floor = d.cap
+	}
+	if d.fixed || len(d.buf) <= floor || d.len > len(d.buf)/4 {
+		return
+	}
+	n := len(d.buf) / 2
+	if n < floor {
+		n = floor
+	}
+	d.resize(n)
+}
+
+// resize moves the elements into a new buffer with given capacity.
+func (d *Deque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if d.len > 0 {
+		n := copy(buf, d.buf[d.head:])
+		if n < d.len {
+			copy(buf[n:], d.buf[:d.len-n])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deque
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/collection/list"
+)
+
+func BenchmarkPushBackPopFront(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		d := New[int]()
+		for i := 0; i < b.N; i++ {
+			d.PushBack(i)
+			if i%2 == 0 {
+				d.PopFront()
+			}
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New[int]()
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+			if i%2 == 0 {
+				l.Remove(l.Front())
+			}
+		}
+	})
+}
+
+func BenchmarkSlidingWindow(b *testing.B) {
+	const window = 1024
+	b.Run("Deque", func(b *testing.B) {
+		d := NewFixed[int](window)
+		for i := 0; i < b.N; i++ {
+			d.PushBack(i)
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New[int]()
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+			if l.Len() > window {
+				l.Remove(l.Front())
+			}
+		}
+	})
+}
+
+func BenchmarkStack(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		d := New[int]()
+		for i := 0; i < b.N; i++ {
+			d.PushFront(i)
+			d.PushFront(i)
+			d.PopFront()
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New[int]()
+		for i := 0; i < b.N; i++ {
+			l.PushFront(i)
+			l.PushFront(i)
+			l.Remove(l.Front())
+		}
+	})
+}
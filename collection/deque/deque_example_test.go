@@ -0,0 +1,57 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deque
+
+import (
+	"fmt"
+)
+
+func Example() {
+	d := New[int]()
+	d.PushBack(2)  // 2
+	d.PushBack(3)  // 2 3
+	d.PushFront(1) // 1 2 3
+	d.PushFront(0) // 0 1 2 3
+
+	fmt.Println(d.Len())              // 4
+	fmt.Println(d.At(1))              // 1
+	fmt.Println(d.PopFront().Value()) // 0
+	fmt.Println(d.PopBack().Value())  // 3
+
+	d.Rotate(1)
+	fmt.Println(d.ToSlice()) // [2 1]
+
+	// Output:
+	// 4
+	// 1
+	// 0
+	// 3
+	// [2 1]
+}
+
+func ExampleNewFixed() {
+	window := NewFixed[int](3)
+	for i := 1; i <= 5; i++ {
+		window.PushBack(i)
+		fmt.Println(window.ToSlice())
+	}
+
+	// Output:
+	// [1]
+	// [1 2]
+	// [1 2 3]
+	// [2 3 4]
+	// [3 4 5]
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deque
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/assert"
+	"github.com/bytedance/gg/internal/fastrand"
+)
+
+func TestNilDeque(t *testing.T) {
+	var d *Deque[int]
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, 0, d.Cap())
+	assert.Equal(t, goption.Nil[int](), d.PopFront())
+	assert.Equal(t, goption.Nil[int](), d.PopBack())
+	assert.Equal(t, goption.Nil[int](), d.Front())
+	assert.Equal(t, goption.Nil[int](), d.Back())
+	assert.Equal(t, []int{}, d.ToSlice())
+	assert.Panic(t, func() { d.At(0) })
+	d.Rotate(1)
+	d.Clear()
+	d.Range(func(int) bool { return true })
+
+	var zero Deque[int]
+	zero.PushFront(1)
+	zero.PushBack(2)
+	assert.Equal(t, []int{1, 2}, zero.ToSlice())
+}
+
+func TestPushPop(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+	}
+	assert.Equal(t, 200, d.Len())
+	assert.Equal(t, goption.OK(-99), d.Front())
+	assert.Equal(t, goption.OK(99), d.Back())
+	assert.Equal(t, -99, d.At(0))
+	assert.Equal(t, 99, d.At(199))
+	assert.Equal(t, 0, d.At(99))
+	assert.Equal(t, 0, d.At(100))
+
+	for i := 99; i >= 0; i-- {
+		assert.Equal(t, goption.OK(-i), d.PopFront())
+		assert.Equal(t, goption.OK(i), d.PopBack())
+	}
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, goption.Nil[int](), d.PopFront())
+	assert.Equal(t, goption.Nil[int](), d.PopBack())
+}
+
+func TestFIFO(t *testing.T) {
+	d := NewWithCap[int](3)
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+		if i%3 == 0 {
+			assert.Equal(t, goption.OK(i/3), d.PopFront())
+		}
+	}
+	assert.Equal(t, 666, d.Len())
+	assert.Equal(t, goption.OK(334), d.Front())
+}
+
+func TestAtSet(t *testing.T) {
+	d := New[string]()
+	d.PushBack("b")
+	d.PushFront("a")
+	d.PushBack("c")
+	d.Set(1, "B")
+	assert.Equal(t, []string{"a", "B", "c"}, d.ToSlice())
+	assert.Panic(t, func() { d.At(-1) })
+	assert.Panic(t, func() { d.At(3) })
+	assert.Panic(t, func() { d.Set(3, "") })
+}
+
+func TestShrink(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 1024; i++ {
+		d.PushBack(i)
+	}
+	assert.Equal(t, 1024, d.Cap())
+	for i := 0; i < 1000; i++ {
+		d.PopFront()
+	}
+	assert.True(t, d.Cap() < 1024)
+	assert.True(t, d.Cap() >= d.Len())
+	for i := 0; i < 24; i++ {
+		assert.Equal(t, goption.OK(1000+i), d.PopFront())
+	}
+	assert.Equal(t, minCapacity, d.Cap())
+
+	// Never shrinks below the initial capacity.
+	d = NewWithCap[int](100)
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 1000; i++ {
+		d.PopBack()
+	}
+	assert.Equal(t, 100, d.Cap())
+	d.PushBack(1)
+	assert.Equal(t, 100, d.Cap())
+}
+
+func TestFixed(t *testing.T) {
+	assert.Panic(t, func() { NewFixed[int](0) })
+
+	d := NewFixed[int](3)
+	d.PushBack(1)
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushBack(4) // drop 1
+	assert.Equal(t, []int{2, 3, 4}, d.ToSlice())
+	assert.Equal(t, 3, d.Cap())
+
+	d.PushFront(0) // drop 4
+	assert.Equal(t, []int{0, 2, 3}, d.ToSlice())
+
+	// Sliding window.
+	for i := 10; i < 20; i++ {
+		d.PushBack(i)
+	}
+	assert.Equal(t, []int{17, 18, 19}, d.ToSlice())
+	assert.Equal(t, goption.OK(17), d.PopFront())
+	d.PushBack(20)
+	assert.Equal(t, []int{18, 19, 20}, d.ToSlice())
+	assert.Equal(t, 3, d.Cap())
+
+	for d.Len() > 0 {
+		d.PopBack()
+	}
+	assert.Equal(t, 3, d.Cap())
+}
+
+func TestRotate(t *testing.T) {
+	for _, fixed := range []bool{false, true} {
+		newDeque := func() *Deque[int] {
+			d := New[int]()
+			if fixed {
+				d = NewFixed[int](5)
+			}
+			for i := 0; i < 5; i++ {
+				d.PushBack(i)
+			}
+			return d
+		}
+		cases := []struct {
+			n        int
+			expected []int
+		}{
+			{0, []int{0, 1, 2, 3, 4}},
+			{1, []int{4, 0, 1, 2, 3}},
+			{2, []int{3, 4, 0, 1, 2}},
+			{4, []int{1, 2, 3, 4, 0}},
+			{5, []int{0, 1, 2, 3, 4}},
+			{7, []int{3, 4, 0, 1, 2}},
+			{-1, []int{1, 2, 3, 4, 0}},
+			{-3, []int{3, 4, 0, 1, 2}},
+			{-11, []int{1, 2, 3, 4, 0}},
+		}
+		for _, c := range cases {
+			d := newDeque()
+			d.Rotate(c.n)
+			assert.Equal(t, c.expected, d.ToSlice())
+		}
+	}
+
+	// Rotate with wrapped buffer.
+	d := NewWithCap[int](8)
+	for i := 0; i < 6; i++ {
+		d.PushBack(i)
+	}
+	d.PopFront()
+	d.PopFront()
+	d.PushBack(6)
+	d.PushBack(7)
+	d.PushBack(8) // 2..8, wrapped
+	d.Rotate(3)
+	assert.Equal(t, []int{6, 7, 8, 2, 3, 4, 5}, d.ToSlice())
+	d.Rotate(-5)
+	assert.Equal(t, []int{4, 5, 6, 7, 8, 2, 3}, d.ToSlice())
+}
+
+func TestClearRange(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 10; i++ {
+		d.PushFront(i)
+	}
+	var got []int
+	d.Range(func(v int) bool {
+		got = append(got, v)
+		return v > 7
+	})
+	assert.Equal(t, []int{9, 8, 7}, got)
+
+	c := d.Cap()
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, c, d.Cap())
+	d.PushBack(1)
+	assert.Equal(t, []int{1}, d.ToSlice())
+}
+
+func TestRandom(t *testing.T) {
+	d := New[int]()
+	var s []int
+	for i := 0; i < 10000; i++ {
+		switch fastrand.Intn(5) {
+		case 0:
+			d.PushFront(i)
+			s = append([]int{i}, s...)
+		case 1:
+			d.PushBack(i)
+			s = append(s, i)
+		case 2:
+			if len(s) > 0 {
+				assert.Equal(t, goption.OK(s[0]), d.PopFront())
+				s = s[1:]
+			} else {
+				assert.Equal(t, goption.Nil[int](), d.PopFront())
+			}
+		case 3:
+			if len(s) > 0 {
+				assert.Equal(t, goption.OK(s[len(s)-1]), d.PopBack())
+				s = s[:len(s)-1]
+			}
+		case 4:
+			if len(s) > 0 {
+				n := fastrand.Intn(len(s))
+				d.Rotate(n)
+				s = append(s[len(s)-n:], s[:len(s)-n]...)
+			}
+		}
+		assert.Equal(t, len(s), d.Len())
+	}
+	assert.Equal(t, append([]int{}, s...), d.ToSlice())
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph provides a generic weighted graph with standard algorithms.
+//
+// 💡 NOTE: Graph is not concurrent-safe.
+//
+// # Structures
+//
+//   - [Graph]: directed or undirected graph, see [NewDirected] and [NewUndirected]
+//   - [Edge]
+//
+// # Operations
+//
+//   - Constructor: [NewDirected], [NewUndirected]
+//   - CRUD operations: [Graph.AddNode], [Graph.AddEdge], [Graph.RemoveNode], [Graph.RemoveEdge], …
+//   - Adjacency: [Graph.Successors], [Graph.Predecessors], [Graph.OutDegree], [Graph.InDegree], …
+//   - Traversal: [Graph.BFS], [Graph.DFS]
+//   - Algorithms: [Graph.TopoSort], [Graph.StronglyConnectedComponents], [Graph.ConnectedComponents],
+//     [Graph.Dijkstra], [Graph.ShortestPath], [Graph.MinimumSpanningTree]
+//
+// # Deterministic order
+//
+// Nodes and edges are kept in the order they were added, all operations and
+// algorithms visit them in that order, so results are deterministic.
+package graph
+
+import (
+	"errors"
+
+	"github.com/bytedance/gg/collection/linkedmap"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+var (
+	// ErrUndirected is returned when an operation requires a directed graph.
+	ErrUndirected = errors.New("graph: operation requires a directed graph")
+	// ErrDirected is returned when an operation requires an undirected graph.
+	ErrDirected = errors.New("graph: operation requires an undirected graph")
+)
+
+// Edge is a weighted edge.
+type Edge[N comparable, W constraints.Number] struct {
+	From   N
+	To     N
+	Weight W
+}
+
+type vertex[N comparable, W constraints.Number] struct {
+	out *linkedmap.LinkedMap[N, W]
+	in  *linkedmap.LinkedMap[N, W] // same as out in undirected graph
+}
+
+// Graph is a weighted graph whose nodes are comparable values.
+//
+// Use [NewDirected] or [NewUndirected] to create a Graph.
+// Parallel edges are not supported: adding an existing edge overrides its weight.
+type Graph[N comparable, W constraints.Number] struct {
+	nodes    *linkedmap.LinkedMap[N, *vertex[N, W]]
+	edges    int
+	directed bool
+}
+
+// NewDirected creates an empty directed graph.
+func NewDirected[N comparable, W constraints.Number]() *Graph[N, W] {
+	return &Graph[N, W]{nodes: linkedmap.New[N, *vertex[N, W]](), directed: true}
+}
+
+// NewUndirected creates an empty undirected graph.
+func NewUndirected[N comparable, W constraints.Number]() *Graph[N, W] {
+	return &Graph[N, W]{nodes: linkedmap.New[N, *vertex[N, W]]()}
+}
+
+// IsDirected returns whether the graph is directed.
+func (g *Graph[N, W]) IsDirected() bool {
+	return g.directed
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph[N, W]) NodeCount() int {
+	return g.nodes.Len()
+}
+
+// EdgeCount returns the number of edges.
+func (g *Graph[N, W]) EdgeCount() int {
+	return g.edges
+}
+
+// AddNode adds a node without edges.
+// It returns false if the node is already present.
+func (g *Graph[N, W]) AddNode(n N) bool {
+	if g.nodes.Contains(n) {
+		return false
+	}
+	g.vertex(n)
+	return true
+}
+
+// vertex returns the vertex of n, n is added if absent.
+func (g *Graph[N, W]) vertex(n N) *vertex[N, W] {
+	if v, ok := g.nodes.Peek(n); ok {
+		return v
+	}
+	v := &vertex[N, W]{out: linkedmap.New[N, W]()}
+	if g.directed {
+		v.in = linkedmap.New[N, W]()
+	} else {
+		v.in = v.out
+	}
+	g.nodes.Store(n, v)
+	return v
+}
+
+// HasNode returns whether the node is present.
+func (g *Graph[N, W]) HasNode(n N) bool {
+	return g.nodes.Contains(n)
+}
+
+// RemoveNode removes a node and all its edges.
+// It returns false if the node is not present.
+func (g *Graph[N, W]) RemoveNode(n N) bool {
+	v, ok := g.nodes.LoadAndDelete(n)
+	if !ok {
+		return false
+	}
+	v.out.Range(func(m N, _ W) bool {
+		if m != n {
+			g.vertexOf(m).in.Delete(n)
+		}
+		g.edges--
+		return true
+	})
+	if g.directed {
+		v.in.Range(func(m N, _ W) bool {
+			if m != n {
+				g.vertexOf(m).out.Delete(n)
+				g.edges--
+			}
+			return true
+		})
+	}
+	return true
+}
+
+// vertexOf returns the vertex of an existing node.
+func (g *Graph[N, W]) vertexOf(n N) *vertex[N, W] {
+	v, _ := g.nodes.Peek(n)
+	return v
+}
+
+// AddEdge adds an edge from node from to node to with weight w, absent
+// nodes are added first.
+// If the edge is already present, its weight is overridden.
+//
+// In undirected graph, edge (from, to) is identical with edge (to, from).
+func (g *Graph[N, W]) AddEdge(from, to N, w W) {
+	vf, vt := g.vertex(from), g.vertex(to)
+	if !vf.out.Contains(to) {
+		g.edges++
+	}
+	vf.out.Store(to, w)
+	vt.in.Store(from, w)
+}
+
+// HasEdge returns whether the edge is present.
+func (g *Graph[N, W]) HasEdge(from, to N) bool {
+	v, ok := g.nodes.Peek(from)
+	return ok && v.out.Contains(to)
+}
+
+// Weight returns the weight of an edge.
+// The ok result indicates whether the edge was found.
+func (g *Graph[N, W]) Weight(from, to N) (w W, ok bool) {
+	v, ok := g.nodes.Peek(from)
+	if !ok {
+		return w, false
+	}
+	return v.out.Peek(to)
+}
+
+// RemoveEdge removes an edge.
+// It returns false if the edge is not present.
+func (g *Graph[N, W]) RemoveEdge(from, to N) bool {
+	v, ok := g.nodes.Peek(from)
+	if !ok || !v.out.Delete(to) {
+		return false
+	}
+	g.vertexOf(to).in.Delete(from)
+	g.edges--
+	return true
+}
+
+// Nodes returns all nodes in the order they were added.
+func (g *Graph[N, W]) Nodes() []N {
+	return g.nodes.Keys()
+}
+
+// Edges returns all edges.
+//
+// In undirected graph, each edge is returned once, and its From is the node
+// added earlier.
+func (g *Graph[N, W]) Edges() []Edge[N, W] {
+	edges := make([]Edge[N, W], 0, g.edges)
+	done := make(map[N]struct{}, g.nodes.Len())
+	g.nodes.Range(func(n N, v *vertex[N, W]) bool {
+		v.out.Range(func(m N, w W) bool {
+			if _, ok := done[m]; g.directed || !ok {
+				edges = append(edges, Edge[N, W]{n, m, w})
+			}
+			return true
+		})
+		done[n] = struct{}{}
+		return true
+	})
+	return edges
+}
+
+// Successors returns nodes which node n has edges to.
+//
+// In undirected graph, successors are the neighbors.
+func (g *Graph[N, W]) Successors(n N) []N {
+	v, ok := g.nodes.Peek(n)
+	if !ok {
+		return nil
+	}
+	return v.out.Keys()
+}
+
+// Predecessors returns nodes which have edges to node n.
+//
+// In undirected graph, predecessors are the neighbors.
+func (g *Graph[N, W]) Predecessors(n N) []N {
+	v, ok := g.nodes.Peek(n)
+	if !ok {
+		return nil
+	}
+	return v.in.Keys()
+}
+
+// OutDegree returns the number of edges from node n.
+func (g *Graph[N, W]) OutDegree(n N) int {
+	v, ok := g.nodes.Peek(n)
+	if !ok {
+		return 0
+	}
+	return v.out.Len()
+}
+
+// InDegree returns the number of edges to node n.
+func (g *Graph[N, W]) InDegree(n N) int {
+	v, ok := g.nodes.Peek(n)
+	if !ok {
+		return 0
+	}
+	return v.in.Len()
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+)
+
+func Example() {
+	// Build dependencies: an edge (a, b) means a must be built before b.
+	deps := NewDirected[string, int]()
+	deps.AddEdge("proto", "server", 1)
+	deps.AddEdge("proto", "client", 1)
+	deps.AddEdge("lib", "server", 1)
+	deps.AddEdge("server", "image", 1)
+
+	order, err := deps.TopoSort()
+	fmt.Println(order, err)
+
+	deps.AddEdge("image", "proto", 1)
+	_, err = deps.TopoSort()
+	fmt.Println(err)
+
+	// Output:
+	// [proto lib client server image] <nil>
+	// graph: cycle detected: server -> image -> proto -> server
+}
+
+func ExampleGraph_ShortestPath() {
+	roads := NewUndirected[string, int]()
+	roads.AddEdge("A", "B", 7)
+	roads.AddEdge("A", "C", 2)
+	roads.AddEdge("C", "B", 3)
+	roads.AddEdge("B", "D", 1)
+
+	fmt.Println(roads.ShortestPath("A", "D"))
+
+	mst, _ := roads.MinimumSpanningTree()
+	fmt.Println(mst)
+
+	// Output:
+	// [A C B D] 6 true
+	// [{B D 1} {A C 2} {B C 3}]
+}
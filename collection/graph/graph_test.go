@@ -0,0 +1,225 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestDirectedGraph(t *testing.T) {
+	g := NewDirected[string, int]()
+	assert.True(t, g.IsDirected())
+	assert.True(t, g.AddNode("a"))
+	assert.False(t, g.AddNode("a"))
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddEdge("c", "b", 3)
+	g.AddEdge("b", "b", 4) // self loop
+	g.AddEdge("a", "b", 5) // override
+	assert.Equal(t, 3, g.NodeCount())
+	assert.Equal(t, 4, g.EdgeCount())
+	assert.True(t, g.HasNode("c"))
+	assert.False(t, g.HasNode("d"))
+	assert.True(t, g.HasEdge("a", "b"))
+	assert.False(t, g.HasEdge("b", "a"))
+	assert.False(t, g.HasEdge("d", "a"))
+	w, ok := g.Weight("a", "b")
+	assert.True(t, ok)
+	assert.Equal(t, 5, w)
+	_, ok = g.Weight("b", "a")
+	assert.False(t, ok)
+	_, ok = g.Weight("d", "a")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"a", "b", "c"}, g.Nodes())
+	assert.Equal(t, []Edge[string, int]{{"a", "b", 5}, {"a", "c", 2}, {"b", "b", 4}, {"c", "b", 3}}, g.Edges())
+	assert.Equal(t, []string{"b", "c"}, g.Successors("a"))
+	assert.Equal(t, []string{"a", "c", "b"}, g.Predecessors("b"))
+	assert.Equal(t, []string(nil), g.Successors("d"))
+	assert.Equal(t, []string(nil), g.Predecessors("d"))
+	assert.Equal(t, 2, g.OutDegree("a"))
+	assert.Equal(t, 3, g.InDegree("b"))
+	assert.Equal(t, 0, g.OutDegree("d"))
+	assert.Equal(t, 0, g.InDegree("d"))
+
+	assert.True(t, g.RemoveEdge("a", "c"))
+	assert.False(t, g.RemoveEdge("a", "c"))
+	assert.False(t, g.RemoveEdge("d", "c"))
+	assert.Equal(t, 3, g.EdgeCount())
+	assert.Equal(t, 0, g.InDegree("c"))
+
+	assert.True(t, g.RemoveNode("b"))
+	assert.False(t, g.RemoveNode("b"))
+	assert.Equal(t, 0, g.EdgeCount())
+	assert.Equal(t, []string{"a", "c"}, g.Nodes())
+	assert.Equal(t, 0, g.OutDegree("a"))
+	assert.Equal(t, 0, g.OutDegree("c"))
+}
+
+func TestUndirectedGraph(t *testing.T) {
+	g := NewUndirected[int, float64]()
+	assert.False(t, g.IsDirected())
+	g.AddEdge(1, 2, 1.5)
+	g.AddEdge(2, 3, 2.5)
+	g.AddEdge(3, 1, 3.5)
+	g.AddEdge(2, 1, 0.5) // override
+	g.AddEdge(3, 3, 1)
+	assert.Equal(t, 4, g.EdgeCount())
+	assert.True(t, g.HasEdge(2, 1))
+	assert.True(t, g.HasEdge(1, 2))
+	w, _ := g.Weight(1, 2)
+	assert.Equal(t, 0.5, w)
+	assert.Equal(t, []Edge[int, float64]{{1, 2, 0.5}, {1, 3, 3.5}, {2, 3, 2.5}, {3, 3, 1}}, g.Edges())
+	assert.Equal(t, []int{2, 1, 3}, g.Successors(3))
+	assert.Equal(t, g.Successors(3), g.Predecessors(3))
+
+	assert.True(t, g.RemoveEdge(3, 2))
+	assert.False(t, g.HasEdge(2, 3))
+	assert.Equal(t, 3, g.EdgeCount())
+
+	assert.True(t, g.RemoveNode(3))
+	assert.Equal(t, 1, g.EdgeCount())
+	assert.Equal(t, []int{2}, g.Successors(1))
+}
+
+func TestTraversal(t *testing.T) {
+	g := NewDirected[int, int]()
+	// 1 -> 2 -> 4
+	// |    |
+	// v    v
+	// 3 -> 5    6
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(1, 3, 0)
+	g.AddEdge(2, 4, 0)
+	g.AddEdge(2, 5, 0)
+	g.AddEdge(3, 5, 0)
+	g.AddNode(6)
+
+	collect := func(traverse func(int, func(int) bool), start, limit int) []int {
+		var res []int
+		traverse(start, func(n int) bool {
+			res = append(res, n)
+			return len(res) < limit
+		})
+		return res
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, collect(g.BFS, 1, 100))
+	assert.Equal(t, []int{1, 2, 4, 5, 3}, collect(g.DFS, 1, 100))
+	assert.Equal(t, []int{1, 2, 3}, collect(g.BFS, 1, 3))
+	assert.Equal(t, []int{1, 2, 4}, collect(g.DFS, 1, 3))
+	assert.Equal(t, []int{3, 5}, collect(g.DFS, 3, 100))
+	assert.Equal(t, []int(nil), collect(g.BFS, 7, 100))
+	assert.Equal(t, []int(nil), collect(g.DFS, 7, 100))
+}
+
+func TestTopoSort(t *testing.T) {
+	g := NewDirected[string, int]()
+	g.AddEdge("app", "lib", 0)
+	g.AddEdge("app", "log", 0)
+	g.AddEdge("lib", "log", 0)
+	g.AddNode("tool")
+	order, err := g.TopoSort()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app", "tool", "lib", "log"}, order)
+
+	g.AddEdge("log", "util", 0)
+	g.AddEdge("util", "lib", 0)
+	_, err = g.TopoSort()
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"log", "util", "lib", "log"}, cycleErr.Cycle)
+	assert.Equal(t, "graph: cycle detected: log -> util -> lib -> log", err.Error())
+
+	// Self loop.
+	g2 := NewDirected[int, int]()
+	g2.AddEdge(1, 1, 0)
+	_, err = g2.TopoSort()
+	assert.Equal(t, "graph: cycle detected: 1 -> 1", err.Error())
+
+	_, err = NewUndirected[int, int]().TopoSort()
+	assert.Equal(t, ErrUndirected, err)
+}
+
+func TestComponents(t *testing.T) {
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 3, 0)
+	g.AddEdge(3, 1, 0)
+	g.AddEdge(3, 4, 0)
+	g.AddEdge(4, 5, 0)
+	g.AddEdge(5, 4, 0)
+	g.AddEdge(6, 7, 0)
+	assert.Equal(t, [][]int{{5, 4}, {3, 2, 1}, {7}, {6}}, g.StronglyConnectedComponents())
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}, {6, 7}}, g.ConnectedComponents())
+
+	u := NewUndirected[int, int]()
+	u.AddEdge(1, 2, 0)
+	u.AddEdge(3, 4, 0)
+	u.AddNode(5)
+	assert.Equal(t, [][]int{{2, 1}, {4, 3}, {5}}, u.StronglyConnectedComponents())
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, u.ConnectedComponents())
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewDirected[string, int]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddNode("e")
+
+	assert.Equal(t, map[string]int{"a": 0, "b": 3, "c": 1, "d": 4}, g.Dijkstra("a"))
+	assert.Equal(t, map[string]int{}, g.Dijkstra("x"))
+
+	path, total, ok := g.ShortestPath("a", "d")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "c", "b", "d"}, path)
+	assert.Equal(t, 4, total)
+
+	path, total, ok = g.ShortestPath("a", "a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, path)
+	assert.Equal(t, 0, total)
+
+	_, _, ok = g.ShortestPath("a", "e")
+	assert.False(t, ok)
+	_, _, ok = g.ShortestPath("d", "a")
+	assert.False(t, ok)
+
+	g.AddEdge("d", "a", -1)
+	assert.Panic(t, func() { g.Dijkstra("a") })
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewUndirected[string, float64]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("a", "c", 2.5)
+	g.AddEdge("c", "d", 1)
+	g.AddEdge("b", "d", 3)
+	g.AddEdge("x", "y", 7)
+	g.AddNode("z")
+
+	tree, err := g.MinimumSpanningTree()
+	assert.Nil(t, err)
+	assert.Equal(t, []Edge[string, float64]{{"a", "b", 1}, {"c", "d", 1}, {"b", "c", 2}, {"x", "y", 7}}, tree)
+
+	_, err = NewDirected[int, int]().MinimumSpanningTree()
+	assert.Equal(t, ErrDirected, err)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/gg/collection/pqueue"
+	"github.com/bytedance/gg/collection/unionfind"
+)
+
+type distance[N comparable, W any] struct {
+	node N
+	dist W
+}
+
+// dijkstra computes shortest distances from src, and the predecessor of each
+// reached node in the shortest path tree.
+// If dst is reached, the computation stops early.
+func (g *Graph[N, W]) dijkstra(src N, dst *N) (map[N]W, map[N]N) {
+	dist := map[N]W{}
+	prev := map[N]N{}
+	if !g.nodes.Contains(src) {
+		return dist, prev
+	}
+	pq := pqueue.New(func(a, b distance[N, W]) bool { return a.dist < b.dist })
+	handles := map[N]*pqueue.Handle[distance[N, W]]{}
+	var zero W
+	dist[src] = zero
+	handles[src] = pq.Push(distance[N, W]{src, zero})
+	done := map[N]struct{}{}
+	for pq.Len() != 0 {
+		d := pq.Pop().Value()
+		done[d.node] = struct{}{}
+		if dst != nil && d.node == *dst {
+			break
+		}
+		g.vertexOf(d.node).out.Range(func(m N, w W) bool {
+			if w < zero {
+				panic(fmt.Errorf("graph: negative weight of edge (%v, %v): %v", d.node, m, w))
+			}
+			if _, ok := done[m]; ok {
+				return true
+			}
+			nd := d.dist + w
+			if old, ok := dist[m]; !ok {
+				handles[m] = pq.Push(distance[N, W]{m, nd})
+			} else if nd < old {
+				pq.Update(handles[m], distance[N, W]{m, nd})
+			} else {
+				return true
+			}
+			dist[m] = nd
+			prev[m] = d.node
+			return true
+		})
+	}
+	return dist, prev
+}
+
+// Dijkstra returns the shortest distances from node src to all reachable
+// nodes, unreachable nodes are absent in the returned map.
+//
+// 💡 NOTE: It panics if any reachable edge has negative weight.
+func (g *Graph[N, W]) Dijkstra(src N) map[N]W {
+	dist, _ := g.dijkstra(src, nil)
+	return dist
+}
+
+// ShortestPath returns one of the shortest paths from node src to node dst
+// and its total weight.
+// The ok result reports whether dst is reachable from src.
+//
+// 💡 NOTE: It panics if any reachable edge has negative weight.
+func (g *Graph[N, W]) ShortestPath(src, dst N) (path []N, total W, ok bool) {
+	dist, prev := g.dijkstra(src, &dst)
+	total, ok = dist[dst]
+	if !ok {
+		return nil, total, false
+	}
+	for n := dst; ; n = prev[n] {
+		path = append(path, n)
+		if n == src {
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, total, true
+}
+
+// MinimumSpanningTree returns edges of a minimum spanning tree, or a minimum
+// spanning forest if the graph is not connected.
+//
+// It returns [ErrDirected] if the graph is directed.
+func (g *Graph[N, W]) MinimumSpanningTree() ([]Edge[N, W], error) {
+	if g.directed {
+		return nil, ErrDirected
+	}
+	// Kruskal's algorithm.
+	edges := g.Edges()
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+	d := unionfind.New(g.Nodes()...)
+	tree := make([]Edge[N, W], 0, g.nodes.Len())
+	for _, e := range edges {
+		if d.Union(e.From, e.To) {
+			tree = append(tree, e)
+		}
+	}
+	return tree, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/gg/collection/unionfind"
+)
+
+// BFS traverses nodes reachable from start in breadth-first order, and calls
+// f for each of them.
+// If f returns false, the traversal stops.
+func (g *Graph[N, W]) BFS(start N, f func(N) bool) {
+	if !g.nodes.Contains(start) {
+		return
+	}
+	visited := map[N]struct{}{start: {}}
+	queue := []N{start}
+	for len(queue) != 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if !f(n) {
+			return
+		}
+		g.vertexOf(n).out.Range(func(m N, _ W) bool {
+			if _, ok := visited[m]; !ok {
+				visited[m] = struct{}{}
+				queue = append(queue, m)
+			}
+			return true
+		})
+	}
+}
+
+// DFS traverses nodes reachable from start in depth-first pre-order, and
+// calls f for each of them.
+// If f returns false, the traversal stops.
+func (g *Graph[N, W]) DFS(start N, f func(N) bool) {
+	if !g.nodes.Contains(start) {
+		return
+	}
+	g.dfs(start, map[N]struct{}{}, f)
+}
+
+func (g *Graph[N, W]) dfs(n N, visited map[N]struct{}, f func(N) bool) bool {
+	visited[n] = struct{}{}
+	if !f(n) {
+		return false
+	}
+	cont := true
+	g.vertexOf(n).out.Range(func(m N, _ W) bool {
+		if _, ok := visited[m]; !ok {
+			cont = g.dfs(m, visited, f)
+		}
+		return cont
+	})
+	return cont
+}
+
+// CycleError is returned by [Graph.TopoSort] when the graph has a cycle.
+type CycleError[N comparable] struct {
+	// Cycle is a path of nodes in the cycle, the first node is repeated at the end.
+	Cycle []N
+}
+
+// Error implements error.
+func (e *CycleError[N]) Error() string {
+	nodes := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		nodes[i] = fmt.Sprintf("%v", n)
+	}
+	return "graph: cycle detected: " + strings.Join(nodes, " -> ")
+}
+
+// TopoSort returns nodes in topological order: for every edge (u, v),
+// u comes before v.
+//
+// Nodes without order constraints between them are in the order they were
+// added.
+// If the graph has a cycle, a [*CycleError] reporting one of the cycles is
+// returned.
+// It returns [ErrUndirected] if the graph is undirected.
+func (g *Graph[N, W]) TopoSort() ([]N, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	// Kahn's algorithm.
+	indegree := make(map[N]int, g.nodes.Len())
+	order := make([]N, 0, g.nodes.Len())
+	g.nodes.Range(func(n N, v *vertex[N, W]) bool {
+		indegree[n] = v.in.Len()
+		if v.in.Len() == 0 {
+			order = append(order, n)
+		}
+		return true
+	})
+	for i := 0; i < len(order); i++ {
+		g.vertexOf(order[i]).out.Range(func(m N, _ W) bool {
+			indegree[m]--
+			if indegree[m] == 0 {
+				order = append(order, m)
+			}
+			return true
+		})
+	}
+	if len(order) == g.nodes.Len() {
+		return order, nil
+	}
+	return nil, &CycleError[N]{g.findCycle(indegree)}
+}
+
+// findCycle finds a cycle in nodes with positive indegree.
+//
+// Every remaining node has a remaining predecessor, so walking backward from
+// any of them must reach a visited node.
+func (g *Graph[N, W]) findCycle(indegree map[N]int) []N {
+	var start N
+	g.nodes.Range(func(n N, _ *vertex[N, W]) bool {
+		start = n
+		return indegree[n] == 0
+	})
+	pos := map[N]int{}
+	var path []N
+	for n := start; ; {
+		if i, ok := pos[n]; ok {
+			path = path[i:]
+			break
+		}
+		pos[n] = len(path)
+		path = append(path, n)
+		g.vertexOf(n).in.Range(func(m N, _ W) bool {
+			if indegree[m] > 0 {
+				n = m
+				return false
+			}
+			return true
+		})
+	}
+	// Reverse the backward path and close the cycle.
+	cycle := make([]N, 0, len(path)+1)
+	for i := len(path) - 1; i >= 0; i-- {
+		cycle = append(cycle, path[i])
+	}
+	return append(cycle, cycle[0])
+}
+
+// StronglyConnectedComponents returns strongly connected components of the
+// graph, in reverse topological order of the condensed graph.
+//
+// In undirected graph, they are the connected components.
+func (g *Graph[N, W]) StronglyConnectedComponents() [][]N {
+	// Tarjan's algorithm.
+	type state struct {
+		index, low int
+		onStack    bool
+	}
+	states := make(map[N]*state, g.nodes.Len())
+	var stack []N
+	var sccs [][]N
+	var visit func(n N)
+	visit = func(n N) {
+		s := &state{len(states), len(states), true}
+		states[n] = s
+		stack = append(stack, n)
+		g.vertexOf(n).out.Range(func(m N, _ W) bool {
+			if t, ok := states[m]; !ok {
+				visit(m)
+				if t = states[m]; t.low < s.low {
+					s.low = t.low
+				}
+			} else if t.onStack && t.index < s.low {
+				s.low = t.index
+			}
+			return true
+		})
+		if s.low != s.index {
+			return
+		}
+		var scc []N
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			states[m].onStack = false
+			scc = append(scc, m)
+			if m == n {
+				break
+			}
+		}
+		sccs = append(sccs, scc)
+	}
+	g.nodes.Range(func(n N, _ *vertex[N, W]) bool {
+		if _, ok := states[n]; !ok {
+			visit(n)
+		}
+		return true
+	})
+	return sccs
+}
+
+// ConnectedComponents returns connected components of the graph, ignoring
+// directions of edges (so called weakly connected components in directed
+// graph).
+//
+// Components are ordered by their earliest added node, and nodes of each
+// component are in the order they were added.
+func (g *Graph[N, W]) ConnectedComponents() [][]N {
+	d := unionfind.New(g.Nodes()...)
+	g.nodes.Range(func(n N, v *vertex[N, W]) bool {
+		v.out.Range(func(m N, _ W) bool {
+			d.Union(n, m)
+			return true
+		})
+		return true
+	})
+	return d.Sets()
+}
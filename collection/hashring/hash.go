@@ -0,0 +1,94 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashring
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+// Jump returns the bucket in [0, buckets) which key belongs to,
+// using the jump consistent hash algorithm.
+//
+// Jump needs no memory and distributes keys evenly, but buckets can only be
+// added or removed at the end.
+//
+// See "A Fast, Minimal Memory, Consistent Hash Algorithm" (Lamping & Veach, 2014).
+func Jump[K comparable](key K, buckets int) int {
+	if buckets <= 0 {
+		panic(fmt.Errorf("buckets must be positive: %d", buckets))
+	}
+	h := hashing.Sum64(key, 0)
+	b, j := int64(-1), int64(0)
+	for j < int64(buckets) {
+		b = j
+		h = h*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((h>>33)+1)))
+	}
+	return int(b)
+}
+
+// Rendezvous returns the node which key belongs to,
+// using the rendezvous (highest random weight) hashing.
+// If nodes is empty, return nil.
+//
+// Rendezvous allows removing arbitrary nodes with minimal disruption,
+// but the complexity is O(n).
+func Rendezvous[K, N comparable](key K, nodes []N) goption.O[N] {
+	if len(nodes) == 0 {
+		return goption.Nil[N]()
+	}
+	kh := hashing.Sum64(key, 0)
+	best := point[N]{score(kh, nodes[0]), 0, nodes[0]}
+	for _, n := range nodes[1:] {
+		if p := (point[N]{score(kh, n), 0, n}); best.less(p) {
+			best = p
+		}
+	}
+	return goption.OK(best.node)
+}
+
+// RendezvousN is a variant of [Rendezvous], returns at most n nodes for key,
+// in the order of preference.
+//
+// 💡 NOTE: nodes should not contain duplicates.
+func RendezvousN[K, N comparable](key K, nodes []N, n int) []N {
+	if n > len(nodes) {
+		n = len(nodes)
+	}
+	if n <= 0 {
+		return []N{}
+	}
+	kh := hashing.Sum64(key, 0)
+	scored := make([]point[N], len(nodes))
+	for i, node := range nodes {
+		scored[i] = point[N]{score(kh, node), 0, node}
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[j].less(scored[i])
+	})
+	res := make([]N, n)
+	for i := range res {
+		res[i] = scored[i].node
+	}
+	return res
+}
+
+func score[N comparable](kh uint64, node N) uint64 {
+	return hashing.Sum64(node, kh)
+}
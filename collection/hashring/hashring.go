@@ -0,0 +1,307 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashring provides consistent hashing algorithms for distributing keys
+// over a set of nodes.
+//
+// # Structures
+//
+//   - [Ring]: a hash ring with weighted virtual nodes and optional bounded loads
+//
+// # Operations
+//
+//   - Constructor: [New], [NewBounded]
+//   - Node operations: [Ring.Add], [Ring.Remove], [Ring.Contains], [Ring.Nodes], …
+//   - Lookup: [Ring.Get], [Ring.GetN]
+//   - Bounded loads: [Ring.Acquire], [Ring.Release], [Ring.Load]
+//   - Alternatives: [Jump], [Rendezvous], [RendezvousN]
+//
+// # Determinism
+//
+// All algorithms are deterministic: the same set of nodes always maps a key
+// to the same node, across processes and platforms, regardless of the order
+// in which nodes are added.
+//
+// 💡 NOTE: Nodes of pointer and channel types are hashed by address, so they
+// are only deterministic within a process.
+package hashring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+// Ring is a consistent hash ring.
+//
+// Each node is placed on the ring as weight × vnodes virtual nodes,
+// a key belongs to the first virtual node clockwise from its hash.
+// Adding or removing a node only moves about 1/n of keys.
+//
+// 💡 NOTE: Ring is not concurrent-safe.
+type Ring[N comparable] struct {
+	vnodes     int
+	loadFactor float64 // 0 if loads are not bounded
+	nodes      map[N]*nodeInfo
+	order      []N // nodes in insertion order
+	points     []point[N]
+	weight     int // total weight of nodes
+	load       int // total load of nodes
+}
+
+type nodeInfo struct {
+	weight int
+	load   int
+}
+
+type point[N comparable] struct {
+	hash  uint64
+	vnode int // index of virtual node
+	node  N
+}
+
+// tieSeed seeds the hash which breaks ties of points with the same hash and
+// virtual node index.
+const tieSeed = 0x2545f4914f6cdd1d
+
+// less orders points by hash, ties are broken by virtual node index and then
+// by another hash of node, so that the order never depends on the order in
+// which nodes are added.
+func (p point[N]) less(q point[N]) bool {
+	if p.hash != q.hash {
+		return p.hash < q.hash
+	}
+	if p.vnode != q.vnode {
+		return p.vnode < q.vnode
+	}
+	return hashing.Sum64(p.node, tieSeed) < hashing.Sum64(q.node, tieSeed)
+}
+
+// New creates an empty ring, each unit of weight of node is placed on the ring
+// as vnodes virtual nodes.
+//
+// 💡 HINT: 100~200 virtual nodes per node give a good balance in practice.
+func New[N comparable](vnodes int) *Ring[N] {
+	if vnodes <= 0 {
+		panic(fmt.Errorf("vnodes must be positive: %d", vnodes))
+	}
+	return &Ring[N]{vnodes: vnodes, nodes: make(map[N]*nodeInfo)}
+}
+
+// NewBounded creates an empty ring with bounded loads.
+//
+// The load of each node acquired by [Ring.Acquire] never exceeds
+// ⌈loadFactor × (total load) × weight / (total weight)⌉,
+// keys are forwarded clockwise to the next node with spare capacity.
+// loadFactor must be at least 1, typical values are 1.25~2.
+//
+// See "Consistent Hashing with Bounded Loads" (Mirrokni et al., 2016).
+func NewBounded[N comparable](vnodes int, loadFactor float64) *Ring[N] {
+	if !(loadFactor >= 1) {
+		panic(fmt.Errorf("loadFactor must be at least 1: %v", loadFactor))
+	}
+	r := New[N](vnodes)
+	r.loadFactor = loadFactor
+	return r
+}
+
+// Len returns the number of nodes of the ring.
+func (r *Ring[N]) Len() int {
+	return len(r.order)
+}
+
+// Add adds a node with weight to the ring.
+// If the node already exists, its weight is updated and its load is kept.
+func (r *Ring[N]) Add(node N, weight int) {
+	if weight <= 0 {
+		panic(fmt.Errorf("weight must be positive: %d", weight))
+	}
+	info, ok := r.nodes[node]
+	if ok {
+		if info.weight == weight {
+			return
+		}
+		r.removePoints(node)
+		r.weight -= info.weight
+		info.weight = weight
+	} else {
+		info = &nodeInfo{weight: weight}
+		r.nodes[node] = info
+		r.order = append(r.order, node)
+	}
+	r.weight += weight
+
+	for i := 0; i < weight*r.vnodes; i++ {
+		r.points = append(r.points, point[N]{hashing.Sum64(node, uint64(i)), i, node})
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i].less(r.points[j])
+	})
+}
+
+// Remove removes a node from the ring, its load is dropped.
+// If the node does not exist, return false.
+func (r *Ring[N]) Remove(node N) bool {
+	info, ok := r.nodes[node]
+	if !ok {
+		return false
+	}
+	delete(r.nodes, node)
+	for i, n := range r.order {
+		if n == node {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	r.removePoints(node)
+	r.weight -= info.weight
+	r.load -= info.load
+	return true
+}
+
+func (r *Ring[N]) removePoints(node N) {
+	points := r.points[:0]
+	for _, p := range r.points {
+		if p.node != node {
+			points = append(points, p)
+		}
+	}
+	var zero point[N]
+	for i := len(points); i < len(r.points); i++ {
+		r.points[i] = zero // avoid memory leaks
+	}
+	r.points = points
+}
+
+// Contains returns whether the node is in the ring.
+func (r *Ring[N]) Contains(node N) bool {
+	_, ok := r.nodes[node]
+	return ok
+}
+
+// Weight returns the weight of node, or 0 if the node does not exist.
+func (r *Ring[N]) Weight(node N) int {
+	if info, ok := r.nodes[node]; ok {
+		return info.weight
+	}
+	return 0
+}
+
+// Nodes returns all nodes of the ring in insertion order.
+func (r *Ring[N]) Nodes() []N {
+	return append([]N{}, r.order...)
+}
+
+// search returns the index of the first point clockwise from hash h.
+func (r *Ring[N]) search(h uint64) int {
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return i
+}
+
+// Get returns the node which key belongs to.
+// If the ring is empty, return nil.
+func (r *Ring[N]) Get(key string) goption.O[N] {
+	if len(r.points) == 0 {
+		return goption.Nil[N]()
+	}
+	return goption.OK(r.points[r.search(hashing.String(key, 0))].node)
+}
+
+// GetN returns at most n distinct nodes for key, in the order of preference.
+// The first one is the same as [Ring.Get].
+//
+// 💡 HINT: It is useful for placing replicas of key.
+func (r *Ring[N]) GetN(key string, n int) []N {
+	if n > len(r.order) {
+		n = len(r.order)
+	}
+	if n <= 0 {
+		return []N{}
+	}
+	res := make([]N, 0, n)
+	seen := make(map[N]struct{}, n)
+	for i, j := r.search(hashing.String(key, 0)), 0; len(res) < n && j < len(r.points); i, j = i+1, j+1 {
+		if i == len(r.points) {
+			i = 0
+		}
+		node := r.points[i].node
+		if _, ok := seen[node]; !ok {
+			seen[node] = struct{}{}
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
+// Acquire returns the node which key belongs to with bounded loads, and
+// increases the load of returned node by 1.
+// If the ring is empty, return nil.
+//
+// If the ring is not created by [NewBounded], the result is the same as
+// [Ring.Get] but the load is still tracked.
+//
+// 💡 NOTE: The result depends on current loads, callers should remember the
+// returned node and [Ring.Release] it when the key is done.
+func (r *Ring[N]) Acquire(key string) goption.O[N] {
+	if len(r.points) == 0 {
+		return goption.Nil[N]()
+	}
+	i := r.search(hashing.String(key, 0))
+	for j := 0; j < len(r.points); i, j = i+1, j+1 {
+		if i == len(r.points) {
+			i = 0
+		}
+		node := r.points[i].node
+		info := r.nodes[node]
+		if r.loadFactor == 0 || info.load < r.capacity(info.weight) {
+			info.load++
+			r.load++
+			return goption.OK(node)
+		}
+	}
+	panic("unreachable") // total capacity is always greater than total load
+}
+
+// capacity returns the max load of a node with weight w after acquiring.
+func (r *Ring[N]) capacity(w int) int {
+	return int(math.Ceil(r.loadFactor * float64(r.load+1) * float64(w) / float64(r.weight)))
+}
+
+// Release decreases the load of node by 1.
+// If the node does not exist or has no load, return false.
+func (r *Ring[N]) Release(node N) bool {
+	info, ok := r.nodes[node]
+	if !ok || info.load == 0 {
+		return false
+	}
+	info.load--
+	r.load--
+	return true
+}
+
+// Load returns the current load of node.
+func (r *Ring[N]) Load(node N) int {
+	if info, ok := r.nodes[node]; ok {
+		return info.load
+	}
+	return 0
+}
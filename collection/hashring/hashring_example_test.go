@@ -0,0 +1,70 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashring
+
+import (
+	"fmt"
+)
+
+func Example() {
+	r := New[string](100)
+	r.Add("node-1", 1)
+	r.Add("node-2", 1)
+	r.Add("node-3", 1)
+
+	fmt.Println(r.Get("hello").Value())
+	fmt.Println(r.GetN("hello", 2))
+
+	// Removing a node only moves its own keys.
+	r.Remove("node-2")
+	fmt.Println(r.Get("hello").Value())
+
+	// Output:
+	// node-3
+	// [node-3 node-2]
+	// node-3
+}
+
+func ExampleNewBounded() {
+	r := NewBounded[string](100, 1.25)
+	r.Add("node-1", 1)
+	r.Add("node-2", 1)
+
+	for i := 0; i < 8; i++ {
+		r.Acquire(fmt.Sprintf("key-%d", i))
+	}
+	// No node holds more than ⌈1.25 × 8 / 2⌉ = 5 keys.
+	fmt.Println(r.Load("node-1") <= 5, r.Load("node-2") <= 5)
+
+	// Output:
+	// true true
+}
+
+func ExampleJump() {
+	fmt.Println(Jump("hello", 10) == Jump("hello", 10))
+	fmt.Println(Jump("hello", 1))
+
+	// Output:
+	// true
+	// 0
+}
+
+func ExampleRendezvous() {
+	nodes := []string{"node-1", "node-2", "node-3"}
+	fmt.Println(Rendezvous("hello", nodes).Value() == RendezvousN("hello", nodes, 2)[0])
+
+	// Output:
+	// true
+}
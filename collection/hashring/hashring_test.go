@@ -0,0 +1,270 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func keys(n int) []string {
+	res := make([]string, n)
+	for i := range res {
+		res[i] = fmt.Sprintf("key-%d", i)
+	}
+	return res
+}
+
+func TestRing(t *testing.T) {
+	r := New[string](100)
+	assert.Equal(t, 0, r.Len())
+	assert.False(t, r.Get("a").IsOK())
+	assert.Equal(t, []string{}, r.GetN("a", 3))
+	assert.False(t, r.Remove("x"))
+
+	r.Add("a", 1)
+	r.Add("b", 1)
+	r.Add("c", 2)
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, []string{"a", "b", "c"}, r.Nodes())
+	assert.True(t, r.Contains("c"))
+	assert.Equal(t, 2, r.Weight("c"))
+	assert.Equal(t, 0, r.Weight("d"))
+	assert.Equal(t, 400, len(r.points))
+
+	count := map[string]int{}
+	for _, k := range keys(10000) {
+		count[r.Get(k).Value()]++
+	}
+	// Weighted: c owns about half of keys.
+	assert.True(t, count["c"] > 4000 && count["c"] < 6000)
+	assert.True(t, count["a"] > 1500 && count["a"] < 3500)
+	assert.True(t, count["b"] > 1500 && count["b"] < 3500)
+
+	assert.True(t, r.Remove("b"))
+	assert.False(t, r.Contains("b"))
+	assert.Equal(t, []string{"a", "c"}, r.Nodes())
+	assert.Equal(t, 300, len(r.points))
+
+	// Update weight.
+	r.Add("a", 3)
+	assert.Equal(t, 3, r.Weight("a"))
+	assert.Equal(t, 500, len(r.points))
+	r.Add("a", 3)
+	assert.Equal(t, 500, len(r.points))
+
+	assert.Panic(t, func() { New[int](0) })
+	assert.Panic(t, func() { r.Add("x", 0) })
+}
+
+func TestRingDeterministic(t *testing.T) {
+	r1 := New[int](50)
+	r2 := New[int](50)
+	for i := 0; i < 10; i++ {
+		r1.Add(i, 1+i%3)
+		r2.Add(9-i, 1+(9-i)%3)
+	}
+	for _, k := range keys(1000) {
+		assert.Equal(t, r1.Get(k), r2.Get(k))
+		assert.Equal(t, r1.GetN(k, 3), r2.GetN(k, 3))
+	}
+	// Stable across processes.
+	r := New[string](100)
+	r.Add("node-1", 1)
+	r.Add("node-2", 1)
+	r.Add("node-3", 1)
+	assert.Equal(t, "node-3", r.Get("hello").Value())
+	assert.Equal(t, []string{"node-3", "node-2", "node-1"}, r.GetN("hello", 5))
+}
+
+func TestRingTie(t *testing.T) {
+	// Points with the same hash are ordered by vnode index, then by node.
+	a0, a1 := point[string]{5, 0, "a"}, point[string]{5, 1, "a"}
+	b0, b1 := point[string]{5, 0, "b"}, point[string]{5, 1, "b"}
+	assert.True(t, b0.less(a1))
+	assert.False(t, a1.less(b0))
+	assert.NotEqual(t, a0.less(b0), b0.less(a0))
+	assert.NotEqual(t, a1.less(b1), b1.less(a1))
+	assert.False(t, a0.less(a0))
+
+	// Pointer nodes are hashed by address.
+	type server struct{ addr string }
+	s1, s2 := &server{"1"}, &server{"2"}
+	r := New[*server](50)
+	r.Add(s1, 1)
+	r.Add(s2, 1)
+	var ring, rendezvous []*server
+	for _, k := range keys(100) {
+		ring = append(ring, r.Get(k).Value())
+		rendezvous = append(rendezvous, Rendezvous(k, []*server{s1, s2}).Value())
+	}
+	s1.addr, s2.addr = "3", "4"
+	r.Add(s1, 2)
+	r.Add(s1, 1)
+	for i, k := range keys(100) {
+		assert.Equal(t, ring[i], r.Get(k).Value())
+		assert.Equal(t, rendezvous[i], Rendezvous(k, []*server{s2, s1}).Value())
+	}
+}
+
+func TestRingMovement(t *testing.T) {
+	r := New[int](100)
+	for i := 0; i < 10; i++ {
+		r.Add(i, 1)
+	}
+	ks := keys(10000)
+	before := make([]int, len(ks))
+	for i, k := range ks {
+		before[i] = r.Get(k).Value()
+	}
+
+	r.Add(10, 1)
+	moved := 0
+	for i, k := range ks {
+		if n := r.Get(k).Value(); n != before[i] {
+			assert.Equal(t, 10, n) // keys only move to the new node
+			moved++
+		}
+	}
+	assert.True(t, moved > 500 && moved < 1500)
+
+	r.Remove(10)
+	r.Remove(3)
+	for i, k := range ks {
+		if before[i] != 3 {
+			assert.Equal(t, before[i], r.Get(k).Value())
+		}
+	}
+}
+
+func TestRingGetN(t *testing.T) {
+	r := New[int](10)
+	for i := 0; i < 5; i++ {
+		r.Add(i, 1)
+	}
+	for _, k := range keys(100) {
+		ns := r.GetN(k, 3)
+		assert.Equal(t, 3, len(ns))
+		assert.Equal(t, r.Get(k).Value(), ns[0])
+		assert.True(t, ns[0] != ns[1] && ns[1] != ns[2] && ns[0] != ns[2])
+		assert.Equal(t, 5, len(r.GetN(k, 10)))
+		assert.Equal(t, ns[:2], r.GetN(k, 2))
+	}
+	assert.Equal(t, []int{}, r.GetN("a", 0))
+}
+
+func TestRingBounded(t *testing.T) {
+	r := NewBounded[int](100, 1.25)
+	assert.False(t, r.Acquire("a").IsOK())
+	for i := 0; i < 4; i++ {
+		r.Add(i, 1)
+	}
+	r.Add(4, 2)
+
+	ks := keys(6000)
+	acquired := make([]int, len(ks))
+	for i, k := range ks {
+		acquired[i] = r.Acquire(k).Value()
+		total := i + 1
+		for n := 0; n < 5; n++ {
+			limit := (total*5*r.Weight(n) + 4*6 - 1) / (4 * 6) // ⌈1.25 × total × w / 6⌉
+			assert.True(t, r.Load(n) <= limit)
+		}
+	}
+	sum := 0
+	for n := 0; n < 5; n++ {
+		sum += r.Load(n)
+	}
+	assert.Equal(t, len(ks), sum)
+	assert.True(t, r.Load(4) > r.Load(0))
+
+	for i := range ks {
+		assert.True(t, r.Release(acquired[i]))
+	}
+	for n := 0; n < 5; n++ {
+		assert.Equal(t, 0, r.Load(n))
+	}
+	assert.False(t, r.Release(0))
+	assert.False(t, r.Release(100))
+
+	// Loads are dropped with the node.
+	r.Acquire("a")
+	r.Acquire("b")
+	n := r.Acquire("c").Value()
+	r.Remove(n)
+	assert.Equal(t, 0, r.Load(n))
+	assert.Equal(t, r.Load(0)+r.Load(1)+r.Load(2)+r.Load(3)+r.Load(4), r.load)
+
+	assert.Panic(t, func() { NewBounded[int](10, 0.5) })
+
+	// Unbounded ring tracks loads too.
+	u := New[int](10)
+	u.Add(1, 1)
+	u.Add(2, 1)
+	assert.Equal(t, u.Get("x"), u.Acquire("x"))
+	assert.Equal(t, 1, u.Load(u.Get("x").Value()))
+}
+
+func TestJump(t *testing.T) {
+	assert.Panic(t, func() { Jump(1, 0) })
+	for k := 0; k < 1000; k++ {
+		assert.Equal(t, 0, Jump(k, 1))
+		prev := 0
+		for n := 2; n <= 50; n++ {
+			b := Jump(k, n)
+			assert.True(t, b >= 0 && b < n)
+			// Keys either stay or move to the new bucket.
+			assert.True(t, b == prev || b == n-1)
+			prev = b
+		}
+	}
+
+	count := make([]int, 10)
+	for _, k := range keys(10000) {
+		count[Jump(k, 10)]++
+	}
+	for _, c := range count {
+		assert.True(t, c > 800 && c < 1200)
+	}
+}
+
+func TestRendezvous(t *testing.T) {
+	assert.False(t, Rendezvous("a", []string{}).IsOK())
+	assert.Equal(t, []string{}, RendezvousN("a", []string{"x"}, 0))
+
+	nodes := []string{"a", "b", "c", "d", "e"}
+	count := map[string]int{}
+	for _, k := range keys(10000) {
+		n := Rendezvous(k, nodes).Value()
+		count[n]++
+		top := RendezvousN(k, nodes, 3)
+		assert.Equal(t, n, top[0])
+		assert.Equal(t, 5, len(RendezvousN(k, nodes, 10)))
+
+		// Order of nodes does not matter.
+		assert.Equal(t, n, Rendezvous(k, []string{"e", "d", "c", "b", "a"}).Value())
+		// Removing a node only moves its keys, to its runner-up.
+		if n == "c" {
+			assert.Equal(t, top[1], Rendezvous(k, []string{"a", "b", "d", "e"}).Value())
+		} else {
+			assert.Equal(t, n, Rendezvous(k, []string{"a", "b", "d", "e"}).Value())
+		}
+	}
+	for _, c := range count {
+		assert.True(t, c > 1700 && c < 2300)
+	}
+}
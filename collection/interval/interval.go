@@ -0,0 +1,82 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interval provides collections for half-open ranges of ordered values.
+//
+// It is useful for indexing time windows, IP ranges, port ranges and so on.
+//
+// 💡 NOTE: IntervalTree and RangeSet are not concurrent-safe.
+//
+// # Structures
+//
+//   - [Interval]: a half-open range [Start, End)
+//   - [IntervalTree]: a map from intervals to values supporting overlapping queries
+//   - [RangeSet]: a set of values represented by disjoint intervals
+//
+// # Operations
+//
+//   - Constructor: [New], [NewTree], [NewRangeSet]
+//   - IntervalTree: [IntervalTree.Insert], [IntervalTree.Delete],
+//     [IntervalTree.Overlapping], [IntervalTree.Containing], [IntervalTree.Range], …
+//   - RangeSet: [RangeSet.Add], [RangeSet.Remove], [RangeSet.Contains],
+//     [RangeSet.Union], [RangeSet.Intersect], [RangeSet.Complement], …
+package interval
+
+import (
+	"fmt"
+
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// Interval is a half-open range [Start, End).
+//
+// An interval with Start >= End is empty.
+type Interval[K constraints.Ordered] struct {
+	Start K
+	End   K
+}
+
+// New creates an interval [start, end).
+func New[K constraints.Ordered](start, end K) Interval[K] {
+	return Interval[K]{start, end}
+}
+
+// IsEmpty returns whether the interval contains nothing.
+func (iv Interval[K]) IsEmpty() bool {
+	return !(iv.Start < iv.End)
+}
+
+// Contains returns whether p is in the interval.
+func (iv Interval[K]) Contains(p K) bool {
+	return iv.Start <= p && p < iv.End
+}
+
+// Overlaps returns whether two intervals have common values.
+//
+// 💡 NOTE: Intervals which are only adjacent (such as [1, 2) and [2, 3))
+// do not overlap.
+func (iv Interval[K]) Overlaps(other Interval[K]) bool {
+	return iv.Start < other.End && other.Start < iv.End &&
+		!iv.IsEmpty() && !other.IsEmpty()
+}
+
+// String implements [fmt.Stringer].
+func (iv Interval[K]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Start, iv.End)
+}
+
+// less orders intervals by start and then end.
+func (iv Interval[K]) less(other Interval[K]) bool {
+	return iv.Start < other.Start || (iv.Start == other.Start && iv.End < other.End)
+}
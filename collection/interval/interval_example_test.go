@@ -0,0 +1,59 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"fmt"
+)
+
+func Example() {
+	// Index maintenance windows by hour of day.
+	windows := NewTree[int, string]()
+	windows.Insert(New(1, 3), "backup")
+	windows.Insert(New(2, 5), "reindex")
+	windows.Insert(New(22, 24), "report")
+
+	for _, e := range windows.Containing(2) {
+		fmt.Println(e.First, e.Second)
+	}
+	for _, e := range windows.Overlapping(New(4, 23)) {
+		fmt.Println(e.First, e.Second)
+	}
+
+	// Output:
+	// [1, 3) backup
+	// [2, 5) reindex
+	// [2, 5) reindex
+	// [22, 24) report
+}
+
+func ExampleRangeSet() {
+	ports := NewRangeSet(New(80, 81), New(8000, 8100), New(8050, 9000))
+	ports.Add(New(443, 444))
+	fmt.Println(ports)
+	fmt.Println(ports.Contains(8080))
+
+	// Ports which are not allowed.
+	fmt.Println(ports.Complement(New(0, 10000)))
+
+	reserved := NewRangeSet(New(0, 1024))
+	fmt.Println(ports.Intersect(reserved))
+
+	// Output:
+	// rangeset{[80, 81) [443, 444) [8000, 9000)}
+	// true
+	// rangeset{[0, 80) [81, 443) [444, 8000) [9000, 10000)}
+	// rangeset{[80, 81) [443, 444)}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bytedance/gg/gvalue"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// RangeSet is a set of values represented by sorted, disjoint and
+// non-adjacent intervals.
+//
+// Added intervals are normalized: empty intervals are dropped, overlapping
+// and adjacent intervals are merged.
+//
+// The zero value for RangeSet is an empty set ready to use.
+type RangeSet[K constraints.Ordered] struct {
+	ranges []Interval[K]
+}
+
+// NewRangeSet creates a set with initial intervals.
+func NewRangeSet[K constraints.Ordered](ivs ...Interval[K]) *RangeSet[K] {
+	sorted := make([]Interval[K], 0, len(ivs))
+	for _, iv := range ivs {
+		if !iv.IsEmpty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].less(sorted[j]) })
+	return &RangeSet[K]{coalesce(sorted)}
+}
+
+// coalesce merges overlapping and adjacent intervals in place,
+// ivs must be non-empty intervals sorted by start.
+func coalesce[K constraints.Ordered](ivs []Interval[K]) []Interval[K] {
+	res := ivs[:0]
+	for _, iv := range ivs {
+		if n := len(res); n > 0 && iv.Start <= res[n-1].End {
+			res[n-1].End = gvalue.Max(res[n-1].End, iv.End)
+			continue
+		}
+		res = append(res, iv)
+	}
+	return res
+}
+
+// Len returns the number of disjoint intervals in the set.
+func (s *RangeSet[K]) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.ranges)
+}
+
+// IsEmpty returns whether the set contains nothing.
+func (s *RangeSet[K]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Ranges returns the sorted, disjoint and non-adjacent intervals of the set.
+func (s *RangeSet[K]) Ranges() []Interval[K] {
+	if s == nil {
+		return []Interval[K]{}
+	}
+	return append([]Interval[K]{}, s.ranges...)
+}
+
+// Add adds all values of iv to the set.
+func (s *RangeSet[K]) Add(iv Interval[K]) {
+	if iv.IsEmpty() {
+		return
+	}
+	// Ranges in [i, j) overlap or are adjacent to iv.
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End >= iv.Start })
+	j := sort.Search(len(s.ranges), func(j int) bool { return s.ranges[j].Start > iv.End })
+	if i < j {
+		iv.Start = gvalue.Min(iv.Start, s.ranges[i].Start)
+		iv.End = gvalue.Max(iv.End, s.ranges[j-1].End)
+	}
+	s.splice(i, j, iv)
+}
+
+// Remove removes all values of iv from the set.
+func (s *RangeSet[K]) Remove(iv Interval[K]) {
+	if s == nil || iv.IsEmpty() {
+		return
+	}
+	// Ranges in [i, j) overlap iv.
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End > iv.Start })
+	j := sort.Search(len(s.ranges), func(j int) bool { return s.ranges[j].Start >= iv.End })
+	if i >= j {
+		return
+	}
+	var rest []Interval[K]
+	if first := s.ranges[i]; first.Start < iv.Start {
+		rest = append(rest, Interval[K]{first.Start, iv.Start})
+	}
+	if last := s.ranges[j-1]; last.End > iv.End {
+		rest = append(rest, Interval[K]{iv.End, last.End})
+	}
+	s.splice(i, j, rest...)
+}
+
+// splice replaces s.ranges[i:j] with ivs.
+func (s *RangeSet[K]) splice(i, j int, ivs ...Interval[K]) {
+	tail := len(s.ranges) - j
+	n := i + len(ivs) + tail
+	if n > cap(s.ranges) {
+		ranges := make([]Interval[K], n, 2*n)
+		copy(ranges, s.ranges[:i])
+		copy(ranges[i+len(ivs):], s.ranges[j:])
+		s.ranges = ranges
+	} else {
+		old := len(s.ranges)
+		s.ranges = s.ranges[:gvalue.Max(n, old)]
+		copy(s.ranges[i+len(ivs):], s.ranges[j:old])
+		s.ranges = s.ranges[:n]
+	}
+	copy(s.ranges[i:], ivs)
+}
+
+// Contains returns whether p is in the set.
+func (s *RangeSet[K]) Contains(p K) bool {
+	if s == nil {
+		return false
+	}
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End > p })
+	return i < len(s.ranges) && s.ranges[i].Start <= p
+}
+
+// ContainsRange returns whether all values of iv are in the set.
+//
+// 💡 HINT: Empty interval is contained by any set.
+func (s *RangeSet[K]) ContainsRange(iv Interval[K]) bool {
+	if iv.IsEmpty() {
+		return true
+	}
+	if s == nil {
+		return false
+	}
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End > iv.Start })
+	return i < len(s.ranges) && s.ranges[i].Start <= iv.Start && iv.End <= s.ranges[i].End
+}
+
+// Union returns the union of sets as a new set.
+func (s *RangeSet[K]) Union(other *RangeSet[K]) *RangeSet[K] {
+	a, b := s.Ranges(), other.Ranges()
+	merged := make([]Interval[K], 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].Start <= b[0].Start {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+	return &RangeSet[K]{coalesce(merged)}
+}
+
+// Intersect returns the intersection of sets as a new set.
+func (s *RangeSet[K]) Intersect(other *RangeSet[K]) *RangeSet[K] {
+	res := &RangeSet[K]{}
+	if s.IsEmpty() || other.IsEmpty() {
+		return res
+	}
+	a, b := s.ranges, other.ranges
+	for len(a) > 0 && len(b) > 0 {
+		iv := Interval[K]{gvalue.Max(a[0].Start, b[0].Start), gvalue.Min(a[0].End, b[0].End)}
+		if !iv.IsEmpty() {
+			res.ranges = append(res.ranges, iv)
+		}
+		// Drop the interval which ends first.
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return res
+}
+
+// Complement returns values in bounds which are not in the set, as a new set.
+func (s *RangeSet[K]) Complement(bounds Interval[K]) *RangeSet[K] {
+	res := &RangeSet[K]{}
+	if bounds.IsEmpty() {
+		return res
+	}
+	cur := bounds.Start
+	for _, r := range s.Ranges() {
+		if r.End <= cur {
+			continue
+		}
+		if r.Start >= bounds.End {
+			break
+		}
+		if cur < r.Start {
+			res.ranges = append(res.ranges, Interval[K]{cur, r.Start})
+		}
+		cur = r.End
+	}
+	if cur < bounds.End {
+		res.ranges = append(res.ranges, Interval[K]{cur, bounds.End})
+	}
+	return res
+}
+
+// Equal returns whether set s and other contain the same values.
+func (s *RangeSet[K]) Equal(other *RangeSet[K]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for i := 0; i < s.Len(); i++ {
+		if s.ranges[i] != other.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of the set.
+func (s *RangeSet[K]) Clone() *RangeSet[K] {
+	return &RangeSet[K]{s.Ranges()}
+}
+
+// String implements [fmt.Stringer].
+func (s *RangeSet[K]) String() string {
+	ranges := make([]string, s.Len())
+	for i := range ranges {
+		ranges[i] = s.ranges[i].String()
+	}
+	return "rangeset{" + strings.Join(ranges, " ") + "}"
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestRangeSet(t *testing.T) {
+	s := NewRangeSet(New(5, 7), New(1, 3), New(2, 4), New(10, 10), New(7, 8))
+	assert.Equal(t, []Interval[int]{New(1, 4), New(5, 8)}, s.Ranges())
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, "rangeset{[1, 4) [5, 8)}", s.String())
+
+	assert.True(t, s.Contains(1))
+	assert.True(t, s.Contains(3))
+	assert.False(t, s.Contains(4))
+	assert.True(t, s.Contains(7))
+	assert.False(t, s.Contains(8))
+	assert.False(t, s.Contains(0))
+
+	assert.True(t, s.ContainsRange(New(1, 4)))
+	assert.True(t, s.ContainsRange(New(2, 2)))
+	assert.False(t, s.ContainsRange(New(3, 6)))
+	assert.False(t, s.ContainsRange(New(9, 10)))
+
+	s.Add(New(4, 5)) // fill the gap
+	assert.Equal(t, []Interval[int]{New(1, 8)}, s.Ranges())
+	s.Add(New(20, 30))
+	s.Add(New(10, 12))
+	s.Add(New(0, 0))
+	assert.Equal(t, []Interval[int]{New(1, 8), New(10, 12), New(20, 30)}, s.Ranges())
+	s.Add(New(11, 25))
+	assert.Equal(t, []Interval[int]{New(1, 8), New(10, 30)}, s.Ranges())
+
+	s.Remove(New(3, 5))
+	assert.Equal(t, []Interval[int]{New(1, 3), New(5, 8), New(10, 30)}, s.Ranges())
+	s.Remove(New(6, 20))
+	assert.Equal(t, []Interval[int]{New(1, 3), New(5, 6), New(20, 30)}, s.Ranges())
+	s.Remove(New(8, 9))
+	s.Remove(New(7, 7))
+	assert.Equal(t, 3, s.Len())
+	s.Remove(New(0, 100))
+	assert.True(t, s.IsEmpty())
+
+	var zero RangeSet[int]
+	zero.Add(New(1, 2))
+	assert.Equal(t, []Interval[int]{New(1, 2)}, zero.Ranges())
+
+	var nilSet *RangeSet[int]
+	assert.True(t, nilSet.IsEmpty())
+	assert.False(t, nilSet.Contains(1))
+	assert.False(t, nilSet.ContainsRange(New(1, 2)))
+	assert.True(t, nilSet.ContainsRange(New(1, 1)))
+	assert.Equal(t, []Interval[int]{}, nilSet.Ranges())
+	nilSet.Remove(New(1, 2))
+	assert.Equal(t, "rangeset{}", nilSet.String())
+}
+
+func TestRangeSetAlgebra(t *testing.T) {
+	a := NewRangeSet(New(1, 5), New(10, 15))
+	b := NewRangeSet(New(3, 11), New(15, 20))
+
+	assert.Equal(t, []Interval[int]{New(1, 20)}, a.Union(b).Ranges())
+	assert.Equal(t, []Interval[int]{New(3, 5), New(10, 11)}, a.Intersect(b).Ranges())
+	assert.Equal(t, []Interval[int]{New(0, 1), New(5, 10), New(15, 30)}, a.Complement(New(0, 30)).Ranges())
+	assert.Equal(t, []Interval[int]{New(5, 10)}, a.Complement(New(2, 12)).Ranges())
+	assert.Equal(t, []Interval[int]{}, a.Complement(New(2, 2)).Ranges())
+	assert.Equal(t, []Interval[int]{New(0, 10)}, NewRangeSet[int]().Complement(New(0, 10)).Ranges())
+
+	assert.True(t, a.Intersect(nil).IsEmpty())
+	assert.True(t, a.Union(nil).Equal(a))
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Equal(NewRangeSet(New(1, 5), New(10, 16))))
+
+	c := a.Clone()
+	c.Add(New(5, 10))
+	assert.Equal(t, 2, a.Len())
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestRangeSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 200
+	randIv := func() Interval[int] {
+		s := r.Intn(n)
+		return New(s, s+r.Intn(20))
+	}
+	for round := 0; round < 50; round++ {
+		var a, b RangeSet[int]
+		var ra, rb [n + 20]bool
+		for i := 0; i < 30; i++ {
+			iv := randIv()
+			add := r.Intn(3) != 0
+			for p := iv.Start; p < iv.End; p++ {
+				ra[p] = add
+			}
+			if add {
+				a.Add(iv)
+			} else {
+				a.Remove(iv)
+			}
+			iv = randIv()
+			for p := iv.Start; p < iv.End; p++ {
+				rb[p] = true
+			}
+			b.Add(iv)
+		}
+		u, x, c := a.Union(&b), a.Intersect(&b), a.Complement(New(10, 150))
+		for p := 0; p < n+20; p++ {
+			assert.Equal(t, ra[p], a.Contains(p))
+			assert.Equal(t, ra[p] || rb[p], u.Contains(p))
+			assert.Equal(t, ra[p] && rb[p], x.Contains(p))
+			assert.Equal(t, !ra[p] && p >= 10 && p < 150, c.Contains(p))
+		}
+		for _, s := range []*RangeSet[int]{&a, u, x, c} {
+			rs := s.Ranges()
+			for i := 1; i < len(rs); i++ {
+				assert.True(t, rs[i-1].End < rs[i].Start)
+			}
+		}
+	}
+}
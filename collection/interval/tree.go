@@ -0,0 +1,306 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/gvalue"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// IntervalTree maps intervals to values, and finds intervals which overlap
+// a given interval or contain a given point efficiently.
+//
+// It is an AVL tree ordered by the start and end of intervals, each node is
+// augmented with the maximum end of its subtree.
+//
+// The zero value for IntervalTree is an empty tree ready to use.
+type IntervalTree[K constraints.Ordered, V any] struct {
+	root *treeNode[K, V]
+	len  int
+}
+
+type treeNode[K constraints.Ordered, V any] struct {
+	iv          Interval[K]
+	value       V
+	maxEnd      K // maximum end of intervals in the subtree
+	height      int
+	left, right *treeNode[K, V]
+}
+
+// NewTree creates an empty interval tree.
+func NewTree[K constraints.Ordered, V any]() *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{}
+}
+
+func height[K constraints.Ordered, V any](n *treeNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *treeNode[K, V]) update() {
+	n.height = 1 + gvalue.Max(height(n.left), height(n.right))
+	n.maxEnd = n.iv.End
+	if n.left != nil && n.left.maxEnd > n.maxEnd {
+		n.maxEnd = n.left.maxEnd
+	}
+	if n.right != nil && n.right.maxEnd > n.maxEnd {
+		n.maxEnd = n.right.maxEnd
+	}
+}
+
+func (n *treeNode[K, V]) rotateLeft() *treeNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *treeNode[K, V]) rotateRight() *treeNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *treeNode[K, V]) balance() *treeNode[K, V] {
+	n.update()
+	switch bf := height(n.left) - height(n.right); {
+	case bf > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[K, V]) Len() int {
+	if t == nil {
+		return 0
+	}
+	return t.len
+}
+
+// Insert sets the value for an interval.
+// If the interval is already in the tree, its value is overridden.
+func (t *IntervalTree[K, V]) Insert(iv Interval[K], value V) {
+	var added bool
+	t.root = insert(t.root, iv, value, &added)
+	if added {
+		t.len++
+	}
+}
+
+func insert[K constraints.Ordered, V any](n *treeNode[K, V], iv Interval[K], value V, added *bool) *treeNode[K, V] {
+	if n == nil {
+		*added = true
+		n = &treeNode[K, V]{iv: iv, value: value}
+		n.update()
+		return n
+	}
+	switch {
+	case iv.less(n.iv):
+		n.left = insert(n.left, iv, value, added)
+	case n.iv.less(iv):
+		n.right = insert(n.right, iv, value, added)
+	default:
+		n.value = value
+		return n
+	}
+	return n.balance()
+}
+
+// Get returns the value stored in the tree for an interval, or zero value if
+// the interval is not present.
+// The ok result indicates whether value was found in the tree.
+func (t *IntervalTree[K, V]) Get(iv Interval[K]) (value V, ok bool) {
+	if t == nil {
+		return value, false
+	}
+	for n := t.root; n != nil; {
+		switch {
+		case iv.less(n.iv):
+			n = n.left
+		case n.iv.less(iv):
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return value, false
+}
+
+// Delete deletes an interval from the tree.
+// It returns whether the interval was present.
+func (t *IntervalTree[K, V]) Delete(iv Interval[K]) bool {
+	if t == nil {
+		return false
+	}
+	var deleted bool
+	t.root = remove(t.root, iv, &deleted)
+	if deleted {
+		t.len--
+	}
+	return deleted
+}
+
+func remove[K constraints.Ordered, V any](n *treeNode[K, V], iv Interval[K], deleted *bool) *treeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case iv.less(n.iv):
+		n.left = remove(n.left, iv, deleted)
+	case n.iv.less(iv):
+		n.right = remove(n.right, iv, deleted)
+	default:
+		*deleted = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// Replace n with its successor.
+		var succ *treeNode[K, V]
+		n.right = removeMin(n.right, &succ)
+		succ.left, succ.right = n.left, n.right
+		n = succ
+	}
+	return n.balance()
+}
+
+func removeMin[K constraints.Ordered, V any](n *treeNode[K, V], min **treeNode[K, V]) *treeNode[K, V] {
+	if n.left == nil {
+		*min = n
+		return n.right
+	}
+	n.left = removeMin(n.left, min)
+	return n.balance()
+}
+
+// RangeOverlapping calls f sequentially for each interval which overlaps q
+// and its value, in ascending order of intervals.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The tree must not be modified during the iteration.
+func (t *IntervalTree[K, V]) RangeOverlapping(q Interval[K], f func(Interval[K], V) bool) {
+	if t == nil || q.IsEmpty() {
+		return
+	}
+	t.root.search(func(n *treeNode[K, V]) (bool, bool) {
+		// Skip subtrees whose intervals all end before q starts, or
+		// start after q ends.
+		return n.maxEnd > q.Start, n.iv.Start < q.End
+	}, func(n *treeNode[K, V]) bool {
+		if n.iv.Overlaps(q) {
+			return f(n.iv, n.value)
+		}
+		return true
+	})
+}
+
+// RangeContaining calls f sequentially for each interval which contains
+// point p and its value, in ascending order of intervals.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The tree must not be modified during the iteration.
+func (t *IntervalTree[K, V]) RangeContaining(p K, f func(Interval[K], V) bool) {
+	if t == nil {
+		return
+	}
+	t.root.search(func(n *treeNode[K, V]) (bool, bool) {
+		return n.maxEnd > p, n.iv.Start <= p
+	}, func(n *treeNode[K, V]) bool {
+		if n.iv.Contains(p) {
+			return f(n.iv, n.value)
+		}
+		return true
+	})
+}
+
+// search visits nodes in order, prune reports whether the subtree of a node
+// may match, and whether the node and its right subtree may match.
+func (n *treeNode[K, V]) search(prune func(*treeNode[K, V]) (bool, bool), f func(*treeNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	subtree, self := prune(n)
+	if !subtree {
+		return true
+	}
+	if !n.left.search(prune, f) {
+		return false
+	}
+	if !self {
+		// Intervals after n start even later.
+		return false
+	}
+	if !f(n) {
+		return false
+	}
+	return n.right.search(prune, f)
+}
+
+// Overlapping returns all intervals which overlap q and their values,
+// in ascending order of intervals.
+func (t *IntervalTree[K, V]) Overlapping(q Interval[K]) []tuple.T2[Interval[K], V] {
+	var res []tuple.T2[Interval[K], V]
+	t.RangeOverlapping(q, func(iv Interval[K], v V) bool {
+		res = append(res, tuple.Make2(iv, v))
+		return true
+	})
+	return res
+}
+
+// Containing returns all intervals which contain point p and their values,
+// in ascending order of intervals.
+func (t *IntervalTree[K, V]) Containing(p K) []tuple.T2[Interval[K], V] {
+	var res []tuple.T2[Interval[K], V]
+	t.RangeContaining(p, func(iv Interval[K], v V) bool {
+		res = append(res, tuple.Make2(iv, v))
+		return true
+	})
+	return res
+}
+
+// Range calls f sequentially for each interval and its value in ascending
+// order, intervals are ordered by start and then end.
+// If f returns false, the iteration stops.
+//
+// 💡 NOTE: The tree must not be modified during the iteration.
+func (t *IntervalTree[K, V]) Range(f func(Interval[K], V) bool) {
+	if t == nil {
+		return
+	}
+	t.root.search(func(*treeNode[K, V]) (bool, bool) {
+		return true, true
+	}, func(n *treeNode[K, V]) bool {
+		return f(n.iv, n.value)
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestInterval(t *testing.T) {
+	iv := New(1, 5)
+	assert.False(t, iv.IsEmpty())
+	assert.True(t, New(1, 1).IsEmpty())
+	assert.True(t, New(2, 1).IsEmpty())
+	assert.True(t, iv.Contains(1))
+	assert.True(t, iv.Contains(4))
+	assert.False(t, iv.Contains(5))
+	assert.False(t, iv.Contains(0))
+
+	assert.True(t, iv.Overlaps(New(4, 10)))
+	assert.True(t, iv.Overlaps(New(0, 2)))
+	assert.True(t, iv.Overlaps(New(2, 3)))
+	assert.False(t, iv.Overlaps(New(5, 10)))
+	assert.False(t, iv.Overlaps(New(0, 1)))
+	assert.False(t, iv.Overlaps(New(3, 3)))
+	assert.Equal(t, "[1, 5)", iv.String())
+}
+
+// checkAVL verifies the invariants of the tree and returns the number of nodes.
+func checkAVL[V any](t *testing.T, n *treeNode[int, V]) int {
+	if n == nil {
+		return 0
+	}
+	l, r := checkAVL(t, n.left), checkAVL(t, n.right)
+	assert.True(t, height(n.left)-height(n.right) <= 1)
+	assert.True(t, height(n.right)-height(n.left) <= 1)
+	maxEnd := n.iv.End
+	if n.left != nil {
+		assert.True(t, n.left.iv.less(n.iv))
+		if n.left.maxEnd > maxEnd {
+			maxEnd = n.left.maxEnd
+		}
+	}
+	if n.right != nil {
+		assert.True(t, n.iv.less(n.right.iv))
+		if n.right.maxEnd > maxEnd {
+			maxEnd = n.right.maxEnd
+		}
+	}
+	assert.Equal(t, maxEnd, n.maxEnd)
+	return l + r + 1
+}
+
+func TestIntervalTree(t *testing.T) {
+	var tr IntervalTree[int, string]
+	assert.Equal(t, 0, tr.Len())
+	assert.Equal(t, 0, len(tr.Overlapping(New(0, 100))))
+
+	tr.Insert(New(1, 3), "a")
+	tr.Insert(New(2, 6), "b")
+	tr.Insert(New(5, 8), "c")
+	tr.Insert(New(10, 12), "d")
+	tr.Insert(New(2, 6), "B") // override
+	tr.Insert(New(2, 4), "e")
+	assert.Equal(t, 5, tr.Len())
+
+	v, ok := tr.Get(New(2, 6))
+	assert.True(t, ok)
+	assert.Equal(t, "B", v)
+	_, ok = tr.Get(New(2, 5))
+	assert.False(t, ok)
+
+	assert.Equal(t, []tuple.T2[Interval[int], string]{
+		tuple.Make2(New(2, 4), "e"),
+		tuple.Make2(New(2, 6), "B"),
+		tuple.Make2(New(5, 8), "c"),
+	}, tr.Overlapping(New(3, 6)))
+	assert.Equal(t, 0, len(tr.Overlapping(New(8, 10))))
+	assert.Equal(t, 0, len(tr.Overlapping(New(5, 5))))
+
+	assert.Equal(t, []tuple.T2[Interval[int], string]{
+		tuple.Make2(New(1, 3), "a"),
+		tuple.Make2(New(2, 4), "e"),
+		tuple.Make2(New(2, 6), "B"),
+	}, tr.Containing(2))
+	assert.Equal(t, 0, len(tr.Containing(8)))
+	assert.Equal(t, 1, len(tr.Containing(11)))
+
+	var all []Interval[int]
+	tr.Range(func(iv Interval[int], _ string) bool {
+		all = append(all, iv)
+		return true
+	})
+	assert.Equal(t, []Interval[int]{New(1, 3), New(2, 4), New(2, 6), New(5, 8), New(10, 12)}, all)
+
+	n := 0
+	tr.RangeOverlapping(New(0, 100), func(Interval[int], string) bool {
+		n++
+		return n < 2
+	})
+	assert.Equal(t, 2, n)
+
+	assert.True(t, tr.Delete(New(2, 6)))
+	assert.False(t, tr.Delete(New(2, 6)))
+	assert.Equal(t, 4, tr.Len())
+	assert.Equal(t, 1, len(tr.Containing(5)))
+
+	var nilTree *IntervalTree[int, int]
+	assert.Equal(t, 0, nilTree.Len())
+	assert.False(t, nilTree.Delete(New(1, 2)))
+	_, ok = nilTree.Get(New(1, 2))
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(nilTree.Overlapping(New(1, 2))))
+	assert.Equal(t, 0, len(nilTree.Containing(1)))
+	nilTree.Range(func(Interval[int], int) bool { panic("unreachable") })
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := NewTree[int, int]()
+	ref := map[Interval[int]]int{}
+	for i := 0; i < 5000; i++ {
+		s := r.Intn(1000)
+		iv := New(s, s+r.Intn(50))
+		if r.Intn(3) == 0 {
+			_, ok := ref[iv]
+			assert.Equal(t, ok, tr.Delete(iv))
+			delete(ref, iv)
+		} else {
+			tr.Insert(iv, i)
+			ref[iv] = i
+		}
+	}
+	assert.Equal(t, len(ref), tr.Len())
+	assert.Equal(t, len(ref), checkAVL(t, tr.root))
+
+	for i := 0; i < 200; i++ {
+		s := r.Intn(1100) - 50
+		q := New(s, s+r.Intn(30))
+		want := 0
+		for iv := range ref {
+			if iv.Overlaps(q) {
+				want++
+			}
+		}
+		got := tr.Overlapping(q)
+		assert.Equal(t, want, len(got))
+		for j, e := range got {
+			assert.True(t, e.First.Overlaps(q))
+			assert.Equal(t, ref[e.First], e.Second)
+			if j > 0 {
+				assert.True(t, got[j-1].First.less(e.First))
+			}
+		}
+
+		want = 0
+		for iv := range ref {
+			if iv.Contains(s) {
+				want++
+			}
+		}
+		assert.Equal(t, want, len(tr.Containing(s)))
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package linkedmap provides a map that remembers the order of its entries.
+//
+// 💡 NOTE: LinkedMap is not concurrent-safe.
+//
+// # Structures
+//
+//   - [LinkedMap]
+//
+// # Operations
+//
+//   - Constructor: [New], [NewWithCap], [NewAccessOrdered]
+//   - CRUD operations: [LinkedMap.Store], [LinkedMap.Load], [LinkedMap.Delete], [LinkedMap.LoadOrStore], …
+//   - Order operations: [LinkedMap.MoveToFront], [LinkedMap.MoveToBack], [LinkedMap.Oldest], [LinkedMap.Newest], …
+//   - Range operations: [LinkedMap.Range], [LinkedMap.RangeReverse]
+//   - Conversion: [LinkedMap.Keys], [LinkedMap.Values], [LinkedMap.Items], …
+//
+// # Iteration order
+//
+// By default, entries are iterated in insertion order: the first stored key
+// is the oldest one, and storing an existing key does not change its position.
+//
+// A map created by [NewAccessOrdered] is iterated in access order instead:
+// every [LinkedMap.Load] and [LinkedMap.Store] moves the key to the newest
+// position, which makes it suitable for implementing LRU caches.
+//
+// # JSON
+//
+// [LinkedMap] implements [encoding/json.Marshaler] and [encoding/json.Unmarshaler],
+// the order of keys is preserved in both directions.
+// See [LinkedMap.MarshalJSON] and [LinkedMap.UnmarshalJSON].
+package linkedmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/gg/collection/list"
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LinkedMap is a map that remembers the order of its entries.
+//
+// The zero value for LinkedMap is an empty insertion-ordered map ready to use.
+//
+// 💡 NOTE: LinkedMap is not concurrent-safe.
+type LinkedMap[K comparable, V any] struct {
+	m           map[K]*list.Element[entry[K, V]]
+	l           list.List[entry[K, V]] // from oldest (front) to newest (back)
+	accessOrder bool
+}
+
+// New creates an empty insertion-ordered map.
+func New[K comparable, V any]() *LinkedMap[K, V] {
+	return NewWithCap[K, V](0)
+}
+
+// NewWithCap creates an empty insertion-ordered map with capacity.
+func NewWithCap[K comparable, V any](capacity int) *LinkedMap[K, V] {
+	m := &LinkedMap[K, V]{
+		m: make(map[K]*list.Element[entry[K, V]], capacity),
+	}
+	m.l.Init()
+	return m
+}
+
+// NewAccessOrdered creates an empty access-ordered map.
+//
+// Both [LinkedMap.Load] and [LinkedMap.Store] move the accessed key to the
+// newest position, so [LinkedMap.Oldest] is always the least recently used one.
+func NewAccessOrdered[K comparable, V any]() *LinkedMap[K, V] {
+	m := New[K, V]()
+	m.accessOrder = true
+	return m
+}
+
+// lazyInit lazily initializes a zero LinkedMap value.
+func (m *LinkedMap[K, V]) lazyInit() {
+	if m.m == nil {
+		m.m = make(map[K]*list.Element[entry[K, V]])
+		m.l.Init()
+	}
+}
+
+// Len returns the number of entries of map m.
+// The complexity is O(1).
+func (m *LinkedMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.m)
+}
+
+// Store sets the value for a key.
+//
+// A new key is stored at the newest position. For an existing key, the
+// position is kept unless the map is access-ordered.
+func (m *LinkedMap[K, V]) Store(key K, value V) {
+	m.lazyInit()
+	if e, ok := m.m[key]; ok {
+		e.Value.value = value
+		m.touch(e)
+		return
+	}
+	m.m[key] = m.l.PushBack(entry[K, V]{key, value})
+}
+
+// Load returns the value stored in the map for a key.
+// The ok result indicates whether value was found in the map.
+//
+// If the map is access-ordered, the key is moved to the newest position.
+func (m *LinkedMap[K, V]) Load(key K) (value V, ok bool) {
+	if m == nil {
+		return
+	}
+	e, ok := m.m[key]
+	if !ok {
+		return
+	}
+	m.touch(e)
+	return e.Value.value, true
+}
+
+// Peek is a variant of [LinkedMap.Load], it never changes the order of map.
+func (m *LinkedMap[K, V]) Peek(key K) (value V, ok bool) {
+	if m == nil {
+		return
+	}
+	e, ok := m.m[key]
+	if !ok {
+		return
+	}
+	return e.Value.value, true
+}
+
+// Contains returns true if the key is present in the map.
+// It never changes the order of map.
+func (m *LinkedMap[K, V]) Contains(key K) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.m[key]
+	return ok
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *LinkedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.lazyInit()
+	if e, ok := m.m[key]; ok {
+		m.touch(e)
+		return e.Value.value, true
+	}
+	m.m[key] = m.l.PushBack(entry[K, V]{key, value})
+	return value, false
+}
+
+// Delete deletes the value for a key.
+// If key is not present in map, return false.
+func (m *LinkedMap[K, V]) Delete(key K) bool {
+	_, ok := m.LoadAndDelete(key)
+	return ok
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *LinkedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	if m == nil {
+		return
+	}
+	e, ok := m.m[key]
+	if !ok {
+		return
+	}
+	delete(m.m, key)
+	m.l.Remove(e)
+	return e.Value.value, true
+}
+
+// Clear removes all entries from the map.
+func (m *LinkedMap[K, V]) Clear() {
+	if m == nil || m.m == nil {
+		return
+	}
+	m.m = make(map[K]*list.Element[entry[K, V]])
+	m.l.Init()
+}
+
+// touch moves e to the newest position if the map is access-ordered.
+func (m *LinkedMap[K, V]) touch(e *list.Element[entry[K, V]]) {
+	if m.accessOrder {
+		m.l.MoveToBack(e)
+	}
+}
+
+// MoveToFront moves the key to the oldest position.
+// If key is not present in map, return false.
+func (m *LinkedMap[K, V]) MoveToFront(key K) bool {
+	if m == nil {
+		return false
+	}
+	e, ok := m.m[key]
+	if ok {
+		m.l.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack moves the key to the newest position.
+// If key is not present in map, return false.
+func (m *LinkedMap[K, V]) MoveToBack(key K) bool {
+	if m == nil {
+		return false
+	}
+	e, ok := m.m[key]
+	if ok {
+		m.l.MoveToBack(e)
+	}
+	return ok
+}
+
+func toItem[K comparable, V any](e *list.Element[entry[K, V]]) goption.O[tuple.T2[K, V]] {
+	if e == nil {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	return goption.OK(tuple.Make2(e.Value.key, e.Value.value))
+}
+
+// Oldest returns the oldest entry of map, which is the first one to be iterated.
+func (m *LinkedMap[K, V]) Oldest() goption.O[tuple.T2[K, V]] {
+	if m == nil {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	return toItem(m.l.Front())
+}
+
+// Newest returns the newest entry of map, which is the last one to be iterated.
+func (m *LinkedMap[K, V]) Newest() goption.O[tuple.T2[K, V]] {
+	if m == nil {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	return toItem(m.l.Back())
+}
+
+// PopOldest removes and returns the oldest entry of map.
+func (m *LinkedMap[K, V]) PopOldest() goption.O[tuple.T2[K, V]] {
+	o := m.Oldest()
+	o.IfOK(func(kv tuple.T2[K, V]) { m.Delete(kv.First) })
+	return o
+}
+
+// PopNewest removes and returns the newest entry of map.
+func (m *LinkedMap[K, V]) PopNewest() goption.O[tuple.T2[K, V]] {
+	o := m.Newest()
+	o.IfOK(func(kv tuple.T2[K, V]) { m.Delete(kv.First) })
+	return o
+}
+
+// Range calls f sequentially for each key and value present in the map,
+// from the oldest to the newest.
+// If f returns false, range stops the iteration.
+//
+// 💡 NOTE: The map must not be modified during iteration.
+func (m *LinkedMap[K, V]) Range(f func(key K, value V) bool) {
+	if m == nil {
+		return
+	}
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if !f(e.Value.key, e.Value.value) {
+			return
+		}
+	}
+}
+
+// RangeReverse is a variant of [LinkedMap.Range], it iterates from the
+// newest to the oldest.
+func (m *LinkedMap[K, V]) RangeReverse(f func(key K, value V) bool) {
+	if m == nil {
+		return
+	}
+	for e := m.l.Back(); e != nil; e = e.Prev() {
+		if !f(e.Value.key, e.Value.value) {
+			return
+		}
+	}
+}
+
+// Keys returns all keys of map, from the oldest to the newest.
+func (m *LinkedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values of map, from the oldest to the newest.
+func (m *LinkedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// Items returns all entries of map, from the oldest to the newest.
+func (m *LinkedMap[K, V]) Items() tuple.S2[K, V] {
+	items := make(tuple.S2[K, V], 0, m.Len())
+	m.Range(func(k K, v V) bool {
+		items = append(items, tuple.Make2(k, v))
+		return true
+	})
+	return items
+}
+
+// ToMap converts the map into a builtin map.
+func (m *LinkedMap[K, V]) ToMap() map[K]V {
+	res := make(map[K]V, m.Len())
+	m.Range(func(k K, v V) bool {
+		res[k] = v
+		return true
+	})
+	return res
+}
+
+// Clone returns a copy of the map, with the same order.
+//
+// 💡 NOTE: Values are copied using assignment (=).
+func (m *LinkedMap[K, V]) Clone() *LinkedMap[K, V] {
+	res := NewWithCap[K, V](m.Len())
+	if m == nil {
+		return res
+	}
+	res.accessOrder = m.accessOrder
+	m.Range(func(k K, v V) bool {
+		res.m[k] = res.l.PushBack(entry[K, V]{k, v})
+		return true
+	})
+	return res
+}
+
+// String implements [fmt.Stringer].
+//
+// Experimental: This API is experimental and may change in the future.
+func (m *LinkedMap[K, V]) String() string {
+	entries := make([]string, 0, m.Len())
+	m.Range(func(k K, v V) bool {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+		return true
+	})
+	return fmt.Sprintf("linkedmap[%s]", strings.Join(entries, " "))
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// The returned bytes is null or JSON object, keys of object are in the same
+// order as [LinkedMap.Range].
+func (m *LinkedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	enc := jsonbuilder.NewDict()
+	var err error
+	m.Range(func(k K, v V) bool {
+		err = enc.Store(k, v)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc.Build()
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// Entries are stored in the order they appear in data.
+// The original entries are always overridden, but the ordering mode is kept.
+func (m *LinkedMap[K, V]) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+
+	unmarshalKey, err := jsonbuilder.KeyUnmarshaler[K]()
+	if err != nil {
+		return err
+	}
+	var (
+		items tuple.S2[K, V]
+		index = make(map[K]int)
+	)
+	err = jsonbuilder.ParseDict(data, func(ks string, vs json.RawMessage) error {
+		k, err := unmarshalKey(ks)
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := json.Unmarshal(vs, &v); err != nil {
+			return err
+		}
+		// Duplicated key takes the last value but keeps the first position.
+		if i, ok := index[k]; ok {
+			items[i].Second = v
+		} else {
+			index[k] = len(items)
+			items = append(items, tuple.Make2(k, v))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Always override original entries.
+	m.m = make(map[K]*list.Element[entry[K, V]], len(items))
+	m.l.Init()
+	for _, kv := range items {
+		m.m[kv.First] = m.l.PushBack(entry[K, V]{kv.First, kv.Second})
+	}
+	return nil
+}
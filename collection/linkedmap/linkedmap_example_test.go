@@ -0,0 +1,62 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linkedmap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func Example() {
+	m := New[string, int]()
+	m.Store("zero", 0)
+	m.Store("one", 1)
+	m.Store("two", 2)
+	m.Store("zero", 10) // updating does not change the order
+
+	fmt.Println(m.Keys())           // [zero one two]
+	fmt.Println(m.Oldest().Value()) // {zero 10}
+	fmt.Println(m.Newest().Value()) // {two 2}
+
+	m.MoveToBack("zero")
+	fmt.Println(m) // linkedmap[one:1 two:2 zero:10]
+
+	bs, _ := json.Marshal(m)
+	fmt.Println(string(bs)) // {"one":1,"two":2,"zero":10}
+
+	// Output:
+	// [zero one two]
+	// {zero 10}
+	// {two 2}
+	// linkedmap[one:1 two:2 zero:10]
+	// {"one":1,"two":2,"zero":10}
+}
+
+func ExampleNewAccessOrdered() {
+	m := NewAccessOrdered[string, int]()
+	m.Store("a", 1)
+	m.Store("b", 2)
+	m.Store("c", 3)
+
+	m.Load("a")
+	fmt.Println(m.Keys()) // [b c a]
+
+	// Evict the least recently used entry.
+	fmt.Println(m.PopOldest().Value()) // {b 2}
+
+	// Output:
+	// [b c a]
+	// {b 2}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linkedmap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestNilLinkedMap(t *testing.T) {
+	{ // read for nil pointer
+		var m *LinkedMap[string, int]
+		assert.Equal(t, 0, m.Len())
+		assert.False(t, m.Contains("a"))
+		assert.False(t, m.Delete("a"))
+		assert.False(t, m.MoveToFront("a"))
+		assert.False(t, m.MoveToBack("a"))
+		_, ok := m.Load("a")
+		assert.False(t, ok)
+		_, ok = m.Peek("a")
+		assert.False(t, ok)
+		assert.Equal(t, goption.Nil[tuple.T2[string, int]](), m.Oldest())
+		assert.Equal(t, goption.Nil[tuple.T2[string, int]](), m.PopNewest())
+		assert.Equal(t, []string{}, m.Keys())
+		assert.Equal(t, []int{}, m.Values())
+		assert.Equal(t, "linkedmap[]", m.String())
+		assert.Equal(t, 0, m.Clone().Len())
+		m.Clear()
+		bs, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, "null", string(bs))
+	}
+	{ // write for zero value
+		var m LinkedMap[string, int]
+		m.Store("b", 2)
+		m.Store("a", 1)
+		assert.Equal(t, []string{"b", "a"}, m.Keys())
+	}
+	{
+		var m LinkedMap[string, int]
+		v, loaded := m.LoadOrStore("a", 1)
+		assert.False(t, loaded)
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestInsertionOrder(t *testing.T) {
+	m := New[string, int]()
+	m.Store("c", 3)
+	m.Store("a", 1)
+	m.Store("b", 2)
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	assert.Equal(t, []int{3, 1, 2}, m.Values())
+
+	// Update does not change the order.
+	m.Store("c", 30)
+	v, ok := m.Load("c")
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+
+	v, loaded := m.LoadOrStore("a", 10)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, v)
+	v, loaded = m.LoadOrStore("d", 4)
+	assert.False(t, loaded)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, []string{"c", "a", "b", "d"}, m.Keys())
+
+	assert.Equal(t, goption.OK(tuple.Make2("c", 30)), m.Oldest())
+	assert.Equal(t, goption.OK(tuple.Make2("d", 4)), m.Newest())
+
+	assert.True(t, m.MoveToBack("c"))
+	assert.True(t, m.MoveToFront("b"))
+	assert.False(t, m.MoveToFront("z"))
+	assert.Equal(t, []string{"b", "a", "d", "c"}, m.Keys())
+
+	var rev []string
+	m.RangeReverse(func(k string, _ int) bool {
+		rev = append(rev, k)
+		return k != "a"
+	})
+	assert.Equal(t, []string{"c", "d", "a"}, rev)
+
+	var fwd []string
+	m.Range(func(k string, _ int) bool {
+		fwd = append(fwd, k)
+		return len(fwd) < 2
+	})
+	assert.Equal(t, []string{"b", "a"}, fwd)
+
+	v, ok = m.LoadAndDelete("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.False(t, m.Delete("a"))
+	assert.True(t, m.Delete("d"))
+	assert.Equal(t, tuple.S2[string, int]{tuple.Make2("b", 2), tuple.Make2("c", 30)}, m.Items())
+	assert.Equal(t, map[string]int{"b": 2, "c": 30}, m.ToMap())
+
+	// Reinsert after deletion goes to the newest position.
+	m.Store("a", 1)
+	assert.Equal(t, []string{"b", "c", "a"}, m.Keys())
+
+	assert.Equal(t, goption.OK(tuple.Make2("b", 2)), m.PopOldest())
+	assert.Equal(t, goption.OK(tuple.Make2("a", 1)), m.PopNewest())
+	assert.Equal(t, []string{"c"}, m.Keys())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, goption.Nil[tuple.T2[string, int]](), m.PopOldest())
+	m.Store("x", 1)
+	assert.Equal(t, []string{"x"}, m.Keys())
+}
+
+func TestAccessOrder(t *testing.T) {
+	m := NewAccessOrdered[int, string]()
+	m.Store(1, "1")
+	m.Store(2, "2")
+	m.Store(3, "3")
+	assert.Equal(t, []int{1, 2, 3}, m.Keys())
+
+	m.Load(1)
+	assert.Equal(t, []int{2, 3, 1}, m.Keys())
+
+	m.Store(2, "22")
+	assert.Equal(t, []int{3, 1, 2}, m.Keys())
+
+	m.LoadOrStore(3, "33")
+	assert.Equal(t, []int{1, 2, 3}, m.Keys())
+
+	// Peek and Contains do not change the order.
+	v, ok := m.Peek(1)
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+	assert.True(t, m.Contains(2))
+	assert.Equal(t, []int{1, 2, 3}, m.Keys())
+
+	// Least recently used.
+	assert.Equal(t, goption.OK(tuple.Make2(1, "1")), m.PopOldest())
+
+	c := m.Clone()
+	c.Load(2)
+	assert.Equal(t, []int{3, 2}, c.Keys())
+	assert.Equal(t, []int{2, 3}, m.Keys())
+}
+
+func TestString(t *testing.T) {
+	m := New[string, int]()
+	m.Store("b", 2)
+	m.Store("a", 1)
+	assert.Equal(t, "linkedmap[b:2 a:1]", m.String())
+}
+
+func TestJSON(t *testing.T) {
+	{
+		m := New[string, int]()
+		m.Store("z", 26)
+		m.Store("a", 1)
+		m.Store("m", 13)
+		bs, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"z":26,"a":1,"m":13}`, string(bs))
+
+		m2 := New[string, int]()
+		m2.Store("x", 0)
+		assert.Nil(t, json.Unmarshal(bs, m2))
+		assert.Equal(t, []string{"z", "a", "m"}, m2.Keys())
+		assert.Equal(t, []int{26, 1, 13}, m2.Values())
+	}
+	{
+		m := New[int, []string]()
+		assert.Nil(t, json.Unmarshal([]byte(`{"3":["c"],"1":["a"],"2":null,"1":["aa"]}`), m))
+		assert.Equal(t, []int{3, 1, 2}, m.Keys())
+		assert.Equal(t, [][]string{{"c"}, {"aa"}, nil}, m.Values())
+
+		bs, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"3":["c"],"1":["aa"],"2":null}`, string(bs))
+	}
+	{ // empty
+		m := New[string, int]()
+		bs, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{}`, string(bs))
+	}
+	{ // as field
+		type S struct {
+			M *LinkedMap[string, int] `json:"m"`
+		}
+		var s S
+		assert.Nil(t, json.Unmarshal([]byte(`{"m":{"b":1,"a":2}}`), &s))
+		assert.Equal(t, []string{"b", "a"}, s.M.Keys())
+		bs, err := json.Marshal(s)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"m":{"b":1,"a":2}}`, string(bs))
+	}
+	{ // null is no-op
+		m := New[string, int]()
+		m.Store("a", 1)
+		assert.Nil(t, json.Unmarshal([]byte(`null`), m))
+		assert.Equal(t, 1, m.Len())
+	}
+	{ // errors keep the original entries
+		m := New[int, int]()
+		m.Store(1, 1)
+		assert.NotNil(t, json.Unmarshal([]byte(`{"a":1}`), m))
+		assert.NotNil(t, json.Unmarshal([]byte(`{"1":"a"}`), m))
+		assert.NotNil(t, json.Unmarshal([]byte(`[1]`), m))
+		assert.Equal(t, []int{1}, m.Keys())
+
+		f := New[float64, int]()
+		assert.NotNil(t, json.Unmarshal([]byte(`{"1":1}`), f))
+		f.Store(1.5, 1)
+		_, err := json.Marshal(f)
+		assert.NotNil(t, err)
+	}
+}
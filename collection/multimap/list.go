@@ -0,0 +1,256 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"encoding/json"
+
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+// ListMultimap is a multimap that keeps values of a key in insertion order,
+// duplicated key-value pairs are allowed.
+//
+// The zero value for ListMultimap is an empty multimap ready to use.
+//
+// 💡 NOTE: ListMultimap is not concurrent-safe.
+type ListMultimap[K, V comparable] struct {
+	m   map[K][]V
+	len int // count of key-value pairs
+}
+
+// NewList creates an empty [ListMultimap].
+func NewList[K, V comparable]() *ListMultimap[K, V] {
+	return &ListMultimap[K, V]{m: make(map[K][]V)}
+}
+
+// ListFromMap creates a [ListMultimap] from map m, such as the result of
+// [github.com/bytedance/gg/gmap.InvertGroup].
+//
+// 💡 NOTE: Slices of m are copied.
+func ListFromMap[K, V comparable](m map[K][]V) *ListMultimap[K, V] {
+	mm := NewList[K, V]()
+	for k, vs := range m {
+		mm.PutAll(k, vs...)
+	}
+	return mm
+}
+
+// lazyInit lazily initializes a zero ListMultimap value.
+func (mm *ListMultimap[K, V]) lazyInit() {
+	if mm.m == nil {
+		mm.m = make(map[K][]V)
+	}
+}
+
+// Len returns the number of key-value pairs of multimap.
+func (mm *ListMultimap[K, V]) Len() int {
+	if mm == nil {
+		return 0
+	}
+	return mm.len
+}
+
+// KeyCount returns the number of distinct keys of multimap.
+func (mm *ListMultimap[K, V]) KeyCount() int {
+	if mm == nil {
+		return 0
+	}
+	return len(mm.m)
+}
+
+// Put appends value v to the values of key k.
+func (mm *ListMultimap[K, V]) Put(k K, v V) {
+	mm.lazyInit()
+	mm.m[k] = append(mm.m[k], v)
+	mm.len++
+}
+
+// PutAll appends values vs to the values of key k.
+func (mm *ListMultimap[K, V]) PutAll(k K, vs ...V) {
+	if len(vs) == 0 {
+		return
+	}
+	mm.lazyInit()
+	mm.m[k] = append(mm.m[k], vs...)
+	mm.len += len(vs)
+}
+
+// Get returns a copy of values of key k, in insertion order.
+//
+// 💡 NOTE: Empty slice is returned when key is not present.
+func (mm *ListMultimap[K, V]) Get(k K) []V {
+	if mm == nil {
+		return []V{}
+	}
+	return append([]V{}, mm.m[k]...)
+}
+
+// Remove removes the first occurrence of value v from the values of key k.
+// If the pair is not present, return false.
+func (mm *ListMultimap[K, V]) Remove(k K, v V) bool {
+	if mm == nil {
+		return false
+	}
+	vs := mm.m[k]
+	i := gslice.Index(vs, v)
+	if i.IsNil() {
+		return false
+	}
+	if len(vs) == 1 {
+		delete(mm.m, k)
+	} else {
+		mm.m[k] = gslice.RemoveIndex(vs, i.Value())
+	}
+	mm.len--
+	return true
+}
+
+// RemoveAll removes key k and returns all its values.
+//
+// 💡 NOTE: Nil is returned when key is not present.
+func (mm *ListMultimap[K, V]) RemoveAll(k K) []V {
+	if mm == nil {
+		return nil
+	}
+	vs, ok := mm.m[k]
+	if !ok {
+		return nil
+	}
+	delete(mm.m, k)
+	mm.len -= len(vs)
+	return vs
+}
+
+// ContainsKey returns true if key k has at least one value.
+func (mm *ListMultimap[K, V]) ContainsKey(k K) bool {
+	if mm == nil {
+		return false
+	}
+	_, ok := mm.m[k]
+	return ok
+}
+
+// ContainsEntry returns true if the key-value pair is present.
+func (mm *ListMultimap[K, V]) ContainsEntry(k K, v V) bool {
+	if mm == nil {
+		return false
+	}
+	return gslice.Contains(mm.m[k], v)
+}
+
+// Keys returns all distinct keys of multimap.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (mm *ListMultimap[K, V]) Keys() []K {
+	keys := make([]K, 0, mm.KeyCount())
+	if mm == nil {
+		return keys
+	}
+	for k := range mm.m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Range calls f sequentially for each key-value pair present in the multimap.
+// If f returns false, range stops the iteration.
+//
+// 💡 NOTE: The iteration order over keys is not specified, values of the
+// same key are iterated in insertion order.
+func (mm *ListMultimap[K, V]) Range(f func(k K, v V) bool) {
+	if mm == nil {
+		return
+	}
+	for k, vs := range mm.m {
+		for _, v := range vs {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Inverse returns a new multimap with keys and values swapped.
+func (mm *ListMultimap[K, V]) Inverse() *ListMultimap[V, K] {
+	res := NewList[V, K]()
+	mm.Range(func(k K, v V) bool {
+		res.Put(v, k)
+		return true
+	})
+	return res
+}
+
+// Clone returns a copy of the multimap.
+func (mm *ListMultimap[K, V]) Clone() *ListMultimap[K, V] {
+	res := NewList[K, V]()
+	if mm == nil {
+		return res
+	}
+	for k, vs := range mm.m {
+		res.m[k] = append([]V{}, vs...)
+	}
+	res.len = mm.len
+	return res
+}
+
+// ToMap converts the multimap into a builtin map.
+//
+// 💡 NOTE: Slices of returned map are copied.
+func (mm *ListMultimap[K, V]) ToMap() map[K][]V {
+	res := make(map[K][]V, mm.KeyCount())
+	if mm == nil {
+		return res
+	}
+	for k, vs := range mm.m {
+		res[k] = append([]V{}, vs...)
+	}
+	return res
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// The returned bytes is null or JSON object, keys of object are sorted
+// lexicographically, values of key are in insertion order.
+func (mm *ListMultimap[K, V]) MarshalJSON() ([]byte, error) {
+	if mm == nil {
+		return []byte("null"), nil
+	}
+	enc := jsonbuilder.NewDict()
+	for k, vs := range mm.m {
+		if err := enc.Store(k, vs); err != nil {
+			return nil, err
+		}
+	}
+	enc.Sort()
+	return enc.Build()
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// The original pairs are always overridden.
+func (mm *ListMultimap[K, V]) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+	var m map[K][]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*mm = *ListFromMap(m)
+	return nil
+}
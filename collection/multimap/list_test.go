@@ -0,0 +1,141 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gg/gmap"
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestListMultimapNil(t *testing.T) {
+	var mm *ListMultimap[string, int]
+	assert.Equal(t, 0, mm.Len())
+	assert.Equal(t, 0, mm.KeyCount())
+	assert.Equal(t, []int{}, mm.Get("a"))
+	assert.False(t, mm.Remove("a", 1))
+	assert.Nil(t, mm.RemoveAll("a"))
+	assert.False(t, mm.ContainsKey("a"))
+	assert.False(t, mm.ContainsEntry("a", 1))
+	assert.Equal(t, []string{}, mm.Keys())
+	assert.Equal(t, 0, mm.Inverse().Len())
+	assert.Equal(t, 0, mm.Clone().Len())
+	assert.Equal(t, map[string][]int{}, mm.ToMap())
+	bs, err := json.Marshal(mm)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(bs))
+
+	var zero ListMultimap[string, int]
+	zero.Put("a", 1)
+	zero.PutAll("a", 2, 3)
+	assert.Equal(t, []int{1, 2, 3}, zero.Get("a"))
+}
+
+func TestListMultimap(t *testing.T) {
+	mm := NewList[string, int]()
+	mm.Put("a", 1)
+	mm.Put("a", 2)
+	mm.Put("a", 1)
+	mm.PutAll("b", 3, 4)
+	mm.PutAll("c")
+	assert.Equal(t, 5, mm.Len())
+	assert.Equal(t, 2, mm.KeyCount())
+	assert.Equal(t, []int{1, 2, 1}, mm.Get("a"))
+	assert.Equal(t, []int{}, mm.Get("c"))
+	assert.False(t, mm.ContainsKey("c"))
+	assert.True(t, mm.ContainsKey("b"))
+	assert.True(t, mm.ContainsEntry("b", 4))
+	assert.False(t, mm.ContainsEntry("b", 1))
+	assert.Equal(t, []string{"a", "b"}, gslice.SortClone(mm.Keys()))
+
+	// Get returns a copy.
+	vs := mm.Get("a")
+	vs[0] = 100
+	assert.Equal(t, []int{1, 2, 1}, mm.Get("a"))
+
+	assert.True(t, mm.Remove("a", 1))
+	assert.Equal(t, []int{2, 1}, mm.Get("a"))
+	assert.False(t, mm.Remove("a", 3))
+	assert.False(t, mm.Remove("c", 3))
+	assert.Equal(t, 4, mm.Len())
+
+	assert.True(t, mm.Remove("a", 2))
+	assert.True(t, mm.Remove("a", 1))
+	assert.False(t, mm.ContainsKey("a"))
+	assert.Equal(t, 2, mm.Len())
+	assert.Equal(t, 1, mm.KeyCount())
+
+	assert.Equal(t, []int{3, 4}, mm.RemoveAll("b"))
+	assert.Nil(t, mm.RemoveAll("b"))
+	assert.Equal(t, 0, mm.Len())
+	assert.Equal(t, 0, mm.KeyCount())
+}
+
+func TestListMultimapConversion(t *testing.T) {
+	mm := ListFromMap(gmap.InvertGroup(map[string]int{"a": 1, "b": 2, "c": 1}))
+	assert.Equal(t, 3, mm.Len())
+	assert.Equal(t, []string{"a", "c"}, gslice.SortClone(mm.Get(1)))
+
+	inv := mm.Inverse()
+	assert.Equal(t, 3, inv.Len())
+	assert.Equal(t, 3, inv.KeyCount())
+	assert.Equal(t, []int{1}, inv.Get("a"))
+	assert.Equal(t, []int{2}, inv.Get("b"))
+
+	cnt := 0
+	mm.Range(func(int, string) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 1, cnt)
+
+	c := mm.Clone()
+	c.Put(3, "d")
+	assert.Equal(t, 4, c.Len())
+	assert.Equal(t, 3, mm.Len())
+
+	m := mm.ToMap()
+	m[2][0] = "x"
+	assert.Equal(t, []string{"b"}, mm.Get(2))
+}
+
+func TestListMultimapJSON(t *testing.T) {
+	mm := NewList[string, int]()
+	mm.PutAll("b", 2, 1, 2)
+	mm.Put("a", 0)
+	bs, err := json.Marshal(mm)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[0],"b":[2,1,2]}`, string(bs))
+
+	mm2 := NewList[string, int]()
+	mm2.Put("x", 1)
+	assert.Nil(t, json.Unmarshal(bs, mm2))
+	assert.Equal(t, 4, mm2.Len())
+	assert.False(t, mm2.ContainsKey("x"))
+	assert.Equal(t, []int{2, 1, 2}, mm2.Get("b"))
+
+	assert.Nil(t, json.Unmarshal([]byte("null"), mm2))
+	assert.Equal(t, 4, mm2.Len())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":1}`), mm2))
+
+	var s struct {
+		M ListMultimap[int, string] `json:"m"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"m":{"1":["a"]}}`), &s))
+	assert.Equal(t, []string{"a"}, s.M.Get(1))
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multimap provides maps that associate a key with multiple values.
+//
+// 💡 NOTE: Multimaps are not concurrent-safe.
+//
+// # Structures
+//
+//   - [ListMultimap]: values of a key are kept in a slice, duplicated values are allowed
+//   - [SetMultimap]: values of a key are kept in a [github.com/bytedance/gg/collection/set.Set]
+//
+// # Operations
+//
+//   - Constructor: [NewList], [NewSet], [ListFromMap], [SetFromMap]
+//   - CRUD operations: [ListMultimap.Put], [ListMultimap.PutAll], [ListMultimap.Get], [ListMultimap.Remove], [ListMultimap.RemoveAll], …
+//   - Predicates: [ListMultimap.ContainsKey], [ListMultimap.ContainsEntry]
+//   - Conversion: [ListMultimap.Inverse], [ListMultimap.ToMap], …
+//
+// # JSON
+//
+// Both multimaps implement [encoding/json.Marshaler] and [encoding/json.Unmarshaler],
+// a multimap is encoded as a JSON object whose values are arrays.
+package multimap
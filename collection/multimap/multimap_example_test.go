@@ -0,0 +1,50 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func Example() {
+	owners := NewList[string, string]()
+	owners.Put("gg", "alice")
+	owners.PutAll("gg", "bob", "alice")
+	owners.Put("kitex", "bob")
+
+	fmt.Println(owners.Len())                         // 4
+	fmt.Println(owners.KeyCount())                    // 2
+	fmt.Println(owners.Get("gg"))                     // [alice bob alice]
+	fmt.Println(owners.ContainsEntry("kitex", "bob")) // true
+
+	projects := NewSet[string, string]()
+	owners.Range(func(project, owner string) bool {
+		projects.Put(owner, project)
+		return true
+	})
+	fmt.Println(projects.Get("bob")) // set[gg kitex]
+
+	bs, _ := json.Marshal(projects)
+	fmt.Println(string(bs)) // {"alice":["gg"],"bob":["gg","kitex"]}
+
+	// Output:
+	// 4
+	// 2
+	// [alice bob alice]
+	// true
+	// set[gg kitex]
+	// {"alice":["gg"],"bob":["gg","kitex"]}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"encoding/json"
+
+	"github.com/bytedance/gg/collection/set"
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+// SetMultimap is a multimap that keeps values of a key in a
+// [github.com/bytedance/gg/collection/set.Set], duplicated key-value pairs
+// are not allowed.
+//
+// The zero value for SetMultimap is an empty multimap ready to use.
+//
+// 💡 NOTE: SetMultimap is not concurrent-safe.
+type SetMultimap[K, V comparable] struct {
+	m   map[K]*set.Set[V]
+	len int // count of key-value pairs
+}
+
+// NewSet creates an empty [SetMultimap].
+func NewSet[K, V comparable]() *SetMultimap[K, V] {
+	return &SetMultimap[K, V]{m: make(map[K]*set.Set[V])}
+}
+
+// SetFromMap creates a [SetMultimap] from map m, such as the result of
+// [github.com/bytedance/gg/gmap.InvertGroup].
+func SetFromMap[K, V comparable](m map[K][]V) *SetMultimap[K, V] {
+	mm := NewSet[K, V]()
+	for k, vs := range m {
+		mm.PutAll(k, vs...)
+	}
+	return mm
+}
+
+// lazyInit lazily initializes a zero SetMultimap value.
+func (mm *SetMultimap[K, V]) lazyInit() {
+	if mm.m == nil {
+		mm.m = make(map[K]*set.Set[V])
+	}
+}
+
+// Len returns the number of key-value pairs of multimap.
+func (mm *SetMultimap[K, V]) Len() int {
+	if mm == nil {
+		return 0
+	}
+	return mm.len
+}
+
+// KeyCount returns the number of distinct keys of multimap.
+func (mm *SetMultimap[K, V]) KeyCount() int {
+	if mm == nil {
+		return 0
+	}
+	return len(mm.m)
+}
+
+// Put adds value v to the values of key k.
+// If the pair is already present, return false.
+func (mm *SetMultimap[K, V]) Put(k K, v V) bool {
+	mm.lazyInit()
+	s, ok := mm.m[k]
+	if !ok {
+		s = set.New[V]()
+		mm.m[k] = s
+	}
+	if !s.Add(v) {
+		return false
+	}
+	mm.len++
+	return true
+}
+
+// PutAll adds values vs to the values of key k.
+// It will not tell you which values have been successfully added.
+func (mm *SetMultimap[K, V]) PutAll(k K, vs ...V) {
+	for _, v := range vs {
+		mm.Put(k, v)
+	}
+}
+
+// Get returns a copy of values of key k.
+//
+// 💡 NOTE: Empty set is returned when key is not present.
+func (mm *SetMultimap[K, V]) Get(k K) *set.Set[V] {
+	if mm == nil {
+		return set.New[V]()
+	}
+	return mm.m[k].Clone()
+}
+
+// Remove removes value v from the values of key k.
+// If the pair is not present, return false.
+func (mm *SetMultimap[K, V]) Remove(k K, v V) bool {
+	if mm == nil {
+		return false
+	}
+	s, ok := mm.m[k]
+	if !ok || !s.Remove(v) {
+		return false
+	}
+	if s.Len() == 0 {
+		delete(mm.m, k)
+	}
+	mm.len--
+	return true
+}
+
+// RemoveAll removes key k and returns all its values.
+//
+// 💡 NOTE: Nil is returned when key is not present.
+func (mm *SetMultimap[K, V]) RemoveAll(k K) *set.Set[V] {
+	if mm == nil {
+		return nil
+	}
+	s, ok := mm.m[k]
+	if !ok {
+		return nil
+	}
+	delete(mm.m, k)
+	mm.len -= s.Len()
+	return s
+}
+
+// ContainsKey returns true if key k has at least one value.
+func (mm *SetMultimap[K, V]) ContainsKey(k K) bool {
+	if mm == nil {
+		return false
+	}
+	_, ok := mm.m[k]
+	return ok
+}
+
+// ContainsEntry returns true if the key-value pair is present.
+func (mm *SetMultimap[K, V]) ContainsEntry(k K, v V) bool {
+	if mm == nil {
+		return false
+	}
+	return mm.m[k].Contains(v)
+}
+
+// Keys returns all distinct keys of multimap.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (mm *SetMultimap[K, V]) Keys() []K {
+	keys := make([]K, 0, mm.KeyCount())
+	if mm == nil {
+		return keys
+	}
+	for k := range mm.m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Range calls f sequentially for each key-value pair present in the multimap.
+// If f returns false, range stops the iteration.
+//
+// 💡 NOTE: The iteration order is not specified.
+func (mm *SetMultimap[K, V]) Range(f func(k K, v V) bool) {
+	if mm == nil {
+		return
+	}
+	for k, s := range mm.m {
+		cont := true
+		s.Range(func(v V) bool {
+			cont = f(k, v)
+			return cont
+		})
+		if !cont {
+			return
+		}
+	}
+}
+
+// Inverse returns a new multimap with keys and values swapped.
+func (mm *SetMultimap[K, V]) Inverse() *SetMultimap[V, K] {
+	res := NewSet[V, K]()
+	mm.Range(func(k K, v V) bool {
+		res.Put(v, k)
+		return true
+	})
+	return res
+}
+
+// Clone returns a copy of the multimap.
+func (mm *SetMultimap[K, V]) Clone() *SetMultimap[K, V] {
+	res := NewSet[K, V]()
+	if mm == nil {
+		return res
+	}
+	for k, s := range mm.m {
+		res.m[k] = s.Clone()
+	}
+	res.len = mm.len
+	return res
+}
+
+// ToMap converts the multimap into a builtin map.
+//
+// 💡 NOTE: Sets of returned map are copied.
+func (mm *SetMultimap[K, V]) ToMap() map[K]*set.Set[V] {
+	res := make(map[K]*set.Set[V], mm.KeyCount())
+	if mm == nil {
+		return res
+	}
+	for k, s := range mm.m {
+		res[k] = s.Clone()
+	}
+	return res
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+//
+// The returned bytes is null or JSON object, keys of object and elements of
+// arrays are sorted lexicographically.
+func (mm *SetMultimap[K, V]) MarshalJSON() ([]byte, error) {
+	if mm == nil {
+		return []byte("null"), nil
+	}
+	enc := jsonbuilder.NewDict()
+	for k, s := range mm.m {
+		if err := enc.Store(k, s); err != nil {
+			return nil, err
+		}
+	}
+	enc.Sort()
+	return enc.Build()
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+//
+// The original pairs are always overridden.
+func (mm *SetMultimap[K, V]) UnmarshalJSON(data []byte) error {
+	// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+	if string(data) == "null" {
+		return nil
+	}
+	var m map[K][]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*mm = *SetFromMap(m)
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multimap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gg/collection/set"
+	"github.com/bytedance/gg/gmap"
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestSetMultimapNil(t *testing.T) {
+	var mm *SetMultimap[string, int]
+	assert.Equal(t, 0, mm.Len())
+	assert.Equal(t, 0, mm.KeyCount())
+	assert.Equal(t, 0, mm.Get("a").Len())
+	assert.False(t, mm.Remove("a", 1))
+	assert.Nil(t, mm.RemoveAll("a"))
+	assert.False(t, mm.ContainsKey("a"))
+	assert.False(t, mm.ContainsEntry("a", 1))
+	assert.Equal(t, []string{}, mm.Keys())
+	assert.Equal(t, 0, mm.Inverse().Len())
+	assert.Equal(t, 0, mm.Clone().Len())
+	assert.Equal(t, map[string]*set.Set[int]{}, mm.ToMap())
+	bs, err := json.Marshal(mm)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(bs))
+
+	var zero SetMultimap[string, int]
+	assert.True(t, zero.Put("a", 1))
+	zero.PutAll("a", 1, 2)
+	assert.Equal(t, 2, zero.Len())
+}
+
+func TestSetMultimap(t *testing.T) {
+	mm := NewSet[string, int]()
+	assert.True(t, mm.Put("a", 1))
+	assert.True(t, mm.Put("a", 2))
+	assert.False(t, mm.Put("a", 1))
+	mm.PutAll("b", 3, 4, 3)
+	mm.PutAll("c")
+	assert.Equal(t, 4, mm.Len())
+	assert.Equal(t, 2, mm.KeyCount())
+	assert.True(t, mm.Get("a").Equal(set.New(1, 2)))
+	assert.Equal(t, 0, mm.Get("c").Len())
+	assert.False(t, mm.ContainsKey("c"))
+	assert.True(t, mm.ContainsEntry("b", 4))
+	assert.False(t, mm.ContainsEntry("b", 1))
+	assert.Equal(t, []string{"a", "b"}, gslice.SortClone(mm.Keys()))
+
+	// Get returns a copy.
+	mm.Get("a").Add(100)
+	assert.False(t, mm.ContainsEntry("a", 100))
+
+	assert.True(t, mm.Remove("a", 1))
+	assert.False(t, mm.Remove("a", 1))
+	assert.False(t, mm.Remove("c", 1))
+	assert.True(t, mm.Remove("a", 2))
+	assert.False(t, mm.ContainsKey("a"))
+	assert.Equal(t, 2, mm.Len())
+
+	assert.True(t, mm.RemoveAll("b").Equal(set.New(3, 4)))
+	assert.Nil(t, mm.RemoveAll("b"))
+	assert.Equal(t, 0, mm.Len())
+	assert.Equal(t, 0, mm.KeyCount())
+}
+
+func TestSetMultimapConversion(t *testing.T) {
+	mm := SetFromMap(gmap.InvertGroup(map[string]int{"a": 1, "b": 2, "c": 1}))
+	assert.Equal(t, 3, mm.Len())
+	assert.True(t, mm.Get(1).Equal(set.New("a", "c")))
+
+	inv := mm.Inverse()
+	assert.Equal(t, 3, inv.Len())
+	assert.True(t, inv.Get("c").Equal(set.New(1)))
+
+	cnt := 0
+	mm.Range(func(int, string) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 1, cnt)
+	cnt = 0
+	mm.Range(func(int, string) bool {
+		cnt++
+		return true
+	})
+	assert.Equal(t, 3, cnt)
+
+	c := mm.Clone()
+	c.Put(1, "d")
+	assert.Equal(t, 4, c.Len())
+	assert.Equal(t, 3, mm.Len())
+
+	m := mm.ToMap()
+	m[2].Add("x")
+	assert.False(t, mm.ContainsEntry(2, "x"))
+}
+
+func TestSetMultimapJSON(t *testing.T) {
+	mm := NewSet[string, int]()
+	mm.PutAll("b", 3, 1, 2)
+	mm.Put("a", 0)
+	bs, err := json.Marshal(mm)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[0],"b":[1,2,3]}`, string(bs))
+
+	mm2 := NewSet[string, int]()
+	mm2.Put("x", 1)
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":[0],"b":[1,2,3,3]}`), mm2))
+	assert.Equal(t, 4, mm2.Len())
+	assert.False(t, mm2.ContainsKey("x"))
+
+	assert.Nil(t, json.Unmarshal([]byte("null"), mm2))
+	assert.Equal(t, 4, mm2.Len())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":1}`), mm2))
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+// Map is an immutable hash map.
+// The zero value for Map is an empty map ready to use.
+//
+// Get, Set and Delete are O(log32 n), which is effectively constant.
+//
+// 💡 NOTE: The iteration order over maps is not specified, but is
+// deterministic for the same set of keys.
+type Map[K comparable, V any] struct {
+	root *hnode[K, V]
+	len  int
+}
+
+type hentry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// hslot is an entry or a sub node.
+type hslot[K comparable, V any] struct {
+	child *hnode[K, V] // not nil if it is a sub node
+	entry hentry[K, V]
+}
+
+// hnode is a bitmap indexed node, or a collision node if coll is not nil.
+type hnode[K comparable, V any] struct {
+	bitmap uint32
+	slots  []hslot[K, V]  // one slot per set bit of bitmap
+	coll   []hentry[K, V] // entries with identical hash
+	edit   *owner
+}
+
+// NewMap creates a map from a Go map.
+func NewMap[K comparable, V any](m map[K]V) Map[K, V] {
+	b := Map[K, V]{}.Builder()
+	for k, v := range m {
+		b.Set(k, v)
+	}
+	return b.Map()
+}
+
+func hashOf[K comparable](k K) uint64 {
+	return hashing.Sum64(k, 0)
+}
+
+// Len returns the number of entries in the map.
+func (m Map[K, V]) Len() int {
+	return m.len
+}
+
+// Get returns the value stored in the map for a key, or zero value if no
+// value is present.
+// The ok result indicates whether value was found in the map.
+func (m Map[K, V]) Get(key K) (value V, ok bool) {
+	h := hashOf(key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += nodeBits {
+		if n.coll != nil {
+			for _, e := range n.coll {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			return value, false
+		}
+		bit := uint32(1) << ((h >> shift) & mask)
+		if n.bitmap&bit == 0 {
+			return value, false
+		}
+		s := &n.slots[n.index(bit)]
+		if s.child == nil {
+			if s.entry.key == key {
+				return s.entry.value, true
+			}
+			return value, false
+		}
+		n = s.child
+	}
+	return value, false
+}
+
+// Contains returns whether the key is in the map.
+func (m Map[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new map with the value for a key set.
+func (m Map[K, V]) Set(key K, value V) Map[K, V] {
+	m.set(nil, key, value)
+	return m
+}
+
+// Delete returns a new map without the key.
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	m.delete(nil, key)
+	return m
+}
+
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, range stops the iteration.
+func (m Map[K, V]) Range(f func(key K, value V) bool) {
+	if m.root != nil {
+		m.root.rangeEntries(f)
+	}
+}
+
+// Keys returns all keys of the map.
+func (m Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.len)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// ToMap collects all entries to a Go map.
+func (m Map[K, V]) ToMap() map[K]V {
+	res := make(map[K]V, m.len)
+	m.Range(func(k K, v V) bool {
+		res[k] = v
+		return true
+	})
+	return res
+}
+
+// Builder returns a transient builder initialized with the map.
+//
+// The map itself is not affected by modifications through the builder.
+func (m Map[K, V]) Builder() *MapBuilder[K, V] {
+	return &MapBuilder[K, V]{m: m, edit: new(owner)}
+}
+
+// With calls f with a transient builder initialized with the map, and
+// returns the built map.
+//
+// It is more efficient than calling write methods of Map one by one.
+func (m Map[K, V]) With(f func(b *MapBuilder[K, V])) Map[K, V] {
+	b := m.Builder()
+	f(b)
+	return b.Map()
+}
+
+// String implements [fmt.Stringer].
+func (m Map[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("map[")
+	first := true
+	m.Range(func(k K, v V) bool {
+		if !first {
+			sb.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&sb, "%v:%v", k, v)
+		return true
+	})
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+func (m *Map[K, V]) set(edit *owner, key K, value V) {
+	e := hentry[K, V]{hashOf(key), key, value}
+	if m.root == nil {
+		m.root = &hnode[K, V]{edit: edit}
+	}
+	var added bool
+	m.root = m.root.set(edit, 0, e, &added)
+	if added {
+		m.len++
+	}
+}
+
+func (m *Map[K, V]) delete(edit *owner, key K) {
+	if m.root == nil {
+		return
+	}
+	root, deleted := m.root.delete(edit, 0, hashOf(key), key)
+	if !deleted {
+		return
+	}
+	m.root = root
+	m.len--
+	if m.len == 0 {
+		m.root = nil
+	}
+}
+
+// index returns the index of slot for bit.
+func (n *hnode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// editable returns a node which can be modified by edit.
+func (n *hnode[K, V]) editable(edit *owner) *hnode[K, V] {
+	if owns(n.edit, edit) {
+		return n
+	}
+	c := &hnode[K, V]{bitmap: n.bitmap, edit: edit}
+	if n.coll != nil {
+		c.coll = append([]hentry[K, V]{}, n.coll...)
+	} else {
+		c.slots = append([]hslot[K, V]{}, n.slots...)
+	}
+	return c
+}
+
+func (n *hnode[K, V]) set(edit *owner, shift uint, e hentry[K, V], added *bool) *hnode[K, V] {
+	if n.coll != nil {
+		if n.coll[0].hash != e.hash {
+			// Push the collision node down into a bitmap node.
+			parent := &hnode[K, V]{edit: edit}
+			parent.bitmap = uint32(1) << ((n.coll[0].hash >> shift) & mask)
+			parent.slots = []hslot[K, V]{{child: n}}
+			return parent.set(edit, shift, e, added)
+		}
+		for i := range n.coll {
+			if n.coll[i].key == e.key {
+				n = n.editable(edit)
+				n.coll[i] = e
+				return n
+			}
+		}
+		*added = true
+		n = n.editable(edit)
+		n.coll = append(n.coll, e)
+		return n
+	}
+
+	bit := uint32(1) << ((e.hash >> shift) & mask)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		*added = true
+		n = n.editable(edit)
+		n.bitmap |= bit
+		n.slots = append(n.slots, hslot[K, V]{})
+		copy(n.slots[i+1:], n.slots[i:])
+		n.slots[i] = hslot[K, V]{entry: e}
+		return n
+	}
+	s := n.slots[i]
+	switch {
+	case s.child != nil:
+		child := s.child.set(edit, shift+nodeBits, e, added)
+		if child == s.child {
+			return n
+		}
+		n = n.editable(edit)
+		n.slots[i].child = child
+	case s.entry.key == e.key:
+		n = n.editable(edit)
+		n.slots[i].entry = e
+	default:
+		*added = true
+		n = n.editable(edit)
+		n.slots[i] = hslot[K, V]{child: merge(edit, shift+nodeBits, s.entry, e)}
+	}
+	return n
+}
+
+// merge creates a node containing two entries with different keys.
+func merge[K comparable, V any](edit *owner, shift uint, a, b hentry[K, V]) *hnode[K, V] {
+	if a.hash == b.hash {
+		return &hnode[K, V]{coll: []hentry[K, V]{a, b}, edit: edit}
+	}
+	ia, ib := (a.hash>>shift)&mask, (b.hash>>shift)&mask
+	n := &hnode[K, V]{bitmap: 1<<ia | 1<<ib, edit: edit}
+	switch {
+	case ia == ib:
+		n.slots = []hslot[K, V]{{child: merge(edit, shift+nodeBits, a, b)}}
+	case ia < ib:
+		n.slots = []hslot[K, V]{{entry: a}, {entry: b}}
+	default:
+		n.slots = []hslot[K, V]{{entry: b}, {entry: a}}
+	}
+	return n
+}
+
+// delete removes key from the subtree rooted at n.
+//
+// The returned node is nil if the subtree becomes empty.
+func (n *hnode[K, V]) delete(edit *owner, shift uint, h uint64, key K) (*hnode[K, V], bool) {
+	if n.coll != nil {
+		for i := range n.coll {
+			if n.coll[i].key == key {
+				n = n.editable(edit)
+				copy(n.coll[i:], n.coll[i+1:])
+				n.coll[len(n.coll)-1] = hentry[K, V]{}
+				n.coll = n.coll[:len(n.coll)-1]
+				return n, true
+			}
+		}
+		return n, false
+	}
+
+	bit := uint32(1) << ((h >> shift) & mask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	s := n.slots[i]
+	if s.child == nil {
+		if s.entry.key != key {
+			return n, false
+		}
+		if len(n.slots) == 1 {
+			return nil, true
+		}
+		n = n.editable(edit)
+		n.bitmap &^= bit
+		copy(n.slots[i:], n.slots[i+1:])
+		n.slots[len(n.slots)-1] = hslot[K, V]{}
+		n.slots = n.slots[:len(n.slots)-1]
+		return n, true
+	}
+
+	child, deleted := s.child.delete(edit, shift+nodeBits, h, key)
+	if !deleted {
+		return n, false
+	}
+	n = n.editable(edit)
+	if e, ok := child.single(); ok {
+		// Inline the only entry of child.
+		n.slots[i] = hslot[K, V]{entry: e}
+	} else {
+		n.slots[i].child = child
+	}
+	return n, true
+}
+
+// single returns the entry if n contains exactly one entry.
+func (n *hnode[K, V]) single() (hentry[K, V], bool) {
+	if n.coll != nil {
+		if len(n.coll) == 1 {
+			return n.coll[0], true
+		}
+	} else if len(n.slots) == 1 && n.slots[0].child == nil {
+		return n.slots[0].entry, true
+	}
+	return hentry[K, V]{}, false
+}
+
+func (n *hnode[K, V]) rangeEntries(f func(K, V) bool) bool {
+	for _, e := range n.coll {
+		if !f(e.key, e.value) {
+			return false
+		}
+	}
+	for _, s := range n.slots {
+		if s.child != nil {
+			if !s.child.rangeEntries(f) {
+				return false
+			}
+		} else if !f(s.entry.key, s.entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// MapBuilder is a transient builder of [Map].
+//
+// Use [Map.Builder] to create a builder.
+type MapBuilder[K comparable, V any] struct {
+	m    Map[K, V]
+	edit *owner
+}
+
+// Len returns the number of entries in the builder.
+func (b *MapBuilder[K, V]) Len() int {
+	return b.m.len
+}
+
+// Get returns the value stored in the builder for a key.
+// The ok result indicates whether value was found.
+func (b *MapBuilder[K, V]) Get(key K) (V, bool) {
+	return b.m.Get(key)
+}
+
+// Set sets the value for a key.
+func (b *MapBuilder[K, V]) Set(key K, value V) {
+	b.m.set(b.edit, key, value)
+}
+
+// Delete deletes the value for a key.
+func (b *MapBuilder[K, V]) Delete(key K) {
+	b.m.delete(b.edit, key)
+}
+
+// Map returns the built map.
+//
+// The builder can still be used after that, and further modifications do not
+// affect the returned map.
+func (b *MapBuilder[K, V]) Map() Map[K, V] {
+	// Give up the ownership of nodes.
+	b.edit = new(owner)
+	return b.m
+}
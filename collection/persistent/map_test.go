@@ -0,0 +1,268 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestMap(t *testing.T) {
+	var m Map[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.False(t, m.Contains("a"))
+	assert.Equal(t, map[string]int{}, m.ToMap())
+	assert.Equal(t, "map[]", m.String())
+	assert.Equal(t, m, m.Delete("a"))
+
+	m1 := m.Set("a", 1)
+	m2 := m1.Set("b", 2)
+	m3 := m2.Set("a", 10)
+	m4 := m3.Delete("b")
+	m5 := m4.Delete("c")
+	assert.Equal(t, map[string]int{"a": 1}, m1.ToMap())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m2.ToMap())
+	assert.Equal(t, map[string]int{"a": 10, "b": 2}, m3.ToMap())
+	assert.Equal(t, map[string]int{"a": 10}, m4.ToMap())
+	assert.Equal(t, m4, m5)
+	assert.Equal(t, 2, m3.Len())
+	assert.Equal(t, "map[a:10]", m4.String())
+
+	v, ok := m3.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	_, ok = m4.Get("b")
+	assert.False(t, ok)
+
+	keys := m3.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	assert.Equal(t, 0, m4.Delete("a").Len())
+	assert.Equal(t, map[string]int{"x": 1, "y": 2}, NewMap(map[string]int{"x": 1, "y": 2}).ToMap())
+}
+
+func TestMapLarge(t *testing.T) {
+	const n = 50000
+	var m Map[int, int]
+	versions := []Map[int, int]{m}
+	for i := 0; i < n; i++ {
+		m = m.Set(i, i)
+		if i%1009 == 0 {
+			versions = append(versions, m)
+		}
+	}
+	assert.Equal(t, n, m.Len())
+	for _, old := range versions {
+		for i := 0; i < old.Len(); i++ {
+			v, ok := old.Get(i)
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		assert.False(t, old.Contains(old.Len()))
+	}
+
+	d := m
+	for i := 0; i < n; i += 2 {
+		d = d.Delete(i)
+	}
+	assert.Equal(t, n/2, d.Len())
+	for i := 0; i < n; i++ {
+		assert.Equal(t, i%2 == 1, d.Contains(i))
+		assert.True(t, m.Contains(i))
+	}
+	for i := 1; i < n; i += 2 {
+		d = d.Delete(i)
+	}
+	assert.Equal(t, Map[int, int]{}, d)
+}
+
+func TestMapBuilder(t *testing.T) {
+	base := NewMap(map[int]string{1: "a", 2: "b"})
+	b := base.Builder()
+	for i := 3; i < 1000; i++ {
+		b.Set(i, fmt.Sprint(i))
+	}
+	b.Delete(1)
+	b.Set(2, "B")
+	assert.Equal(t, 998, b.Len())
+	v, ok := b.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "B", v)
+	m1 := b.Map()
+
+	// Modifications after Map do not affect the built one.
+	b.Set(2, "BB")
+	b.Set(500, "x")
+	b.Delete(999)
+	b.Set(1, "A")
+	m2 := b.Map()
+
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, base.ToMap())
+	assert.Equal(t, 998, m1.Len())
+	assert.False(t, m1.Contains(1))
+	v, _ = m1.Get(2)
+	assert.Equal(t, "B", v)
+	v, _ = m1.Get(500)
+	assert.Equal(t, "500", v)
+	assert.True(t, m1.Contains(999))
+
+	assert.Equal(t, 998, m2.Len())
+	v, _ = m2.Get(2)
+	assert.Equal(t, "BB", v)
+	v, _ = m2.Get(500)
+	assert.Equal(t, "x", v)
+	assert.False(t, m2.Contains(999))
+
+	m3 := m2.With(func(b *MapBuilder[int, string]) {
+		for i := 0; i < 1000; i++ {
+			b.Delete(i)
+		}
+	})
+	assert.Equal(t, Map[int, string]{}, m3)
+	assert.Equal(t, 998, m2.Len())
+}
+
+// setHash and deleteHash modify m as if key had hash h.
+func setHash[K comparable, V any](m Map[K, V], h uint64, key K, value V) Map[K, V] {
+	if m.root == nil {
+		m.root = &hnode[K, V]{}
+	}
+	var added bool
+	m.root = m.root.set(nil, 0, hentry[K, V]{h, key, value}, &added)
+	if added {
+		m.len++
+	}
+	return m
+}
+
+func deleteHash[K comparable, V any](m Map[K, V], h uint64, key K) Map[K, V] {
+	root, deleted := m.root.delete(nil, 0, h, key)
+	if deleted {
+		m.root = root
+		m.len--
+		if m.len == 0 {
+			m.root = nil
+		}
+	}
+	return m
+}
+
+func collect[K comparable, V any](t *testing.T, m Map[K, V]) map[K]V {
+	res := m.ToMap()
+	assert.Equal(t, len(res), m.Len())
+	return res
+}
+
+func TestMapCollision(t *testing.T) {
+	var m Map[string, int]
+	m = setHash(m, 0x1234, "a", 1)
+	m = setHash(m, 0x1234, "b", 2)
+	m = setHash(m, 0x1234, "c", 3)
+	m1 := setHash(m, 0x1234, "b", 20)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, collect(t, m))
+	assert.Equal(t, map[string]int{"a": 1, "b": 20, "c": 3}, collect(t, m1))
+
+	// Push the collision node down: 0x1234 and 0x5234 share the lowest 5 bits.
+	m2 := setHash(m1, 0x5234, "d", 4)
+	m2 = setHash(m2, 0x1, "e", 5)
+	assert.Equal(t, map[string]int{"a": 1, "b": 20, "c": 3, "d": 4, "e": 5}, collect(t, m2))
+	assert.Equal(t, 3, m1.Len())
+
+	m3 := deleteHash(m2, 0x1234, "a")
+	m3 = deleteHash(m3, 0x1234, "x") // absent
+	m3 = deleteHash(m3, 0x1234, "c")
+	assert.Equal(t, map[string]int{"b": 20, "d": 4, "e": 5}, collect(t, m3))
+	// The collision node is collapsed into its parent.
+	m4 := deleteHash(m3, 0x5234, "d")
+	m4 = deleteHash(m4, 0x1, "e")
+	assert.Equal(t, map[string]int{"b": 20}, collect(t, m4))
+	assert.Equal(t, &hnode[string, int]{bitmap: 1 << 0x14, slots: []hslot[string, int]{{entry: hentry[string, int]{0x1234, "b", 20}}}}, m4.root)
+	assert.Equal(t, 0, deleteHash(m4, 0x1234, "b").Len())
+	assert.Equal(t, 5, m2.Len())
+}
+
+func TestMapCompositeKey(t *testing.T) {
+	type key struct{ X float64 }
+
+	// Equal struct keys are the same key.
+	m := Map[key, int]{}.Set(key{0}, 1).Set(key{math.Copysign(0, -1)}, 2)
+	assert.Equal(t, 1, m.Len())
+	v, ok := m.Get(key{0})
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	// Pointer keys are identified by address.
+	p := &key{1}
+	pm := Map[*key, int]{}.Set(p, 1)
+	p.X = 2
+	v, ok = pm.Get(p)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.False(t, pm.Contains(&key{2}))
+	assert.Equal(t, 0, pm.Delete(p).Len())
+}
+
+func TestMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	type version struct {
+		m   Map[int, int]
+		ref map[int]int
+	}
+	clone := func(m map[int]int) map[int]int {
+		res := make(map[int]int, len(m))
+		for k, v := range m {
+			res[k] = v
+		}
+		return res
+	}
+	versions := []version{{Map[int, int]{}, map[int]int{}}}
+	for i := 0; i < 3000; i++ {
+		base := versions[r.Intn(len(versions))]
+		m, ref := base.m, clone(base.ref)
+		switch op := r.Intn(10); {
+		case op < 4:
+			k := r.Intn(500)
+			m = m.Set(k, i)
+			ref[k] = i
+		case op < 7:
+			k := r.Intn(500)
+			m = m.Delete(k)
+			delete(ref, k)
+		default:
+			m = m.With(func(b *MapBuilder[int, int]) {
+				for j := r.Intn(100); j > 0; j-- {
+					k := r.Intn(500)
+					if r.Intn(3) == 0 {
+						b.Delete(k)
+						delete(ref, k)
+					} else {
+						b.Set(k, j)
+						ref[k] = j
+					}
+				}
+			})
+		}
+		versions = append(versions, version{m, ref})
+	}
+	for _, ver := range versions {
+		assert.Equal(t, ver.ref, collect(t, ver.m))
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package persistent provides immutable collections with structural sharing.
+//
+// Every "write" operation returns a new version of the collection, which
+// shares most of its internal structure with the old one, so updates cost
+// O(log n) instead of a full copy. All versions are immutable and can be
+// shared across goroutines without synchronization.
+//
+// For bulk updates, use builders ([Vector.Builder], [Map.Builder]) or the
+// With methods ([Vector.With], [Map.With]): a builder is a transient,
+// mutable view of a collection which updates nodes it owns in place.
+//
+// # Structures
+//
+//   - [Vector]: an indexed sequence, based on 32-way trie with tail
+//   - [Map]: a hash map, based on hash array mapped trie (HAMT)
+//
+// 💡 NOTE: Builders are not concurrent-safe.
+package persistent
+
+const (
+	nodeBits = 5
+	width    = 1 << nodeBits
+	mask     = width - 1
+)
+
+// owner identifies the builder which owns a node, a node can only be
+// modified in place by its owner.
+//
+// The field is required: pointers to distinct zero-size variables may be equal.
+type owner struct{ _ byte }
+
+// owns returns whether a node with owner o can be modified by edit.
+func owns(o, edit *owner) bool {
+	return edit != nil && o == edit
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"fmt"
+)
+
+func ExampleVector() {
+	v1 := NewVector(1, 2, 3)
+	v2 := v1.Append(4)
+	v3 := v2.Set(0, 10)
+	v4 := v3.Pop()
+
+	fmt.Println(v1, v2, v3, v4)
+
+	// Output:
+	// vector[1 2 3] vector[1 2 3 4] vector[10 2 3 4] vector[10 2 3]
+}
+
+func ExampleMap() {
+	m1 := NewMap(map[string]int{"a": 1})
+	m2 := m1.Set("b", 2)
+	m3 := m2.Delete("a")
+
+	fmt.Println(m1.ToMap(), m2.ToMap(), m3.ToMap())
+	fmt.Println(m2.Get("b"))
+	fmt.Println(m3.Get("a"))
+
+	// Output:
+	// map[a:1] map[a:1 b:2] map[b:2]
+	// 2 true
+	// 0 false
+}
+
+func ExampleVector_With() {
+	v1 := NewVector[int]()
+	v2 := v1.With(func(b *VectorBuilder[int]) {
+		for i := 0; i < 5; i++ {
+			b.Append(i * i)
+		}
+		b.Set(0, -1)
+	})
+
+	fmt.Println(v1.Len(), v2)
+
+	// Output:
+	// 0 vector[-1 1 4 9 16]
+}
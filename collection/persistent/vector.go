@@ -0,0 +1,395 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"fmt"
+)
+
+// Vector is an immutable indexed sequence.
+// The zero value for Vector is an empty vector ready to use.
+//
+// Get and Set are O(log32 n), which is effectively constant, Append and Pop
+// are amortized O(1).
+type Vector[T any] struct {
+	count int
+	shift uint      // shift of root level
+	root  *vnode[T] // nil means an empty trie
+	tail  []T       // the last (up to 32) values, not in the trie
+}
+
+type vnode[T any] struct {
+	children []*vnode[T] // for internal nodes
+	values   []T         // for leaf nodes
+	edit     *owner
+}
+
+// NewVector creates a vector with values.
+func NewVector[T any](values ...T) Vector[T] {
+	b := Vector[T]{}.Builder()
+	for _, v := range values {
+		b.Append(v)
+	}
+	return b.Vector()
+}
+
+// Len returns the number of values in the vector.
+func (v Vector[T]) Len() int {
+	return v.count
+}
+
+func (v Vector[T]) tailOffset() int {
+	if v.count < width {
+		return 0
+	}
+	return ((v.count - 1) >> nodeBits) << nodeBits
+}
+
+func (v Vector[T]) checkIndex(i int) {
+	if i < 0 || i >= v.count {
+		panic(fmt.Errorf("index out of range [%d] with length %d", i, v.count))
+	}
+}
+
+// leafFor returns the leaf values containing index i.
+func (v Vector[T]) leafFor(i int) []T {
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= nodeBits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
+
+// Get returns the value at index i.
+//
+// 💡 NOTE: It panics if i is out of range.
+func (v Vector[T]) Get(i int) T {
+	v.checkIndex(i)
+	return v.leafFor(i)[i&mask]
+}
+
+// Set returns a new vector with the value at index i replaced by x.
+//
+// 💡 NOTE: It panics if i is out of range.
+func (v Vector[T]) Set(i int, x T) Vector[T] {
+	v.checkIndex(i)
+	v.set(nil, false, i, x)
+	return v
+}
+
+// Append returns a new vector with values appended.
+func (v Vector[T]) Append(values ...T) Vector[T] {
+	if len(values) > 1 {
+		return v.With(func(b *VectorBuilder[T]) {
+			for _, x := range values {
+				b.Append(x)
+			}
+		})
+	}
+	for _, x := range values {
+		v.append(nil, false, x)
+	}
+	return v
+}
+
+// Pop returns a new vector with the last value removed.
+//
+// 💡 NOTE: It panics if the vector is empty.
+func (v Vector[T]) Pop() Vector[T] {
+	if v.count == 0 {
+		panic(fmt.Errorf("pop from empty vector"))
+	}
+	v.pop(nil, false)
+	return v
+}
+
+// Range calls f sequentially for each index and value in the vector.
+// If f returns false, range stops the iteration.
+func (v Vector[T]) Range(f func(i int, x T) bool) {
+	for i := 0; i < v.count; i += width {
+		leaf := v.leafFor(i)
+		for j, x := range leaf {
+			if !f(i+j, x) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice collects all values to a new slice.
+func (v Vector[T]) ToSlice() []T {
+	s := make([]T, 0, v.count)
+	v.Range(func(_ int, x T) bool {
+		s = append(s, x)
+		return true
+	})
+	return s
+}
+
+// Builder returns a transient builder initialized with the vector.
+//
+// The vector itself is not affected by modifications through the builder.
+func (v Vector[T]) Builder() *VectorBuilder[T] {
+	return &VectorBuilder[T]{v: v, edit: new(owner)}
+}
+
+// With calls f with a transient builder initialized with the vector, and
+// returns the built vector.
+//
+// It is more efficient than calling write methods of Vector one by one.
+func (v Vector[T]) With(f func(b *VectorBuilder[T])) Vector[T] {
+	b := v.Builder()
+	f(b)
+	return b.Vector()
+}
+
+// String implements [fmt.Stringer].
+func (v Vector[T]) String() string {
+	return fmt.Sprintf("vector%v", v.ToSlice())
+}
+
+// editable returns a node which can be modified by edit.
+func (n *vnode[T]) editable(edit *owner) *vnode[T] {
+	if owns(n.edit, edit) {
+		return n
+	}
+	c := &vnode[T]{edit: edit}
+	if n.values != nil {
+		c.values = make([]T, len(n.values), width)
+		copy(c.values, n.values)
+	} else {
+		c.children = make([]*vnode[T], len(n.children), width)
+		copy(c.children, n.children)
+	}
+	return c
+}
+
+// editableTail returns the tail which can be modified in place, with
+// capacity for a full leaf.
+func (v *Vector[T]) editableTail(ownedTail bool) []T {
+	if ownedTail {
+		return v.tail
+	}
+	t := make([]T, len(v.tail), width)
+	copy(t, v.tail)
+	return t
+}
+
+// set replaces the value at index i, nodes owned by edit and the tail (if
+// ownedTail) are modified in place.
+func (v *Vector[T]) set(edit *owner, ownedTail bool, i int, x T) {
+	if i >= v.tailOffset() {
+		v.tail = v.editableTail(ownedTail)
+		v.tail[i&mask] = x
+		return
+	}
+	v.root = v.root.set(edit, v.shift, i, x)
+}
+
+func (n *vnode[T]) set(edit *owner, level uint, i int, x T) *vnode[T] {
+	n = n.editable(edit)
+	if level == 0 {
+		n.values[i&mask] = x
+	} else {
+		sub := (i >> level) & mask
+		n.children[sub] = n.children[sub].set(edit, level-nodeBits, i, x)
+	}
+	return n
+}
+
+// append appends x, nodes owned by edit and the tail (if ownedTail) are
+// modified in place.
+// After that, the tail is always owned.
+func (v *Vector[T]) append(edit *owner, ownedTail bool, x T) {
+	if v.count-v.tailOffset() < width {
+		v.tail = append(v.editableTail(ownedTail), x)
+		v.count++
+		return
+	}
+	// The tail is full, push it into the trie.
+	leaf := &vnode[T]{values: v.tail}
+	if ownedTail {
+		leaf.edit = edit
+	}
+	switch {
+	case v.root == nil:
+		v.root = &vnode[T]{children: append(make([]*vnode[T], 0, width), leaf), edit: edit}
+		v.shift = nodeBits
+	case (v.count >> nodeBits) > (1 << v.shift):
+		// The trie is full, add a level.
+		root := &vnode[T]{children: make([]*vnode[T], 0, width), edit: edit}
+		root.children = append(root.children, v.root, newPath(edit, v.shift, leaf))
+		v.root = root
+		v.shift += nodeBits
+	default:
+		v.root = v.root.pushTail(edit, v.count, v.shift, leaf)
+	}
+	v.tail = append(make([]T, 0, width), x)
+	v.count++
+}
+
+func newPath[T any](edit *owner, level uint, leaf *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return leaf
+	}
+	n := &vnode[T]{children: make([]*vnode[T], 0, width), edit: edit}
+	n.children = append(n.children, newPath(edit, level-nodeBits, leaf))
+	return n
+}
+
+func (n *vnode[T]) pushTail(edit *owner, count int, level uint, leaf *vnode[T]) *vnode[T] {
+	n = n.editable(edit)
+	sub := ((count - 1) >> level) & mask
+	var child *vnode[T]
+	if level == nodeBits {
+		child = leaf
+	} else if sub < len(n.children) {
+		child = n.children[sub].pushTail(edit, count, level-nodeBits, leaf)
+	} else {
+		child = newPath(edit, level-nodeBits, leaf)
+	}
+	if sub < len(n.children) {
+		n.children[sub] = child
+	} else {
+		n.children = append(n.children, child)
+	}
+	return n
+}
+
+// pop removes the last value, nodes owned by edit and the tail (if
+// ownedTail) are modified in place.
+// It returns whether the tail is still owned.
+func (v *Vector[T]) pop(edit *owner, ownedTail bool) bool {
+	if v.count == 1 {
+		*v = Vector[T]{}
+		return false
+	}
+	if n := len(v.tail); n > 1 {
+		if ownedTail {
+			var zero T
+			v.tail[n-1] = zero
+			v.tail = v.tail[:n-1]
+		} else {
+			// Limit the capacity, so the shared array is never appended in place.
+			v.tail = v.tail[: n-1 : n-1]
+		}
+		v.count--
+		return ownedTail
+	}
+	// The tail becomes empty, pull the last leaf from the trie.
+	v.tail = v.leafFor(v.count - 2)
+	root := v.root.popTail(edit, v.count, v.shift)
+	switch {
+	case root == nil:
+		v.root, v.shift = nil, 0
+	case v.shift > nodeBits && len(root.children) == 1:
+		v.root = root.children[0]
+		v.shift -= nodeBits
+	default:
+		v.root = root
+	}
+	v.count--
+	return false
+}
+
+// popTail removes the last leaf, it returns nil if n becomes empty.
+func (n *vnode[T]) popTail(edit *owner, count int, level uint) *vnode[T] {
+	sub := ((count - 2) >> level) & mask
+	if level > nodeBits {
+		child := n.children[sub].popTail(edit, count, level-nodeBits)
+		if child == nil && sub == 0 {
+			return nil
+		}
+		n = n.editable(edit)
+		if child == nil {
+			n.children[sub] = nil
+			n.children = n.children[:sub]
+		} else {
+			n.children[sub] = child
+		}
+		return n
+	}
+	if sub == 0 {
+		return nil
+	}
+	n = n.editable(edit)
+	n.children[sub] = nil
+	n.children = n.children[:sub]
+	return n
+}
+
+// VectorBuilder is a transient builder of [Vector].
+//
+// Use [Vector.Builder] to create a builder.
+type VectorBuilder[T any] struct {
+	v         Vector[T]
+	edit      *owner
+	ownedTail bool
+}
+
+// Len returns the number of values in the builder.
+func (b *VectorBuilder[T]) Len() int {
+	return b.v.count
+}
+
+// Get returns the value at index i.
+//
+// 💡 NOTE: It panics if i is out of range.
+func (b *VectorBuilder[T]) Get(i int) T {
+	return b.v.Get(i)
+}
+
+// Set replaces the value at index i with x.
+//
+// 💡 NOTE: It panics if i is out of range.
+func (b *VectorBuilder[T]) Set(i int, x T) {
+	b.v.checkIndex(i)
+	b.v.set(b.edit, b.ownedTail, i, x)
+	if i >= b.v.tailOffset() {
+		b.ownedTail = true
+	}
+}
+
+// Append appends x to the end.
+func (b *VectorBuilder[T]) Append(x T) {
+	b.v.append(b.edit, b.ownedTail, x)
+	b.ownedTail = true
+}
+
+// Pop removes the last value.
+//
+// 💡 NOTE: It panics if the builder is empty.
+func (b *VectorBuilder[T]) Pop() {
+	if b.v.count == 0 {
+		panic(fmt.Errorf("pop from empty vector"))
+	}
+	b.ownedTail = b.v.pop(b.edit, b.ownedTail)
+}
+
+// Vector returns the built vector.
+//
+// The builder can still be used after that, and further modifications do not
+// affect the returned vector.
+func (b *VectorBuilder[T]) Vector() Vector[T] {
+	// Give up the ownership of nodes.
+	b.edit = new(owner)
+	b.ownedTail = false
+	v := b.v
+	v.tail = v.tail[:len(v.tail):len(v.tail)]
+	return v
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistent
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestVector(t *testing.T) {
+	var v Vector[int]
+	assert.Equal(t, 0, v.Len())
+	assert.Equal(t, []int{}, v.ToSlice())
+	assert.Panic(t, func() { v.Get(0) })
+	assert.Panic(t, func() { v.Pop() })
+	assert.Panic(t, func() { v.Set(0, 1) })
+
+	v1 := v.Append(1)
+	v2 := v1.Append(2, 3)
+	v3 := v2.Set(0, 10)
+	v4 := v3.Pop()
+	assert.Equal(t, []int{1}, v1.ToSlice())
+	assert.Equal(t, []int{1, 2, 3}, v2.ToSlice())
+	assert.Equal(t, []int{10, 2, 3}, v3.ToSlice())
+	assert.Equal(t, []int{10, 2}, v4.ToSlice())
+	assert.Equal(t, 0, v.Len())
+	assert.Equal(t, 3, v2.Get(2))
+	assert.Panic(t, func() { v2.Get(3) })
+	assert.Panic(t, func() { v2.Get(-1) })
+	assert.Equal(t, "vector[10 2]", v4.String())
+
+	// Appending to an old version does not affect newer versions.
+	v5 := v4.Append(5)
+	v6 := v4.Append(6)
+	assert.Equal(t, []int{10, 2, 5}, v5.ToSlice())
+	assert.Equal(t, []int{10, 2, 6}, v6.ToSlice())
+}
+
+func TestVectorLarge(t *testing.T) {
+	const n = 40000 // more than 3 levels
+	versions := make([]Vector[int], 0, n+1)
+	var v Vector[int]
+	versions = append(versions, v)
+	for i := 0; i < n; i++ {
+		v = v.Append(i)
+		if i%997 == 0 {
+			versions = append(versions, v)
+		}
+	}
+	assert.Equal(t, n, v.Len())
+	for i := 0; i < n; i++ {
+		assert.Equal(t, i, v.Get(i))
+	}
+	// Old versions are intact.
+	for _, old := range versions {
+		for i := 0; i < old.Len(); i++ {
+			assert.Equal(t, i, old.Get(i))
+		}
+	}
+
+	w := v
+	for i := 0; i < n; i += 7 {
+		w = w.Set(i, -i)
+	}
+	for i := 0; i < n; i++ {
+		assert.Equal(t, i, v.Get(i))
+		if i%7 == 0 {
+			assert.Equal(t, -i, w.Get(i))
+		} else {
+			assert.Equal(t, i, w.Get(i))
+		}
+	}
+
+	for w.Len() > 0 {
+		w = w.Pop()
+		if l := w.Len(); l > 0 && l%1013 == 0 {
+			assert.Equal(t, l-1, w.Get(l-1)*boolInt(l-1 != 0 && (l-1)%7 == 0, -1, 1))
+		}
+	}
+	assert.Equal(t, Vector[int]{}, w)
+	assert.Equal(t, n, v.Len())
+}
+
+func boolInt(b bool, x, y int) int {
+	if b {
+		return x
+	}
+	return y
+}
+
+func TestVectorBuilder(t *testing.T) {
+	base := NewVector(0, 1, 2)
+	b := base.Builder()
+	for i := 3; i < 1000; i++ {
+		b.Append(i)
+	}
+	b.Set(0, -1)
+	b.Set(999, -999)
+	assert.Equal(t, 1000, b.Len())
+	assert.Equal(t, -1, b.Get(0))
+	v1 := b.Vector()
+
+	// Modifications after Vector do not affect the built one.
+	b.Set(0, -2)
+	b.Set(500, -500)
+	b.Set(999, -1000)
+	b.Pop()
+	b.Append(7)
+	v2 := b.Vector()
+
+	assert.Equal(t, []int{0, 1, 2}, base.ToSlice())
+	assert.Equal(t, -1, v1.Get(0))
+	assert.Equal(t, 500, v1.Get(500))
+	assert.Equal(t, -999, v1.Get(999))
+	assert.Equal(t, -2, v2.Get(0))
+	assert.Equal(t, -500, v2.Get(500))
+	assert.Equal(t, 7, v2.Get(999))
+
+	// Pop through the trie.
+	b = v2.Builder()
+	for b.Len() > 10 {
+		b.Pop()
+	}
+	b.Append(100)
+	assert.Equal(t, []int{-2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100}, b.Vector().ToSlice())
+	assert.Equal(t, 1000, v2.Len())
+	assert.Equal(t, 998, v2.Get(998))
+	for b.Len() > 0 {
+		b.Pop()
+	}
+	assert.Panic(t, func() { b.Pop() })
+
+	v3 := v1.With(func(b *VectorBuilder[int]) {
+		b.Set(1, 100)
+		b.Append(1000)
+	})
+	assert.Equal(t, 100, v3.Get(1))
+	assert.Equal(t, 1001, v3.Len())
+	assert.Equal(t, 1, v1.Get(1))
+}
+
+func TestVectorRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	type version struct {
+		v   Vector[int]
+		ref []int
+	}
+	versions := []version{{Vector[int]{}, nil}}
+	for i := 0; i < 3000; i++ {
+		base := versions[r.Intn(len(versions))]
+		v, ref := base.v, append([]int{}, base.ref...)
+		switch op := r.Intn(10); {
+		case op < 3:
+			v = v.Append(i)
+			ref = append(ref, i)
+		case op < 5 && len(ref) > 0:
+			j := r.Intn(len(ref))
+			v = v.Set(j, -i)
+			ref[j] = -i
+		case op < 6 && len(ref) > 0:
+			v = v.Pop()
+			ref = ref[:len(ref)-1]
+		default:
+			v = v.With(func(b *VectorBuilder[int]) {
+				for k := r.Intn(100); k > 0; k-- {
+					switch {
+					case r.Intn(4) == 0 && b.Len() > 0:
+						b.Pop()
+						ref = ref[:len(ref)-1]
+					case r.Intn(3) == 0 && b.Len() > 0:
+						j := r.Intn(b.Len())
+						b.Set(j, k)
+						ref[j] = k
+					default:
+						b.Append(k)
+						ref = append(ref, k)
+					}
+				}
+			})
+		}
+		versions = append(versions, version{v, ref})
+	}
+	for _, ver := range versions {
+		if ver.ref == nil {
+			ver.ref = []int{}
+		}
+		assert.Equal(t, ver.ref, ver.v.ToSlice())
+	}
+}
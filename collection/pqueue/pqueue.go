@@ -0,0 +1,328 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pqueue provides a priority queue based on binary heap.
+//
+// # Structures
+//
+//   - [PriorityQueue]: a priority queue which is not concurrent-safe
+//   - [SyncPriorityQueue]: a concurrent-safe wrapper of [PriorityQueue]
+//   - [Handle]: a reference to an item of queue, used for updating or removing arbitrary item
+//
+// # Operations
+//
+//   - Constructor: [New], [NewOrdered], [Heapify], [NewSync]
+//   - Queue operations: [PriorityQueue.Push], [PriorityQueue.Pop], [PriorityQueue.Peek], …
+//   - Handle operations: [PriorityQueue.Update], [PriorityQueue.Remove], [SyncPriorityQueue.Value], [SyncPriorityQueue.Valid]
+package pqueue
+
+import (
+	"sync"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// Handle is a reference to an item of [PriorityQueue].
+//
+// A handle is invalidated once its item is popped or removed from queue.
+//
+// 💡 NOTE: Methods of Handle are not protected by [SyncPriorityQueue],
+// use [SyncPriorityQueue.Value] and [SyncPriorityQueue.Valid] instead if the
+// queue is wrapped by [NewSync].
+type Handle[T any] struct {
+	value T
+	index int // index in heap, -1 means the item is not in any queue
+	pq    *PriorityQueue[T]
+}
+
+// Value returns the value of the item.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// Valid returns true if the item is still in queue.
+func (h *Handle[T]) Valid() bool {
+	return h.index >= 0
+}
+
+// PriorityQueue is a priority queue based on binary heap,
+// the item with the highest priority (the least one) is popped first.
+//
+// 💡 NOTE: PriorityQueue is not concurrent-safe, use [NewSync] if you need.
+type PriorityQueue[T any] struct {
+	items []*Handle[T]
+	less  func(a, b T) bool
+}
+
+// New creates an empty priority queue.
+// Item a has higher priority than item b if less(a, b) returns true.
+func New[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewOrdered creates an empty priority queue,
+// the smallest item has the highest priority.
+func NewOrdered[T constraints.Ordered]() *PriorityQueue[T] {
+	return New(func(a, b T) bool { return a < b })
+}
+
+// Heapify creates a priority queue from the items of slice s in O(n) time.
+//
+// 💡 NOTE: Slice s is not modified.
+func Heapify[T any](s []T, less func(a, b T) bool) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		items: make([]*Handle[T], len(s)),
+		less:  less,
+	}
+	for i, v := range s {
+		pq.items[i] = &Handle[T]{value: v, index: i, pq: pq}
+	}
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq
+}
+
+// Len returns the number of items of queue.
+// The complexity is O(1).
+func (pq *PriorityQueue[T]) Len() int {
+	if pq == nil {
+		return 0
+	}
+	return len(pq.items)
+}
+
+// Push pushes value v onto queue and returns its handle.
+// The complexity is O(log n).
+func (pq *PriorityQueue[T]) Push(v T) *Handle[T] {
+	h := &Handle[T]{value: v, index: len(pq.items), pq: pq}
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	return h
+}
+
+// Peek returns the item with the highest priority without removing it.
+func (pq *PriorityQueue[T]) Peek() goption.O[T] {
+	if pq.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	return goption.OK(pq.items[0].value)
+}
+
+// Pop removes and returns the item with the highest priority.
+// The complexity is O(log n).
+func (pq *PriorityQueue[T]) Pop() goption.O[T] {
+	if pq.Len() == 0 {
+		return goption.Nil[T]()
+	}
+	return goption.OK(pq.remove(0))
+}
+
+// Update changes the value of the item referenced by handle h,
+// and fixes its position in queue.
+// If h is not a valid handle of this queue, return false.
+// The complexity is O(log n).
+func (pq *PriorityQueue[T]) Update(h *Handle[T], v T) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	h.value = v
+	if !pq.down(h.index) {
+		pq.up(h.index)
+	}
+	return true
+}
+
+// Remove removes the item referenced by handle h from queue.
+// If h is not a valid handle of this queue, return false.
+// The complexity is O(log n).
+func (pq *PriorityQueue[T]) Remove(h *Handle[T]) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	pq.remove(h.index)
+	return true
+}
+
+// Clear removes all items from queue, all handles are invalidated.
+func (pq *PriorityQueue[T]) Clear() {
+	if pq == nil {
+		return
+	}
+	for i, h := range pq.items {
+		h.index = -1
+		h.pq = nil
+		pq.items[i] = nil // avoid memory leaks
+	}
+	pq.items = pq.items[:0]
+}
+
+// ToSlice collects all values of queue.
+//
+// 💡 NOTE: The order of returned slice is not specified.
+func (pq *PriorityQueue[T]) ToSlice() []T {
+	res := make([]T, pq.Len())
+	for i := range res {
+		res[i] = pq.items[i].value
+	}
+	return res
+}
+
+func (pq *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return pq != nil && h != nil && h.pq == pq && h.index >= 0
+}
+
+// remove removes the item at index i and returns its value.
+func (pq *PriorityQueue[T]) remove(i int) T {
+	n := len(pq.items) - 1
+	h := pq.items[i]
+	if i != n {
+		pq.swap(i, n)
+	}
+	pq.items[n] = nil // avoid memory leaks
+	pq.items = pq.items[:n]
+	if i != n && !pq.down(i) {
+		pq.up(i)
+	}
+	h.index = -1
+	h.pq = nil
+	return h.value
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *PriorityQueue[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 // parent
+		if !pq.less(pq.items[j].value, pq.items[i].value) {
+			break
+		}
+		pq.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the item at index i0 down, and reports whether it is moved.
+func (pq *PriorityQueue[T]) down(i0 int) bool {
+	n := len(pq.items)
+	i := i0
+	for {
+		j := 2*i + 1 // left child
+		if j >= n {
+			break
+		}
+		if j2 := j + 1; j2 < n && pq.less(pq.items[j2].value, pq.items[j].value) {
+			j = j2 // right child
+		}
+		if !pq.less(pq.items[j].value, pq.items[i].value) {
+			break
+		}
+		pq.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+// SyncPriorityQueue is a concurrent-safe wrapper of [PriorityQueue],
+// all operations are protected by a mutex.
+type SyncPriorityQueue[T any] struct {
+	mu sync.Mutex
+	pq *PriorityQueue[T]
+}
+
+// NewSync wraps priority queue pq into a concurrent-safe one.
+//
+// 💡 NOTE: pq must not be used directly after wrapping.
+func NewSync[T any](pq *PriorityQueue[T]) *SyncPriorityQueue[T] {
+	return &SyncPriorityQueue[T]{pq: pq}
+}
+
+// Len wraps [PriorityQueue.Len].
+func (q *SyncPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Push wraps [PriorityQueue.Push].
+func (q *SyncPriorityQueue[T]) Push(v T) *Handle[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Push(v)
+}
+
+// Peek wraps [PriorityQueue.Peek].
+func (q *SyncPriorityQueue[T]) Peek() goption.O[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Peek()
+}
+
+// Pop wraps [PriorityQueue.Pop].
+func (q *SyncPriorityQueue[T]) Pop() goption.O[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Pop()
+}
+
+// Update wraps [PriorityQueue.Update].
+func (q *SyncPriorityQueue[T]) Update(h *Handle[T], v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Update(h, v)
+}
+
+// Remove wraps [PriorityQueue.Remove].
+func (q *SyncPriorityQueue[T]) Remove(h *Handle[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Remove(h)
+}
+
+// Value returns the value of the item referenced by handle h.
+// If h is not a valid handle of this queue, return [goption.Nil].
+func (q *SyncPriorityQueue[T]) Value(h *Handle[T]) goption.O[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.pq.owns(h) {
+		return goption.Nil[T]()
+	}
+	return goption.OK(h.value)
+}
+
+// Valid returns true if handle h references an item still in queue.
+func (q *SyncPriorityQueue[T]) Valid(h *Handle[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.owns(h)
+}
+
+// Clear wraps [PriorityQueue.Clear].
+func (q *SyncPriorityQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Clear()
+}
+
+// ToSlice wraps [PriorityQueue.ToSlice].
+func (q *SyncPriorityQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.ToSlice()
+}
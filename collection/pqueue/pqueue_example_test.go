@@ -0,0 +1,57 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pqueue
+
+import (
+	"fmt"
+)
+
+func Example() {
+	type job struct {
+		name     string
+		priority int
+	}
+	pq := New(func(a, b job) bool { return a.priority > b.priority })
+	pq.Push(job{"backup", 1})
+	pq.Push(job{"deploy", 5})
+	logs := pq.Push(job{"logs", 2})
+	fmt.Println(pq.Peek().Value().name) // deploy
+
+	pq.Update(logs, job{"logs", 10})
+	fmt.Println(pq.Pop().Value().name) // logs
+	fmt.Println(pq.Pop().Value().name) // deploy
+	fmt.Println(pq.Len())              // 1
+
+	// Output:
+	// deploy
+	// logs
+	// deploy
+	// 1
+}
+
+func ExampleHeapify() {
+	pq := Heapify([]int{5, 2, 8, 1}, func(a, b int) bool { return a < b })
+	for pq.Len() > 0 {
+		fmt.Println(pq.Pop().Value())
+	}
+	fmt.Println(pq.Pop()) // nothing
+
+	// Output:
+	// 1
+	// 2
+	// 5
+	// 8
+	// goption.Nil[int]()
+}
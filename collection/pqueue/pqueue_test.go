@@ -0,0 +1,191 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pqueue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/gslice"
+	"github.com/bytedance/gg/internal/assert"
+	"github.com/bytedance/gg/internal/fastrand"
+)
+
+func popAll[T any](pq *PriorityQueue[T]) []T {
+	var res []T
+	for pq.Len() > 0 {
+		res = append(res, pq.Pop().Value())
+	}
+	return res
+}
+
+func TestNilQueue(t *testing.T) {
+	var pq *PriorityQueue[int]
+	assert.Equal(t, 0, pq.Len())
+	assert.Equal(t, goption.Nil[int](), pq.Peek())
+	assert.Equal(t, goption.Nil[int](), pq.Pop())
+	assert.False(t, pq.Update(nil, 1))
+	assert.False(t, pq.Remove(nil))
+	assert.Equal(t, []int{}, pq.ToSlice())
+	pq.Clear()
+}
+
+func TestPushPop(t *testing.T) {
+	pq := NewOrdered[int]()
+	assert.Equal(t, goption.Nil[int](), pq.Pop())
+	for _, v := range []int{5, 3, 8, 1, 9, 1, 4} {
+		pq.Push(v)
+	}
+	assert.Equal(t, 7, pq.Len())
+	assert.Equal(t, goption.OK(1), pq.Peek())
+	assert.Equal(t, 7, pq.Len())
+	assert.Equal(t, []int{1, 1, 3, 4, 5, 8, 9}, gslice.SortClone(pq.ToSlice()))
+	assert.Equal(t, []int{1, 1, 3, 4, 5, 8, 9}, popAll(pq))
+	assert.Equal(t, goption.Nil[int](), pq.Peek())
+
+	// Max queue.
+	max := New(func(a, b string) bool { return a > b })
+	max.Push("b")
+	max.Push("c")
+	max.Push("a")
+	assert.Equal(t, []string{"c", "b", "a"}, popAll(max))
+}
+
+func TestHeapify(t *testing.T) {
+	s := []int{9, 2, 7, 4, 5, 6, 3, 8, 1}
+	pq := Heapify(s, func(a, b int) bool { return a < b })
+	assert.Equal(t, []int{9, 2, 7, 4, 5, 6, 3, 8, 1}, s)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, popAll(pq))
+
+	pq = Heapify([]int{}, func(a, b int) bool { return a < b })
+	assert.Equal(t, 0, pq.Len())
+	pq.Push(1)
+	assert.Equal(t, goption.OK(1), pq.Pop())
+
+	for i := 0; i < 100; i++ {
+		s := make([]int, fastrand.Intn(100))
+		for j := range s {
+			s[j] = fastrand.Intn(50)
+		}
+		pq := Heapify(s, func(a, b int) bool { return a < b })
+		assert.Equal(t, gslice.SortClone(s), gslice.Of(popAll(pq)...))
+	}
+}
+
+type task struct {
+	name     string
+	priority int
+}
+
+func TestHandle(t *testing.T) {
+	pq := New(func(a, b task) bool { return a.priority < b.priority })
+	a := pq.Push(task{"a", 3})
+	b := pq.Push(task{"b", 2})
+	c := pq.Push(task{"c", 1})
+	d := pq.Push(task{"d", 4})
+	assert.Equal(t, "a", a.Value().name)
+	assert.True(t, a.Valid())
+
+	// Increase priority.
+	assert.True(t, pq.Update(d, task{"d", 0}))
+	assert.Equal(t, "d", pq.Peek().Value().name)
+	// Decrease priority.
+	assert.True(t, pq.Update(d, task{"d", 5}))
+	assert.Equal(t, "c", pq.Peek().Value().name)
+
+	assert.True(t, pq.Remove(b))
+	assert.False(t, b.Valid())
+	assert.False(t, pq.Remove(b))
+	assert.False(t, pq.Update(b, task{"b", 0}))
+	assert.Equal(t, 3, pq.Len())
+
+	// Handle from another queue.
+	other := New(func(a, b task) bool { return a.priority < b.priority })
+	assert.False(t, other.Remove(a))
+	assert.False(t, other.Update(a, task{}))
+
+	assert.Equal(t, "c", pq.Pop().Value().name)
+	assert.False(t, c.Valid())
+	assert.True(t, pq.Remove(d)) // remove the last one
+	assert.Equal(t, "a", pq.Pop().Value().name)
+	assert.Equal(t, 0, pq.Len())
+
+	e := pq.Push(task{"e", 1})
+	pq.Clear()
+	assert.False(t, e.Valid())
+	assert.Equal(t, 0, pq.Len())
+}
+
+func TestHandleRandom(t *testing.T) {
+	pq := NewOrdered[int]()
+	handles := map[*Handle[int]]struct{}{}
+	for i := 0; i < 1000; i++ {
+		handles[pq.Push(fastrand.Intn(1000))] = struct{}{}
+	}
+	for h := range handles {
+		switch fastrand.Intn(3) {
+		case 0:
+			assert.True(t, pq.Remove(h))
+			delete(handles, h)
+		case 1:
+			assert.True(t, pq.Update(h, fastrand.Intn(1000)))
+		}
+	}
+	var expected []int
+	for h := range handles {
+		expected = append(expected, h.Value())
+	}
+	gslice.Sort(expected)
+	assert.Equal(t, expected, popAll(pq))
+}
+
+func TestSync(t *testing.T) {
+	q := NewSync(NewOrdered[int]())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				h := q.Push(i*100 + j)
+				if j%2 == 0 {
+					q.Update(h, -(i*100 + j))
+				}
+				if j%10 == 0 {
+					q.Remove(h)
+					assert.False(t, q.Valid(h))
+					assert.Equal(t, goption.Nil[int](), q.Value(h))
+				} else {
+					assert.True(t, q.Valid(h))
+					assert.True(t, q.Value(h).IsOK())
+				}
+				q.Peek()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 720, q.Len())
+	assert.Equal(t, 720, len(q.ToSlice()))
+
+	prev := q.Pop().Value()
+	for q.Len() > 0 {
+		cur := q.Pop().Value()
+		assert.True(t, prev <= cur)
+		prev = cur
+	}
+	q.Clear()
+	assert.Equal(t, goption.Nil[int](), q.Pop())
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// BloomFilter is a space-efficient probabilistic set.
+//
+// [BloomFilter.Contains] may return true for a value which was never added
+// (false positive), but never returns false for an added value.
+//
+// 💡 NOTE: BloomFilter is not concurrent-safe, use [SyncBloomFilter] instead.
+type BloomFilter[T comparable] struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint32 // number of hash functions
+}
+
+// NewBloomFilter creates a bloom filter which is expected to hold n values
+// with false positive rate fpRate.
+//
+// 💡 NOTE: It panics if n is not positive or fpRate is not in (0, 1).
+func NewBloomFilter[T comparable](n int, fpRate float64) *BloomFilter[T] {
+	if n <= 0 {
+		panic(fmt.Errorf("n must be positive: %d", n))
+	}
+	if !(fpRate > 0 && fpRate < 1) {
+		panic(fmt.Errorf("fpRate must be in (0, 1): %v", fpRate))
+	}
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return newBloomFilter[T](uint64(m), uint32(math.Max(k, 1)))
+}
+
+func newBloomFilter[T comparable](m uint64, k uint32) *BloomFilter[T] {
+	return &BloomFilter[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Cap returns the number of bits of the filter.
+func (b *BloomFilter[T]) Cap() int {
+	return int(b.m)
+}
+
+// Hashes returns the number of hash functions of the filter.
+func (b *BloomFilter[T]) Hashes() int {
+	return int(b.k)
+}
+
+// Add adds v to the filter.
+func (b *BloomFilter[T]) Add(v T) {
+	h1, h2 := hash2(v)
+	for i := uint32(0); i < b.k; i++ {
+		pos := (h1 + uint64(i)*h2) % b.m
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// Contains returns whether v may be in the filter.
+//
+// False positive is possible, false negative is impossible.
+func (b *BloomFilter[T]) Contains(v T) bool {
+	h1, h2 := hash2(v)
+	for i := uint32(0); i < b.k; i++ {
+		pos := (h1 + uint64(i)*h2) % b.m
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimateCount returns the estimated number of distinct values added to
+// the filter.
+func (b *BloomFilter[T]) EstimateCount() int {
+	x := 0
+	for _, w := range b.bits {
+		x += bits.OnesCount64(w)
+	}
+	if uint64(x) >= b.m {
+		return math.MaxInt
+	}
+	m, k := float64(b.m), float64(b.k)
+	return int(math.Round(-m / k * math.Log(1-float64(x)/m)))
+}
+
+// Merge updates the filter with the union of itself and filter other.
+//
+// It returns [ErrIncompatible] if filters have different parameters.
+func (b *BloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+	for i, w := range other.bits {
+		b.bits[i] |= w
+	}
+	return nil
+}
+
+// Clear removes all values from the filter.
+func (b *BloomFilter[T]) Clear() {
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+}
+
+// Clone returns a copy of the filter.
+func (b *BloomFilter[T]) Clone() *BloomFilter[T] {
+	c := newBloomFilter[T](b.m, b.k)
+	copy(c.bits, b.bits)
+	return c
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 2+4+8+len(b.bits)*8)
+	data[0], data[1] = magicBloom, version
+	binary.LittleEndian.PutUint32(data[2:], b.k)
+	binary.LittleEndian.PutUint64(data[6:], b.m)
+	putUint64s(data[14:], b.bits)
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+//
+// 💡 NOTE: Always override original parameters and values.
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	data, err := header(data, magicBloom, 4+8)
+	if err != nil {
+		return err
+	}
+	k := binary.LittleEndian.Uint32(data)
+	m := binary.LittleEndian.Uint64(data[4:])
+	data = data[12:]
+	if k == 0 || m == 0 || m > uint64(len(data))*8 || uint64(len(data)) != (m+63)/64*8 {
+		return ErrInvalidData
+	}
+	b.k, b.m, b.bits = k, m, readUint64s(data)
+	return nil
+}
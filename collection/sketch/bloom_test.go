@@ -0,0 +1,120 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestBloomFilter(t *testing.T) {
+	assert.Panic(t, func() { NewBloomFilter[int](0, 0.01) })
+	assert.Panic(t, func() { NewBloomFilter[int](10, 0) })
+	assert.Panic(t, func() { NewBloomFilter[int](10, 1) })
+
+	const n = 10000
+	b := NewBloomFilter[string](n, 0.01)
+	assert.Equal(t, 95851, b.Cap())
+	assert.Equal(t, 7, b.Hashes())
+	for i := 0; i < n; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	// No false negative.
+	for i := 0; i < n; i++ {
+		assert.True(t, b.Contains(strconv.Itoa(i)))
+	}
+	fp := 0
+	for i := n; i < 2*n; i++ {
+		if b.Contains(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	assert.True(t, fp < n*2/100)
+	est := b.EstimateCount()
+	assert.True(t, est > n*95/100 && est < n*105/100)
+
+	b.Clear()
+	assert.False(t, b.Contains("1"))
+	assert.Equal(t, 0, b.EstimateCount())
+
+	full := NewBloomFilter[int](1, 0.5)
+	for i := range full.bits {
+		full.bits[i] = ^uint64(0)
+	}
+	assert.True(t, full.EstimateCount() > 1<<60)
+}
+
+func TestBloomFilterCompositeValue(t *testing.T) {
+	type point struct{ X, Y float64 }
+
+	b := NewBloomFilter[point](100, 0.01)
+	b.Add(point{0, 1})
+	assert.True(t, b.Contains(point{math.Copysign(0, -1), 1}))
+
+	// Pointers are identified by address.
+	pb := NewBloomFilter[*point](100, 0.01)
+	p := &point{1, 2}
+	pb.Add(p)
+	p.X = 3
+	assert.True(t, pb.Contains(p))
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	a := NewBloomFilter[int](1000, 0.01)
+	b := NewBloomFilter[int](1000, 0.01)
+	for i := 0; i < 500; i++ {
+		a.Add(i)
+		b.Add(i + 500)
+	}
+	c := a.Clone()
+	assert.Nil(t, c.Merge(b))
+	for i := 0; i < 1000; i++ {
+		assert.True(t, c.Contains(i))
+	}
+	assert.False(t, a.Contains(999) && a.Contains(998) && a.Contains(997))
+	assert.True(t, errors.Is(a.Merge(NewBloomFilter[int](2000, 0.01)), ErrIncompatible))
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	a := NewBloomFilter[string](100, 0.01)
+	a.Add("foo")
+	data, err := a.MarshalBinary()
+	assert.Nil(t, err)
+
+	var b BloomFilter[string]
+	assert.Nil(t, b.UnmarshalBinary(data))
+	assert.True(t, b.Contains("foo"))
+	assert.Equal(t, a.Cap(), b.Cap())
+	assert.Equal(t, a.Hashes(), b.Hashes())
+	assert.Nil(t, b.Merge(a))
+
+	assert.True(t, errors.Is(b.UnmarshalBinary(nil), ErrInvalidData))
+	assert.True(t, errors.Is(b.UnmarshalBinary(data[:len(data)-1]), ErrInvalidData))
+	bad := append([]byte{}, data...)
+	bad[1] = 2
+	assert.True(t, errors.Is(b.UnmarshalBinary(bad), ErrInvalidData))
+	bad[0], bad[1] = magicHLL, version
+	assert.True(t, errors.Is(b.UnmarshalBinary(bad), ErrInvalidData))
+	bad = append([]byte{}, data...)
+	for i := 6; i < 14; i++ {
+		bad[i] = 0xff // huge m
+	}
+	assert.True(t, errors.Is(b.UnmarshalBinary(bad), ErrInvalidData))
+	assert.True(t, b.Contains("foo"))
+}
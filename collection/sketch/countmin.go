@@ -0,0 +1,157 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CountMinSketch estimates frequencies of values in a stream.
+//
+// With probability at least 1-delta, the estimated count of a value exceeds
+// its real count by at most epsilon*N, where N is the total count of all
+// values. The estimation is never less than the real count.
+//
+// 💡 NOTE: CountMinSketch is not concurrent-safe, use [SyncCountMinSketch] instead.
+type CountMinSketch[T comparable] struct {
+	counters []uint64 // depth rows of width counters
+	depth    uint32
+	width    uint32
+	total    uint64
+}
+
+// NewCountMinSketch creates a count-min sketch with error factor epsilon
+// and failure probability delta.
+//
+// 💡 NOTE: It panics if epsilon or delta is not in (0, 1).
+func NewCountMinSketch[T comparable](epsilon, delta float64) *CountMinSketch[T] {
+	if !(epsilon > 0 && epsilon < 1) {
+		panic(fmt.Errorf("epsilon must be in (0, 1): %v", epsilon))
+	}
+	if !(delta > 0 && delta < 1) {
+		panic(fmt.Errorf("delta must be in (0, 1): %v", delta))
+	}
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Ceil(math.Log(1 / delta))
+	return newCountMinSketch[T](uint32(depth), uint32(width))
+}
+
+func newCountMinSketch[T comparable](depth, width uint32) *CountMinSketch[T] {
+	return &CountMinSketch[T]{
+		counters: make([]uint64, int(depth)*int(width)),
+		depth:    depth,
+		width:    width,
+	}
+}
+
+// Depth returns the number of rows (hash functions) of the sketch.
+func (c *CountMinSketch[T]) Depth() int {
+	return int(c.depth)
+}
+
+// Width returns the number of counters per row of the sketch.
+func (c *CountMinSketch[T]) Width() int {
+	return int(c.width)
+}
+
+// Total returns the total count of all added values.
+func (c *CountMinSketch[T]) Total() uint64 {
+	return c.total
+}
+
+// Add increases the count of v by n.
+func (c *CountMinSketch[T]) Add(v T, n uint64) {
+	h1, h2 := hash2(v)
+	for i := uint32(0); i < c.depth; i++ {
+		pos := uint64(i)*uint64(c.width) + (h1+uint64(i)*h2)%uint64(c.width)
+		c.counters[pos] += n
+	}
+	c.total += n
+}
+
+// Count returns the estimated count of v.
+func (c *CountMinSketch[T]) Count(v T) uint64 {
+	h1, h2 := hash2(v)
+	res := uint64(math.MaxUint64)
+	for i := uint32(0); i < c.depth; i++ {
+		pos := uint64(i)*uint64(c.width) + (h1+uint64(i)*h2)%uint64(c.width)
+		if c.counters[pos] < res {
+			res = c.counters[pos]
+		}
+	}
+	return res
+}
+
+// Merge adds counts of sketch other into the sketch.
+//
+// It returns [ErrIncompatible] if sketches have different parameters.
+func (c *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if c.depth != other.depth || c.width != other.width {
+		return ErrIncompatible
+	}
+	for i, n := range other.counters {
+		c.counters[i] += n
+	}
+	c.total += other.total
+	return nil
+}
+
+// Clear resets all counts to zero.
+func (c *CountMinSketch[T]) Clear() {
+	for i := range c.counters {
+		c.counters[i] = 0
+	}
+	c.total = 0
+}
+
+// Clone returns a copy of the sketch.
+func (c *CountMinSketch[T]) Clone() *CountMinSketch[T] {
+	res := newCountMinSketch[T](c.depth, c.width)
+	copy(res.counters, c.counters)
+	res.total = c.total
+	return res
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (c *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 2+4+4+8+len(c.counters)*8)
+	data[0], data[1] = magicCountMin, version
+	binary.LittleEndian.PutUint32(data[2:], c.depth)
+	binary.LittleEndian.PutUint32(data[6:], c.width)
+	binary.LittleEndian.PutUint64(data[10:], c.total)
+	putUint64s(data[18:], c.counters)
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+//
+// 💡 NOTE: Always override original parameters and counts.
+func (c *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	data, err := header(data, magicCountMin, 4+4+8)
+	if err != nil {
+		return err
+	}
+	depth := binary.LittleEndian.Uint32(data)
+	width := binary.LittleEndian.Uint32(data[4:])
+	total := binary.LittleEndian.Uint64(data[8:])
+	data = data[16:]
+	if depth == 0 || width == 0 || uint64(len(data)) != uint64(depth)*uint64(width)*8 {
+		return ErrInvalidData
+	}
+	c.depth, c.width, c.total, c.counters = depth, width, total, readUint64s(data)
+	return nil
+}
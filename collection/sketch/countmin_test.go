@@ -0,0 +1,90 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	assert.Panic(t, func() { NewCountMinSketch[int](0, 0.01) })
+	assert.Panic(t, func() { NewCountMinSketch[int](0.01, 1) })
+
+	c := NewCountMinSketch[int](0.001, 0.01)
+	assert.Equal(t, 2719, c.Width())
+	assert.Equal(t, 5, c.Depth())
+
+	r := rand.New(rand.NewSource(1))
+	real := map[int]uint64{}
+	for i := 0; i < 100000; i++ {
+		// Skewed distribution.
+		v := int(r.ExpFloat64() * 100)
+		n := uint64(r.Intn(3) + 1)
+		c.Add(v, n)
+		real[v] += n
+	}
+	var total uint64
+	for _, n := range real {
+		total += n
+	}
+	assert.Equal(t, total, c.Total())
+	bound := uint64(0.001 * float64(total))
+	for v, n := range real {
+		est := c.Count(v)
+		assert.True(t, est >= n)
+		assert.True(t, est <= n+bound)
+	}
+	assert.True(t, c.Count(-1) <= bound)
+
+	c.Clear()
+	assert.Equal(t, uint64(0), c.Total())
+	assert.Equal(t, uint64(0), c.Count(0))
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a := NewCountMinSketch[string](0.01, 0.01)
+	b := NewCountMinSketch[string](0.01, 0.01)
+	a.Add("x", 3)
+	b.Add("x", 4)
+	b.Add("y", 1)
+	c := a.Clone()
+	assert.Nil(t, c.Merge(b))
+	assert.Equal(t, uint64(7), c.Count("x"))
+	assert.Equal(t, uint64(8), c.Total())
+	assert.Equal(t, uint64(3), a.Count("x"))
+	assert.True(t, errors.Is(a.Merge(NewCountMinSketch[string](0.1, 0.01)), ErrIncompatible))
+}
+
+func TestCountMinSketchBinary(t *testing.T) {
+	a := NewCountMinSketch[string](0.01, 0.01)
+	a.Add("x", 3)
+	data, err := a.MarshalBinary()
+	assert.Nil(t, err)
+
+	var b CountMinSketch[string]
+	assert.Nil(t, b.UnmarshalBinary(data))
+	assert.Equal(t, uint64(3), b.Count("x"))
+	assert.Equal(t, uint64(3), b.Total())
+	assert.Equal(t, a.Width(), b.Width())
+	assert.Equal(t, a.Depth(), b.Depth())
+
+	assert.True(t, errors.Is(b.UnmarshalBinary(data[:10]), ErrInvalidData))
+	assert.True(t, errors.Is(b.UnmarshalBinary(data[:len(data)-8]), ErrInvalidData))
+	assert.Equal(t, uint64(3), b.Count("x"))
+}
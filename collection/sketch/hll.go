@@ -0,0 +1,158 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+const (
+	minPrecision = 4
+	maxPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct values in a stream.
+//
+// With precision p, it uses 2^p bytes, and the standard error of the
+// estimation is about 1.04/sqrt(2^p).
+//
+// 💡 NOTE: HyperLogLog is not concurrent-safe, use [SyncHyperLogLog] instead.
+type HyperLogLog[T comparable] struct {
+	registers []uint8
+	p         uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog whose standard error is at most
+// stdErr, the precision is clamped to [4, 18].
+//
+// 💡 NOTE: It panics if stdErr is not in (0, 1).
+func NewHyperLogLog[T comparable](stdErr float64) *HyperLogLog[T] {
+	if !(stdErr > 0 && stdErr < 1) {
+		panic(fmt.Errorf("stdErr must be in (0, 1): %v", stdErr))
+	}
+	p := math.Ceil(math.Log2(math.Pow(1.04/stdErr, 2)))
+	p = math.Max(minPrecision, math.Min(maxPrecision, p))
+	return NewHyperLogLogWithPrecision[T](int(p))
+}
+
+// NewHyperLogLogWithPrecision creates a HyperLogLog with 2^p registers.
+//
+// 💡 NOTE: It panics if p is not in [4, 18].
+func NewHyperLogLogWithPrecision[T comparable](p int) *HyperLogLog[T] {
+	if p < minPrecision || p > maxPrecision {
+		panic(fmt.Errorf("precision must be in [%d, %d]: %d", minPrecision, maxPrecision, p))
+	}
+	return &HyperLogLog[T]{registers: make([]uint8, 1<<p), p: uint8(p)}
+}
+
+// Precision returns the precision of the HyperLogLog.
+func (h *HyperLogLog[T]) Precision() int {
+	return int(h.p)
+}
+
+// Add adds v to the HyperLogLog.
+func (h *HyperLogLog[T]) Add(v T) {
+	x := hashing.Sum64(v, 0)
+	idx := x >> (64 - h.p)
+	// The guard bit bounds rank to 64-p+1.
+	rank := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Count returns the estimated number of distinct values.
+func (h *HyperLogLog[T]) Count() uint64 {
+	m := float64(len(h.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros != 0 {
+		// Small range correction: linear counting.
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(est))
+}
+
+// Merge updates the HyperLogLog with the union of itself and other.
+//
+// It returns [ErrIncompatible] if they have different precisions.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.p != other.p {
+		return ErrIncompatible
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Clear removes all values from the HyperLogLog.
+func (h *HyperLogLog[T]) Clear() {
+	for i := range h.registers {
+		h.registers[i] = 0
+	}
+}
+
+// Clone returns a copy of the HyperLogLog.
+func (h *HyperLogLog[T]) Clone() *HyperLogLog[T] {
+	return &HyperLogLog[T]{registers: append([]uint8{}, h.registers...), p: h.p}
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 3, 3+len(h.registers))
+	data[0], data[1], data[2] = magicHLL, version, h.p
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+//
+// 💡 NOTE: Always override original precision and values.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	data, err := header(data, magicHLL, 1)
+	if err != nil {
+		return err
+	}
+	p := data[0]
+	data = data[1:]
+	if p < minPrecision || p > maxPrecision || len(data) != 1<<p {
+		return ErrInvalidData
+	}
+	h.p, h.registers = p, append([]uint8{}, data...)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	assert.Panic(t, func() { NewHyperLogLog[int](0) })
+	assert.Panic(t, func() { NewHyperLogLogWithPrecision[int](3) })
+	assert.Panic(t, func() { NewHyperLogLogWithPrecision[int](19) })
+	assert.Equal(t, 14, NewHyperLogLog[int](0.01).Precision())
+	assert.Equal(t, 4, NewHyperLogLog[int](0.9).Precision())
+	assert.Equal(t, 18, NewHyperLogLog[int](0.0001).Precision())
+
+	for _, p := range []int{4, 5, 6, 10, 14} {
+		h := NewHyperLogLogWithPrecision[int](p)
+		assert.Equal(t, uint64(0), h.Count())
+		stdErr := 1.04 / math.Sqrt(float64(int(1)<<p))
+		for _, n := range []int{10, 1000, 100000} {
+			h.Clear()
+			for i := 0; i < n; i++ {
+				h.Add(i)
+				h.Add(i) // duplicated
+			}
+			est := float64(h.Count())
+			// Allow 4 standard errors.
+			assert.True(t, math.Abs(est-float64(n)) <= 4*stdErr*float64(n)+1)
+		}
+	}
+}
+
+func TestHyperLogLogPointer(t *testing.T) {
+	type point struct{ X float64 }
+	h := NewHyperLogLogWithPrecision[*point](10)
+	p := &point{1}
+	h.Add(p)
+	p.X = 2
+	h.Add(p)
+	assert.Equal(t, uint64(1), h.Count())
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog[string](0.01)
+	b := NewHyperLogLog[string](0.01)
+	for i := 0; i < 50000; i++ {
+		a.Add("a" + string(rune(i)))
+		b.Add("b" + string(rune(i)))
+		b.Add("a" + string(rune(i)))
+	}
+	c := a.Clone()
+	assert.Nil(t, c.Merge(b))
+	est := float64(c.Count())
+	assert.True(t, math.Abs(est-100000) < 3000)
+	assert.True(t, float64(a.Count()) < 52000)
+	assert.True(t, errors.Is(a.Merge(NewHyperLogLogWithPrecision[string](4)), ErrIncompatible))
+}
+
+func TestHyperLogLogBinary(t *testing.T) {
+	a := NewHyperLogLogWithPrecision[int](8)
+	for i := 0; i < 1000; i++ {
+		a.Add(i)
+	}
+	data, err := a.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, 3+256, len(data))
+
+	var b HyperLogLog[int]
+	assert.Nil(t, b.UnmarshalBinary(data))
+	assert.Equal(t, a.Count(), b.Count())
+	assert.Equal(t, 8, b.Precision())
+
+	assert.True(t, errors.Is(b.UnmarshalBinary(data[:100]), ErrInvalidData))
+	bad := append([]byte{}, data...)
+	bad[2] = 30
+	assert.True(t, errors.Is(b.UnmarshalBinary(bad), ErrInvalidData))
+	assert.Equal(t, a.Count(), b.Count())
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sketch provides probabilistic data structures for high-volume
+// streams, which trade exactness for small and fixed memory.
+//
+// # Structures
+//
+//   - [BloomFilter]: set membership with false positives but no false negatives
+//   - [CountMinSketch]: frequency estimation which never underestimates
+//   - [HyperLogLog]: cardinality (number of distinct values) estimation
+//
+// Each of them has a concurrent-safe variant: [SyncBloomFilter],
+// [SyncCountMinSketch] and [SyncHyperLogLog].
+//
+// # Distributed aggregation
+//
+// Values are hashed by a deterministic hash function which is stable across
+// processes (unlike [hash/maphash], whose seed is random per process), so
+// sketches built with the same parameters on different machines can be
+// serialized by MarshalBinary, and combined by Merge.
+//
+// 💡 NOTE: Pointers and channels are hashed by address, sketches of values
+// containing them are meaningful only in the process which built them.
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/gg/internal/hashing"
+)
+
+var (
+	// ErrIncompatible is returned when merging sketches created with
+	// different parameters.
+	ErrIncompatible = errors.New("sketch: incompatible parameters")
+	// ErrInvalidData is returned when unmarshaling malformed binary data.
+	ErrInvalidData = errors.New("sketch: invalid binary data")
+)
+
+// Binary format version, the first byte of binary data is a magic byte
+// identifying type of sketch, the second byte is the version.
+const version = 1
+
+const (
+	magicBloom    = 'B'
+	magicCountMin = 'C'
+	magicHLL      = 'H'
+)
+
+// hash2 returns two independent hashes of v for double hashing:
+// the i-th hash is h1 + i*h2.
+func hash2[T comparable](v T) (uint64, uint64) {
+	h1 := hashing.Sum64(v, 0)
+	h2 := hashing.Mix(h1^0x9e3779b97f4a7c15) | 1 // odd, so it never degenerates
+	return h1, h2
+}
+
+// header checks the magic and version of binary data, returns the payload.
+func header(data []byte, magic byte, size int) ([]byte, error) {
+	if len(data) < 2+size || data[0] != magic {
+		return nil, ErrInvalidData
+	}
+	if data[1] != version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, data[1])
+	}
+	return data[2:], nil
+}
+
+// putUint64s encodes s into b in little-endian order.
+func putUint64s(b []byte, s []uint64) {
+	for i, x := range s {
+		binary.LittleEndian.PutUint64(b[i*8:], x)
+	}
+}
+
+// readUint64s decodes b in little-endian order.
+func readUint64s(b []byte) []uint64 {
+	s := make([]uint64, len(b)/8)
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint64(b[i*8:])
+	}
+	return s
+}
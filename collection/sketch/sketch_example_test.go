@@ -0,0 +1,60 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"fmt"
+)
+
+func Example() {
+	seen := NewBloomFilter[string](1000, 0.01)
+	freq := NewCountMinSketch[string](0.001, 0.01)
+	uv := NewHyperLogLog[string](0.01)
+
+	for _, user := range []string{"alice", "bob", "alice", "carol", "alice"} {
+		seen.Add(user)
+		freq.Add(user, 1)
+		uv.Add(user)
+	}
+	fmt.Println(seen.Contains("bob"), seen.Contains("dave"))
+	fmt.Println(freq.Count("alice"))
+	fmt.Println(uv.Count())
+
+	// Output:
+	// true false
+	// 3
+	// 3
+}
+
+func ExampleHyperLogLog_Merge() {
+	// Sketches built on different machines.
+	a := NewHyperLogLog[int](0.01)
+	b := NewHyperLogLog[int](0.01)
+	for i := 0; i < 100; i++ {
+		a.Add(i)
+		b.Add(i + 50)
+	}
+
+	// Ship b to the aggregator.
+	data, _ := b.MarshalBinary()
+	var received HyperLogLog[int]
+	_ = received.UnmarshalBinary(data)
+
+	_ = a.Merge(&received)
+	fmt.Println(a.Count())
+
+	// Output:
+	// 150
+}
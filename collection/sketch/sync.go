@@ -0,0 +1,231 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"sync"
+)
+
+// SyncBloomFilter is a concurrent-safe wrapper of [BloomFilter],
+// all operations are protected by a read-write mutex.
+type SyncBloomFilter[T comparable] struct {
+	mu sync.RWMutex
+	b  *BloomFilter[T]
+}
+
+// NewSyncBloomFilter wraps filter b into a concurrent-safe one.
+//
+// 💡 NOTE: b must not be used directly after wrapping.
+func NewSyncBloomFilter[T comparable](b *BloomFilter[T]) *SyncBloomFilter[T] {
+	return &SyncBloomFilter[T]{b: b}
+}
+
+// Add wraps [BloomFilter.Add].
+func (s *SyncBloomFilter[T]) Add(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.b.Add(v)
+}
+
+// Contains wraps [BloomFilter.Contains].
+func (s *SyncBloomFilter[T]) Contains(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.b.Contains(v)
+}
+
+// EstimateCount wraps [BloomFilter.EstimateCount].
+func (s *SyncBloomFilter[T]) EstimateCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.b.EstimateCount()
+}
+
+// Merge wraps [BloomFilter.Merge].
+func (s *SyncBloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Merge(other)
+}
+
+// Clear wraps [BloomFilter.Clear].
+func (s *SyncBloomFilter[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.b.Clear()
+}
+
+// Snapshot returns a copy of the underlying filter.
+func (s *SyncBloomFilter[T]) Snapshot() *BloomFilter[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.b.Clone()
+}
+
+// MarshalBinary wraps [BloomFilter.MarshalBinary].
+func (s *SyncBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.b.MarshalBinary()
+}
+
+// UnmarshalBinary wraps [BloomFilter.UnmarshalBinary].
+func (s *SyncBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.b == nil {
+		s.b = &BloomFilter[T]{}
+	}
+	return s.b.UnmarshalBinary(data)
+}
+
+// SyncCountMinSketch is a concurrent-safe wrapper of [CountMinSketch],
+// all operations are protected by a read-write mutex.
+type SyncCountMinSketch[T comparable] struct {
+	mu sync.RWMutex
+	c  *CountMinSketch[T]
+}
+
+// NewSyncCountMinSketch wraps sketch c into a concurrent-safe one.
+//
+// 💡 NOTE: c must not be used directly after wrapping.
+func NewSyncCountMinSketch[T comparable](c *CountMinSketch[T]) *SyncCountMinSketch[T] {
+	return &SyncCountMinSketch[T]{c: c}
+}
+
+// Add wraps [CountMinSketch.Add].
+func (s *SyncCountMinSketch[T]) Add(v T, n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Add(v, n)
+}
+
+// Count wraps [CountMinSketch.Count].
+func (s *SyncCountMinSketch[T]) Count(v T) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.c.Count(v)
+}
+
+// Total wraps [CountMinSketch.Total].
+func (s *SyncCountMinSketch[T]) Total() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.c.Total()
+}
+
+// Merge wraps [CountMinSketch.Merge].
+func (s *SyncCountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Merge(other)
+}
+
+// Clear wraps [CountMinSketch.Clear].
+func (s *SyncCountMinSketch[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Clear()
+}
+
+// Snapshot returns a copy of the underlying sketch.
+func (s *SyncCountMinSketch[T]) Snapshot() *CountMinSketch[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.c.Clone()
+}
+
+// MarshalBinary wraps [CountMinSketch.MarshalBinary].
+func (s *SyncCountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.c.MarshalBinary()
+}
+
+// UnmarshalBinary wraps [CountMinSketch.UnmarshalBinary].
+func (s *SyncCountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c == nil {
+		s.c = &CountMinSketch[T]{}
+	}
+	return s.c.UnmarshalBinary(data)
+}
+
+// SyncHyperLogLog is a concurrent-safe wrapper of [HyperLogLog],
+// all operations are protected by a read-write mutex.
+type SyncHyperLogLog[T comparable] struct {
+	mu sync.RWMutex
+	h  *HyperLogLog[T]
+}
+
+// NewSyncHyperLogLog wraps HyperLogLog h into a concurrent-safe one.
+//
+// 💡 NOTE: h must not be used directly after wrapping.
+func NewSyncHyperLogLog[T comparable](h *HyperLogLog[T]) *SyncHyperLogLog[T] {
+	return &SyncHyperLogLog[T]{h: h}
+}
+
+// Add wraps [HyperLogLog.Add].
+func (s *SyncHyperLogLog[T]) Add(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Add(v)
+}
+
+// Count wraps [HyperLogLog.Count].
+func (s *SyncHyperLogLog[T]) Count() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.Count()
+}
+
+// Merge wraps [HyperLogLog.Merge].
+func (s *SyncHyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Merge(other)
+}
+
+// Clear wraps [HyperLogLog.Clear].
+func (s *SyncHyperLogLog[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Clear()
+}
+
+// Snapshot returns a copy of the underlying HyperLogLog.
+func (s *SyncHyperLogLog[T]) Snapshot() *HyperLogLog[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.Clone()
+}
+
+// MarshalBinary wraps [HyperLogLog.MarshalBinary].
+func (s *SyncHyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.MarshalBinary()
+}
+
+// UnmarshalBinary wraps [HyperLogLog.UnmarshalBinary].
+func (s *SyncHyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.h == nil {
+		s.h = &HyperLogLog[T]{}
+	}
+	return s.h.UnmarshalBinary(data)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestSync(t *testing.T) {
+	b := NewSyncBloomFilter(NewBloomFilter[int](10000, 0.01))
+	c := NewSyncCountMinSketch(NewCountMinSketch[int](0.01, 0.01))
+	h := NewSyncHyperLogLog(NewHyperLogLog[int](0.01))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				v := g*1000 + i
+				b.Add(v)
+				c.Add(v%10, 1)
+				h.Add(v)
+				b.Contains(v)
+				c.Count(v)
+				if i%100 == 0 {
+					h.Count()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for v := 0; v < 8000; v++ {
+		assert.True(t, b.Contains(v))
+	}
+	assert.True(t, b.EstimateCount() > 7800)
+	assert.Equal(t, uint64(8000), c.Total())
+	assert.True(t, c.Count(3) >= 800)
+	assert.True(t, h.Count() > 7800 && h.Count() < 8200)
+
+	// Merge and serialization.
+	assert.Nil(t, b.Merge(b.Snapshot()))
+	assert.Nil(t, c.Merge(c.Snapshot()))
+	assert.Nil(t, h.Merge(h.Snapshot()))
+	assert.Equal(t, uint64(16000), c.Total())
+
+	var b2 SyncBloomFilter[int]
+	data, err := b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Nil(t, b2.UnmarshalBinary(data))
+	assert.True(t, b2.Contains(100))
+
+	var c2 SyncCountMinSketch[int]
+	data, err = c.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Nil(t, c2.UnmarshalBinary(data))
+	assert.Equal(t, uint64(16000), c2.Total())
+
+	var h2 SyncHyperLogLog[int]
+	data, err = h.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Nil(t, h2.UnmarshalBinary(data))
+	assert.Equal(t, h.Count(), h2.Count())
+
+	b.Clear()
+	c.Clear()
+	h.Clear()
+	assert.False(t, b.Contains(1))
+	assert.Equal(t, uint64(0), c.Total())
+	assert.Equal(t, uint64(0), h.Count())
+}
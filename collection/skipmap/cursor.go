@@ -0,0 +1,133 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+)
+
+// navigator is implemented by [OrderedMap], [OrderedMapDesc] and [FuncMap].
+type navigator[keyT any, valueT any] interface {
+	First() goption.O[tuple.T2[keyT, valueT]]
+	Last() goption.O[tuple.T2[keyT, valueT]]
+	Ceiling(key keyT) goption.O[tuple.T2[keyT, valueT]]
+	Higher(key keyT) goption.O[tuple.T2[keyT, valueT]]
+	Lower(key keyT) goption.O[tuple.T2[keyT, valueT]]
+}
+
+type cursorPos int
+
+const (
+	beforeFirst cursorPos = iota
+	atEntry
+	afterLast
+)
+
+// Cursor is a bidirectional cursor over entries of a skipmap.
+//
+// A cursor remembers the key of current entry instead of the node, so it
+// can be resumed from any key by [Cursor.Seek], and each move locates the
+// adjacent entry by the index of skip list in O(log n).
+//
+// Cursor is weakly consistent: entries stored or deleted concurrently may or
+// may not be visited, but no key is visited twice in the same direction.
+// [Cursor.Value] returns the value at the time when the cursor moved to it.
+//
+// 💡 NOTE: Cursor is not concurrent-safe, but the underlying skipmap can be
+// modified concurrently.
+type Cursor[keyT any, valueT any] struct {
+	m     navigator[keyT, valueT]
+	pos   cursorPos
+	entry tuple.T2[keyT, valueT]
+}
+
+func newCursor[keyT any, valueT any](m navigator[keyT, valueT]) *Cursor[keyT, valueT] {
+	return &Cursor[keyT, valueT]{m: m}
+}
+
+// Cursor returns a cursor positioned before the first entry.
+func (s *OrderedMap[keyT, valueT]) Cursor() *Cursor[keyT, valueT] {
+	return newCursor[keyT, valueT](s)
+}
+
+// Cursor returns a cursor positioned before the first entry.
+func (s *OrderedMapDesc[keyT, valueT]) Cursor() *Cursor[keyT, valueT] {
+	return newCursor[keyT, valueT](s)
+}
+
+// Cursor returns a cursor positioned before the first entry.
+func (s *FuncMap[keyT, valueT]) Cursor() *Cursor[keyT, valueT] {
+	return newCursor[keyT, valueT](s)
+}
+
+func (c *Cursor[keyT, valueT]) moveTo(e goption.O[tuple.T2[keyT, valueT]], otherwise cursorPos) bool {
+	if e.IsOK() {
+		c.pos, c.entry = atEntry, e.Value()
+		return true
+	}
+	c.pos, c.entry = otherwise, tuple.T2[keyT, valueT]{}
+	return false
+}
+
+// Seek moves the cursor to the first entry whose key is after or equal to
+// the given key, and reports whether there is such entry.
+// If not, the cursor is positioned after the last entry.
+func (c *Cursor[keyT, valueT]) Seek(key keyT) bool {
+	return c.moveTo(c.m.Ceiling(key), afterLast)
+}
+
+// Next moves the cursor to the next entry, and reports whether there is one.
+// If the cursor is positioned before the first entry, it moves to the first one.
+func (c *Cursor[keyT, valueT]) Next() bool {
+	switch c.pos {
+	case beforeFirst:
+		return c.moveTo(c.m.First(), afterLast)
+	case atEntry:
+		return c.moveTo(c.m.Higher(c.entry.First), afterLast)
+	default:
+		return false
+	}
+}
+
+// Prev moves the cursor to the previous entry, and reports whether there is one.
+// If the cursor is positioned after the last entry, it moves to the last one.
+func (c *Cursor[keyT, valueT]) Prev() bool {
+	switch c.pos {
+	case afterLast:
+		return c.moveTo(c.m.Last(), beforeFirst)
+	case atEntry:
+		return c.moveTo(c.m.Lower(c.entry.First), beforeFirst)
+	default:
+		return false
+	}
+}
+
+// Valid reports whether the cursor is positioned at an entry.
+func (c *Cursor[keyT, valueT]) Valid() bool {
+	return c.pos == atEntry
+}
+
+// Key returns the key of current entry.
+// If the cursor is not positioned at an entry, return zero value.
+func (c *Cursor[keyT, valueT]) Key() keyT {
+	return c.entry.First
+}
+
+// Value returns the value of current entry.
+// If the cursor is not positioned at an entry, return zero value.
+func (c *Cursor[keyT, valueT]) Value() valueT {
+	return c.entry.Second
+}
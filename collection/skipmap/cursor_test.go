@@ -0,0 +1,104 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+type cursorskipmap interface {
+	Store(key int, value int)
+	Delete(key int) bool
+	Cursor() *Cursor[int, int]
+}
+
+func TestCursor(t *testing.T) {
+	testSkipMapCursor(t, New[int, int](), false)
+	testSkipMapCursor(t, NewDesc[int, int](), true)
+	testSkipMapCursor(t, NewFunc[int, int](func(a, b int) bool { return a < b }), false)
+}
+
+func testSkipMapCursor(t *testing.T, m cursorskipmap, desc bool) {
+	c := m.Cursor()
+	assert.False(t, c.Valid())
+	assert.False(t, c.Next())
+	assert.False(t, c.Prev())
+	assert.False(t, c.Seek(0))
+
+	for i := 1; i <= 5; i++ {
+		m.Store(i*10, i)
+	}
+	ordered := []int{10, 20, 30, 40, 50}
+	if desc {
+		ordered = []int{50, 40, 30, 20, 10}
+	}
+
+	// Forward and backward.
+	c = m.Cursor()
+	var keys []int
+	for c.Next() {
+		assert.True(t, c.Valid())
+		assert.Equal(t, c.Key()/10, c.Value())
+		keys = append(keys, c.Key())
+	}
+	assert.Equal(t, ordered, keys)
+	assert.False(t, c.Valid())
+	assert.Equal(t, 0, c.Key())
+	assert.False(t, c.Next())
+
+	keys = nil
+	for c.Prev() {
+		keys = append(keys, c.Key())
+	}
+	assert.Equal(t, []int{ordered[4], ordered[3], ordered[2], ordered[1], ordered[0]}, keys)
+	assert.False(t, c.Prev())
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[0], c.Key())
+
+	// Resume from any key, even if it does not exist.
+	assert.True(t, c.Seek(25))
+	if desc {
+		assert.Equal(t, 20, c.Key())
+	} else {
+		assert.Equal(t, 30, c.Key())
+	}
+	assert.True(t, c.Seek(ordered[3]))
+	assert.Equal(t, ordered[3], c.Key())
+	assert.True(t, c.Prev())
+	assert.Equal(t, ordered[2], c.Key())
+
+	// Concurrent modifications are visible for the following moves.
+	m.Delete(ordered[3])
+	m.Store(ordered[2]+orderedStep(desc), 0)
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[2]+orderedStep(desc), c.Key())
+	assert.Equal(t, 0, c.Value())
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[4], c.Key())
+	assert.False(t, c.Next())
+	assert.False(t, c.Seek(ordered[4]+orderedStep(desc)))
+	assert.True(t, c.Prev())
+	assert.Equal(t, ordered[4], c.Key())
+}
+
+// orderedStep is a small step forward in the order of map.
+func orderedStep(desc bool) int {
+	if desc {
+		return -1
+	}
+	return 1
+}
@@ -73,7 +73,7 @@ func main() {
 		Package:         "skipmap",
 		Name:            "ordered",
 		Path:            "gen_ordered.go",
-		Imports:         "\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n\n\"github.com/bytedance/gg/internal/constraints\"\n",
+		Imports:         "\"fmt\"\n\"sort\"\n\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n\n\"github.com/bytedance/gg/collection/tuple\"\n\"github.com/bytedance/gg/goption\"\n\"github.com/bytedance/gg/internal/constraints\"\n",
 		KeyType:         "keyT",
 		ValueType:       "valueT",
 		TypeArgument:    "[keyT, valueT]",
@@ -109,7 +109,7 @@ func main() {
 		Package:         "skipmap",
 		Name:            "func",
 		Path:            "gen_func.go",
-		Imports:         "\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n",
+		Imports:         "\"fmt\"\n\"sort\"\n\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n\n\"github.com/bytedance/gg/collection/tuple\"\n\"github.com/bytedance/gg/goption\"\n",
 		KeyType:         "keyT",
 		ValueType:       "valueT",
 		TypeArgument:    "[keyT, valueT]",
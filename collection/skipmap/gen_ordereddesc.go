@@ -17,18 +17,33 @@
 package skipmap
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"unsafe"
 
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
 	"github.com/bytedance/gg/internal/constraints"
 )
 
 // OrderedMapDesc represents a map based on skip list.
+//
+// If it is created with [Options.ConsistentSnapshot], every write operation
+// enters a map-wide barrier in shared mode, so that [OrderedMapDesc.Snapshot] and
+// [OrderedMapDesc.Clone] can block writers. It costs two atomic operations on a shared
+// counter per write, which makes updates and deletes up to about 30% slower in
+// BenchmarkParallelWrite, and the counter may become contended when many cores
+// write concurrently. Reads are not affected.
 type OrderedMapDesc[keyT constraints.Ordered, valueT any] struct {
 	length       int64
 	highestLevel uint64 // highest level for now
 	header       *orderednodeDesc[keyT, valueT]
+	levels       *levelGenerator // nil means the default
+	barrier      sync.RWMutex    // held by writers in shared mode if consistent, see Snapshot
+	consistent   bool            // see Options.ConsistentSnapshot
+
 }
 
 type orderednodeDesc[keyT constraints.Ordered, valueT any] struct {
@@ -120,6 +135,66 @@ func (s *OrderedMapDesc[keyT, valueT]) findNodeDelete(key keyT, preds *[maxLevel
 	return lFound
 }
 
+// findNodeHint is a variant of findNode for batch operations on keys in
+// ascending order, the search at level i starts from preds[i] left by
+// the previous search if it is a better start point.
+// The preds must precede the key or be nil.
+func (s *OrderedMapDesc[keyT, valueT]) findNodeHint(key keyT, preds *[maxLevel]*orderednodeDesc[keyT, valueT], succs *[maxLevel]*orderednodeDesc[keyT, valueT]) *orderednodeDesc[keyT, valueT] {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && (succ.key > key) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the key already in the skipmap.
+		if succ != nil && succ.key == key {
+			return succ
+		}
+	}
+	return nil
+}
+
+// findNodeDeleteHint is a variant of findNodeDelete which uses preds as hints,
+// see findNodeHint.
+func (s *OrderedMapDesc[keyT, valueT]) findNodeDeleteHint(key keyT, preds *[maxLevel]*orderednodeDesc[keyT, valueT], succs *[maxLevel]*orderednodeDesc[keyT, valueT]) int {
+	// lFound represents the index of the first layer at which it found a node.
+	lFound, x := -1, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && (succ.key > key) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the key already in the skip list.
+		if lFound == -1 && succ != nil && succ.key == key {
+			lFound = i
+		}
+	}
+	return lFound
+}
+
+// further returns the one of x and hint which is closer to the tail.
+// A marked hint is ignored, it may have been unlinked and its next nodes are
+// no longer up to date.
+func (s *OrderedMapDesc[keyT, valueT]) further(x, hint *orderednodeDesc[keyT, valueT]) *orderednodeDesc[keyT, valueT] {
+	if hint == nil || hint == s.header || hint.flags.Get(marked) {
+		return x
+	}
+	if x == s.header || (x.key > hint.key) {
+		return hint
+	}
+	return x
+}
+
 func unlockorderedDesc[keyT constraints.Ordered, valueT any](preds [maxLevel]*orderednodeDesc[keyT, valueT], highestLevel int) {
 	var prevPred *orderednodeDesc[keyT, valueT]
 	for i := highestLevel; i >= 0; i-- {
@@ -132,10 +207,18 @@ func unlockorderedDesc[keyT constraints.Ordered, valueT any](preds [maxLevel]*or
 
 // Store sets the value for a key.
 func (s *OrderedMapDesc[keyT, valueT]) Store(key keyT, value valueT) {
-	level := s.randomlevel()
 	var preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+	s.store(key, value, &preds, &succs)
+}
+
+// store sets the value for a key, the preds are used as hints of search,
+// see findNodeHint.
+func (s *OrderedMapDesc[keyT, valueT]) store(key keyT, value valueT, preds, succs *[maxLevel]*orderednodeDesc[keyT, valueT]) {
+	s.enterWrite()
+	defer s.exitWrite()
+	level := s.randomlevel()
 	for {
-		nodeFound := s.findNode(key, &preds, &succs)
+		nodeFound := s.findNodeHint(key, preds, succs)
 		if nodeFound != nil { // indicating the key is already in the skip-list
 			if !nodeFound.flags.Get(marked) {
 				// We don't need to care about whether or not the node is fully linked,
@@ -145,6 +228,7 @@ func (s *OrderedMapDesc[keyT, valueT]) Store(key keyT, value valueT) {
 			}
 			// If the node is marked, represents some other goroutines is in the process of deleting this node,
 			// we need to add this node in next loop.
+			*preds = [maxLevel]*orderednodeDesc[keyT, valueT]{}
 			continue
 		}
 		// Add this node into skip list.
@@ -168,7 +252,8 @@ func (s *OrderedMapDesc[keyT, valueT]) Store(key keyT, value valueT) {
 			valid = !pred.flags.Get(marked) && (succ == nil || !succ.flags.Get(marked)) && pred.loadNext(layer) == succ
 		}
 		if !valid {
-			unlockorderedDesc(preds, highestLocked)
+			unlockorderedDesc(*preds, highestLocked)
+			*preds = [maxLevel]*orderednodeDesc[keyT, valueT]{}
 			continue
 		}
 
@@ -178,7 +263,7 @@ func (s *OrderedMapDesc[keyT, valueT]) Store(key keyT, value valueT) {
 			preds[layer].atomicStoreNext(layer, nn)
 		}
 		nn.flags.SetTrue(fullyLinked)
-		unlockorderedDesc(preds, highestLocked)
+		unlockorderedDesc(*preds, highestLocked)
 		atomic.AddInt64(&s.length, 1)
 		return
 	}
@@ -187,7 +272,7 @@ func (s *OrderedMapDesc[keyT, valueT]) Store(key keyT, value valueT) {
 // randomlevel returns a random level and update the highest level if needed.
 func (s *OrderedMapDesc[keyT, valueT]) randomlevel() int {
 	// Generate random level.
-	level := randomLevel()
+	level := s.levels.next()
 	// Update highest level if possible.
 	for {
 		hl := atomic.LoadUint64(&s.highestLevel)
@@ -228,6 +313,8 @@ func (s *OrderedMapDesc[keyT, valueT]) Load(key keyT) (value valueT, ok bool) {
 // The loaded result reports whether the key was present.
 // (Modified from Delete)
 func (s *OrderedMapDesc[keyT, valueT]) LoadAndDelete(key keyT) (value valueT, loaded bool) {
+	s.enterWrite()
+	defer s.exitWrite()
 	var (
 		nodeToDelete *orderednodeDesc[keyT, valueT]
 		isMarked     bool // represents if this operation mark the node
@@ -294,6 +381,8 @@ func (s *OrderedMapDesc[keyT, valueT]) LoadAndDelete(key keyT) (value valueT, lo
 // The loaded result is true if the value was loaded, false if stored.
 // (Modified from Store)
 func (s *OrderedMapDesc[keyT, valueT]) LoadOrStore(key keyT, value valueT) (actual valueT, loaded bool) {
+	s.enterWrite()
+	defer s.exitWrite()
 	var (
 		level        int
 		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
@@ -363,6 +452,8 @@ func (s *OrderedMapDesc[keyT, valueT]) LoadOrStore(key keyT, value valueT) (actu
 // The loaded result is true if the value was loaded, false if stored.
 // (Modified from LoadOrStore)
 func (s *OrderedMapDesc[keyT, valueT]) LoadOrStoreLazy(key keyT, f func() valueT) (actual valueT, loaded bool) {
+	s.enterWrite()
+	defer s.exitWrite()
 	var (
 		level        int
 		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
@@ -427,16 +518,215 @@ func (s *OrderedMapDesc[keyT, valueT]) LoadOrStoreLazy(key keyT, f func() valueT
 	}
 }
 
+// Compute atomically computes the value for a key by calling f with the current
+// value, loaded reports whether the key is present.
+//
+// If f returns [ComputeKeep], the returned value is stored for the key;
+// if f returns [ComputeDelete], the key is deleted if present.
+// The actual result is the value present after computing, and ok reports
+// whether the key is present.
+//
+// 💡 NOTE: f may be called more than once if the value is modified concurrently,
+// so it should be free of side effects.
+// f may be called with internal locks held, so it must not call any write
+// method, [OrderedMapDesc.Snapshot] or [OrderedMapDesc.Clone] of the skipmap,
+// or it may deadlock.
+func (s *OrderedMapDesc[keyT, valueT]) Compute(key keyT, f func(old valueT, loaded bool) (valueT, ComputeOp)) (actual valueT, ok bool) {
+	s.enterWrite()
+	defer s.exitWrite()
+	var (
+		level        int
+		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+		hl           = int(atomic.LoadUint64(&s.highestLevel))
+	)
+	for {
+		nodeFound := s.findNode(key, &preds, &succs)
+		if nodeFound != nil { // indicating the key is already in the skip-list
+			if !nodeFound.flags.MGet(fullyLinked|marked, fullyLinked) {
+				// The node is being inserted or deleted, wait for it in next loop.
+				continue
+			}
+			p := atomic.LoadPointer(&nodeFound.value)
+			value, op := f(*(*valueT)(p), true)
+			if op == ComputeDelete {
+				if s.deleteIfUnchanged(nodeFound, p) {
+					return actual, false
+				}
+			} else if atomic.CompareAndSwapPointer(&nodeFound.value, p, unsafe.Pointer(&value)) {
+				return value, true
+			}
+			// The value is modified concurrently, compute again.
+			continue
+		}
+		// Add this node into skip list.
+		var (
+			highestLocked        = -1 // the highest level being locked by this process
+			valid                = true
+			pred, succ, prevPred *orderednodeDesc[keyT, valueT]
+		)
+		if level == 0 {
+			level = s.randomlevel()
+			if level > hl {
+				// If the highest level is updated, usually means that many goroutines
+				// are inserting items. Hopefully we can find a better path in next loop.
+				continue
+			}
+		}
+		for layer := 0; valid && layer < level; layer++ {
+			pred = preds[layer]   // target node's previous node
+			succ = succs[layer]   // target node's next node
+			if pred != prevPred { // the node in this layer could be locked by previous loop
+				pred.mu.Lock()
+				highestLocked = layer
+				prevPred = pred
+			}
+			// valid check if there is another node has inserted into the skip list in this layer during this process.
+			// It is valid if:
+			// 1. The previous node and next node both are not marked.
+			// 2. The previous node's next node is succ in this layer.
+			valid = !pred.flags.Get(marked) && pred.loadNext(layer) == succ && (succ == nil || !succ.flags.Get(marked))
+		}
+		if !valid {
+			unlockorderedDesc(preds, highestLocked)
+			continue
+		}
+		value, op := f(actual, false)
+		if op == ComputeDelete {
+			unlockorderedDesc(preds, highestLocked)
+			return actual, false
+		}
+		nn := newOrderedNodeDesc(key, value, level)
+		for layer := 0; layer < level; layer++ {
+			nn.storeNext(layer, succs[layer])
+			preds[layer].atomicStoreNext(layer, nn)
+		}
+		nn.flags.SetTrue(fullyLinked)
+		unlockorderedDesc(preds, highestLocked)
+		atomic.AddInt64(&s.length, 1)
+		return value, true
+	}
+}
+
+// Swap stores the value for a key and returns the previous value if any.
+// The loaded result reports whether the key was present.
+func (s *OrderedMapDesc[keyT, valueT]) Swap(key keyT, value valueT) (previous valueT, loaded bool) {
+	s.Compute(key, func(old valueT, ok bool) (valueT, ComputeOp) {
+		previous, loaded = old, ok
+		return value, ComputeKeep
+	})
+	return
+}
+
+// CompareAndSwap swaps the old and new values for key if the value stored in
+// the map is equal to old.
+//
+// 💡 NOTE: It panics if the value type is not comparable,
+// use [OrderedMapDesc.CompareAndSwapFunc] instead.
+func (s *OrderedMapDesc[keyT, valueT]) CompareAndSwap(key keyT, old, new valueT) bool {
+	return s.CompareAndSwapFunc(key, old, new, equalAny[valueT])
+}
+
+// CompareAndSwapFunc is a variant of [OrderedMapDesc.CompareAndSwap],
+// the values are compared by function eq.
+func (s *OrderedMapDesc[keyT, valueT]) CompareAndSwapFunc(key keyT, old, new valueT, eq func(a, b valueT) bool) bool {
+	s.enterWrite()
+	defer s.exitWrite()
+	for {
+		n := s.loadNode(key)
+		if n == nil {
+			return false
+		}
+		p := atomic.LoadPointer(&n.value)
+		if n.flags.Get(marked) || !eq(*(*valueT)(p), old) {
+			return false
+		}
+		if atomic.CompareAndSwapPointer(&n.value, p, unsafe.Pointer(&new)) {
+			return true
+		}
+	}
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// The deleted result reports whether the entry was deleted.
+//
+// 💡 NOTE: It panics if the value type is not comparable,
+// use [OrderedMapDesc.CompareAndDeleteFunc] instead.
+func (s *OrderedMapDesc[keyT, valueT]) CompareAndDelete(key keyT, old valueT) (deleted bool) {
+	return s.CompareAndDeleteFunc(key, old, equalAny[valueT])
+}
+
+// CompareAndDeleteFunc is a variant of [OrderedMapDesc.CompareAndDelete],
+// the values are compared by function eq.
+func (s *OrderedMapDesc[keyT, valueT]) CompareAndDeleteFunc(key keyT, old valueT, eq func(a, b valueT) bool) (deleted bool) {
+	s.enterWrite()
+	defer s.exitWrite()
+	for {
+		n := s.loadNode(key)
+		if n == nil {
+			return false
+		}
+		p := atomic.LoadPointer(&n.value)
+		if n.flags.Get(marked) || !eq(*(*valueT)(p), old) {
+			return false
+		}
+		if s.deleteIfUnchanged(n, p) {
+			return true
+		}
+	}
+}
+
+// loadNode returns the fully linked node of key, or nil if no such node.
+// (Modified from Load)
+func (s *OrderedMapDesc[keyT, valueT]) loadNode(key keyT) *orderednodeDesc[keyT, valueT] {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		nex := x.atomicLoadNext(i)
+		for nex != nil && (nex.key > key) {
+			x = nex
+			nex = x.atomicLoadNext(i)
+		}
+
+		// Check if the key already in the skip list.
+		if nex != nil && nex.key == key {
+			if nex.flags.MGet(fullyLinked|marked, fullyLinked) {
+				return nex
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// deleteIfUnchanged deletes the fully linked node x if its value is still p.
+func (s *OrderedMapDesc[keyT, valueT]) deleteIfUnchanged(x *orderednodeDesc[keyT, valueT], p unsafe.Pointer) bool {
+	x.mu.Lock()
+	if x.flags.Get(marked) || atomic.LoadPointer(&x.value) != p {
+		x.mu.Unlock()
+		return false
+	}
+	x.flags.SetTrue(marked)
+	s.unlinkMarked(x)
+	return true
+}
+
 // Delete deletes the value for a key.
 func (s *OrderedMapDesc[keyT, valueT]) Delete(key keyT) bool {
+	var preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+	return s.delete(key, &preds, &succs)
+}
+
+// delete deletes the value for a key, the preds are used as hints of search,
+// see findNodeHint.
+func (s *OrderedMapDesc[keyT, valueT]) delete(key keyT, preds, succs *[maxLevel]*orderednodeDesc[keyT, valueT]) bool {
+	s.enterWrite()
+	defer s.exitWrite()
 	var (
 		nodeToDelete *orderednodeDesc[keyT, valueT]
 		isMarked     bool // represents if this operation mark the node
 		topLayer     = -1
-		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
 	)
 	for {
-		lFound := s.findNodeDelete(key, &preds, &succs)
+		lFound := s.findNodeDeleteHint(key, preds, succs)
 		if isMarked || // this process mark this node or we can find this node in the skip list
 			lFound != -1 && succs[lFound].flags.MGet(fullyLinked|marked, fullyLinked) && (int(succs[lFound].level)-1) == lFound {
 			if !isMarked { // we don't mark this node for now
@@ -473,7 +763,8 @@ func (s *OrderedMapDesc[keyT, valueT]) Delete(key keyT) bool {
 				valid = !pred.flags.Get(marked) && pred.atomicLoadNext(layer) == succ
 			}
 			if !valid {
-				unlockorderedDesc(preds, highestLocked)
+				unlockorderedDesc(*preds, highestLocked)
+				*preds = [maxLevel]*orderednodeDesc[keyT, valueT]{}
 				continue
 			}
 			for i := topLayer; i >= 0; i-- {
@@ -482,7 +773,7 @@ func (s *OrderedMapDesc[keyT, valueT]) Delete(key keyT) bool {
 				preds[i].atomicStoreNext(i, nodeToDelete.loadNext(i))
 			}
 			nodeToDelete.mu.Unlock()
-			unlockorderedDesc(preds, highestLocked)
+			unlockorderedDesc(*preds, highestLocked)
 			atomic.AddInt64(&s.length, -1)
 			return true
 		}
@@ -490,6 +781,102 @@ func (s *OrderedMapDesc[keyT, valueT]) Delete(key keyT) bool {
 	}
 }
 
+// StoreBatch sets the values for keys of entries, it is equivalent to calling
+// [OrderedMapDesc.Store] for each entry in order, so the latter one wins if
+// there are duplicate keys.
+//
+// The entries are stored in key order and the search of each key starts from
+// the position of the previous one, which is faster than separate calls
+// when keys are adjacent.
+//
+// The batch is not atomic, [OrderedMapDesc.Snapshot] and [OrderedMapDesc.Clone] may observe
+// part of it.
+func (s *OrderedMapDesc[keyT, valueT]) StoreBatch(entries []tuple.T2[keyT, valueT]) {
+	sorted := make([]tuple.T2[keyT, valueT], len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return (sorted[i].First > sorted[j].First)
+	})
+	s.storeSorted(sorted)
+}
+
+// LoadSorted is a variant of [OrderedMapDesc.StoreBatch] for entries already sorted
+// by key in the order of skipmap, it stores entries without copying and sorting.
+//
+// It panics if entries are not sorted.
+//
+// The batch is not atomic, [OrderedMapDesc.Snapshot] and [OrderedMapDesc.Clone] may observe
+// part of it.
+func (s *OrderedMapDesc[keyT, valueT]) LoadSorted(entries []tuple.T2[keyT, valueT]) {
+	s.checkSorted(entries)
+	s.storeSorted(entries)
+}
+
+func (s *OrderedMapDesc[keyT, valueT]) storeSorted(entries []tuple.T2[keyT, valueT]) {
+	var preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+	for _, e := range entries {
+		s.store(e.First, e.Second, &preds, &succs)
+	}
+}
+
+// DeleteBatch deletes the values for keys and returns the number of deleted keys.
+//
+// Like [OrderedMapDesc.StoreBatch], the keys are deleted in key order and the search of
+// each key starts from the position of the previous one.
+//
+// The batch is not atomic, [OrderedMapDesc.Snapshot] and [OrderedMapDesc.Clone] may observe
+// part of it.
+func (s *OrderedMapDesc[keyT, valueT]) DeleteBatch(keys []keyT) int {
+	sorted := make([]keyT, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return (sorted[i] > sorted[j])
+	})
+	var (
+		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+		n            int
+	)
+	for _, key := range sorted {
+		if s.delete(key, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// buildSorted links entries sorted by key into the empty skipmap in O(n),
+// the latter one wins if there are duplicate keys.
+// It must be called before the skipmap is shared.
+func (s *OrderedMapDesc[keyT, valueT]) buildSorted(entries []tuple.T2[keyT, valueT]) {
+	s.checkSorted(entries)
+	var tails [maxLevel]*orderednodeDesc[keyT, valueT]
+	for i := range tails {
+		tails[i] = s.header
+	}
+	for i, e := range entries {
+		if i > 0 && !(entries[i-1].First > e.First) {
+			tails[0].storeVal(e.Second)
+			continue
+		}
+		level := s.randomlevel()
+		nn := newOrderedNodeDesc(e.First, e.Second, level)
+		nn.flags.SetTrue(fullyLinked)
+		for l := 0; l < level; l++ {
+			tails[l].storeNext(l, nn)
+			tails[l] = nn
+		}
+		s.length++
+	}
+}
+
+func (s *OrderedMapDesc[keyT, valueT]) checkSorted(entries []tuple.T2[keyT, valueT]) {
+	for i := 1; i < len(entries); i++ {
+		if entries[i].First > entries[i-1].First {
+			panic(fmt.Errorf("entries are not sorted at index %d", i))
+		}
+	}
+}
+
 // Range calls f sequentially for each key and value present in the skipmap.
 // If f returns false, range stops the iteration.
 //
@@ -497,6 +884,7 @@ func (s *OrderedMapDesc[keyT, valueT]) Delete(key keyT) bool {
 // contents: no key will be visited more than once, but if the value for any key
 // is stored or deleted concurrently, Range may reflect any mapping for that key
 // from any point during the Range call.
+// Use [OrderedMapDesc.Snapshot] if a consistent view is needed.
 func (s *OrderedMapDesc[keyT, valueT]) Range(f func(key keyT, value valueT) bool) {
 	x := s.header.atomicLoadNext(0)
 	for x != nil {
@@ -511,11 +899,406 @@ func (s *OrderedMapDesc[keyT, valueT]) Range(f func(key keyT, value valueT) bool
 	}
 }
 
+// RangeFrom is a variant of [OrderedMapDesc.Range], calls f sequentially for
+// each key and value whose key is after or equal to start in the order of the skipmap.
+//
+// The start position is located by the index of skip list, so the complexity
+// of visiting k entries is O(log n + k).
+func (s *OrderedMapDesc[keyT, valueT]) RangeFrom(start keyT, f func(key keyT, value valueT) bool) {
+	for x := s.seek(start, true); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		if !f(x.key, x.loadVal()) {
+			break
+		}
+	}
+}
+
+// RangeBetween is a variant of [OrderedMapDesc.Range], calls f sequentially for
+// each key and value whose key is between lo and hi in the order of the skipmap.
+// loInclusive and hiInclusive control whether lo and hi themselves are included.
+//
+// The start position is located by the index of skip list, so the complexity
+// of visiting k entries is O(log n + k).
+func (s *OrderedMapDesc[keyT, valueT]) RangeBetween(lo, hi keyT, loInclusive, hiInclusive bool, f func(key keyT, value valueT) bool) {
+	for x := s.seek(lo, loInclusive); x != nil; x = x.atomicLoadNext(0) {
+		if !((x.key > hi) || hiInclusive && x.key == hi) {
+			break
+		}
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		if !f(x.key, x.loadVal()) {
+			break
+		}
+	}
+}
+
+// RangeReverse is a variant of [OrderedMapDesc.Range], calls f sequentially for
+// each key and value in the reverse order of the skipmap.
+//
+// 💡 NOTE: Skip list has no backward links, the entries are collected by a
+// forward scan before f is called, so it always takes O(n) time and memory
+// even if f stops the iteration early.
+func (s *OrderedMapDesc[keyT, valueT]) RangeReverse(f func(key keyT, value valueT) bool) {
+	var nodes []*orderednodeDesc[keyT, valueT]
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			nodes = append(nodes, x)
+		}
+	}
+	rangeReverseorderedDesc(nodes, f)
+}
+
+// RangeBetweenReverse is a variant of [OrderedMapDesc.RangeBetween], calls f in the
+// reverse order of the skipmap, from hi to lo.
+//
+// The start position is located by the index of skip list, and the k entries
+// between lo and hi are collected by a forward scan before f is called,
+// so the complexity is O(log n + k) time and O(k) memory,
+// see [OrderedMapDesc.RangeReverse].
+func (s *OrderedMapDesc[keyT, valueT]) RangeBetweenReverse(lo, hi keyT, loInclusive, hiInclusive bool, f func(key keyT, value valueT) bool) {
+	var nodes []*orderednodeDesc[keyT, valueT]
+	for x := s.seek(lo, loInclusive); x != nil; x = x.atomicLoadNext(0) {
+		if !((x.key > hi) || hiInclusive && x.key == hi) {
+			break
+		}
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			nodes = append(nodes, x)
+		}
+	}
+	rangeReverseorderedDesc(nodes, f)
+}
+
+// rangeReverseorderedDesc calls f for nodes in the reverse order,
+// nodes deleted after being collected are skipped.
+func rangeReverseorderedDesc[keyT constraints.Ordered, valueT any](nodes []*orderednodeDesc[keyT, valueT], f func(key keyT, value valueT) bool) {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		x := nodes[i]
+		if x.flags.Get(marked) {
+			continue
+		}
+		if !f(x.key, x.loadVal()) {
+			break
+		}
+	}
+}
+
+// Stats returns the structural statistics of the skipmap.
+//
+// It visits every node and searches every key, so the complexity is O(n log n),
+// it is intended for debugging and tuning.
+// Like [OrderedMapDesc.Range], it does not necessarily correspond to any
+// consistent snapshot if the skipmap is modified concurrently.
+func (s *OrderedMapDesc[keyT, valueT]) Stats() Stats {
+	st := Stats{
+		HighestLevel: int(atomic.LoadUint64(&s.highestLevel)),
+		Levels:       make([]int, maxLevel),
+	}
+	var path, highest int
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		st.Nodes++
+		st.Levels[x.level-1]++
+		if int(x.level) > highest {
+			highest = int(x.level)
+		}
+		path += s.searchPath(x.key)
+	}
+	st.Levels = st.Levels[:highest]
+	if st.Nodes > 0 {
+		st.AvgSearchPath = float64(path) / float64(st.Nodes)
+	}
+	return st
+}
+
+// searchPath returns the number of nodes visited by searching the key.
+func (s *OrderedMapDesc[keyT, valueT]) searchPath(key keyT) int {
+	n, x := 0, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		succ := x.atomicLoadNext(i)
+		for succ != nil {
+			n++
+			if !(succ.key > key) {
+				break
+			}
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		if succ != nil && succ.key == key {
+			return n
+		}
+	}
+	return n
+}
+
+// Snapshot returns the entries of the skipmap in key order at a point in time.
+//
+// If the skipmap is created with [Options.ConsistentSnapshot], the result is
+// consistent: writers are blocked while the entries are being collected,
+// readers are not. Otherwise, it is weakly consistent like [OrderedMapDesc.Range].
+// The result can be used to build a skipmap by [NewDescFromSorted].
+func (s *OrderedMapDesc[keyT, valueT]) Snapshot() []tuple.T2[keyT, valueT] {
+	if s.consistent {
+		s.barrier.Lock()
+		defer s.barrier.Unlock()
+	}
+	return s.entries()
+}
+
+// Clone returns a copy of the skipmap at a point in time,
+// see [OrderedMapDesc.Snapshot] for consistency.
+// The copy is created with the same options.
+func (s *OrderedMapDesc[keyT, valueT]) Clone() *OrderedMapDesc[keyT, valueT] {
+	if s.consistent {
+		s.barrier.Lock()
+	}
+	entries := s.entries()
+	var levels *levelGenerator
+	if s.levels != nil {
+		g := *s.levels
+		levels = &g
+	}
+	if s.consistent {
+		s.barrier.Unlock()
+	}
+
+	c := NewDesc[keyT, valueT]()
+	c.levels = levels
+	c.consistent = s.consistent
+	c.buildSorted(entries)
+	return c
+}
+
+// enterWrite enters the write barrier in shared mode if snapshots are consistent.
+func (s *OrderedMapDesc[keyT, valueT]) enterWrite() {
+	if s.consistent {
+		s.barrier.RLock()
+	}
+}
+
+// exitWrite exits the write barrier entered by enterWrite.
+func (s *OrderedMapDesc[keyT, valueT]) exitWrite() {
+	if s.consistent {
+		s.barrier.RUnlock()
+	}
+}
+
+// entries returns all valid entries in key order.
+func (s *OrderedMapDesc[keyT, valueT]) entries() []tuple.T2[keyT, valueT] {
+	res := make([]tuple.T2[keyT, valueT], 0, s.Len())
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			res = append(res, tuple.Make2(x.key, x.loadVal()))
+		}
+	}
+	return res
+}
+
 // Len returns the length of this skipmap.
 func (s *OrderedMapDesc[keyT, valueT]) Len() int {
 	return int(atomic.LoadInt64(&s.length))
 }
 
+// First returns the first entry of the skipmap.
+// If the skipmap is empty, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) First() goption.O[tuple.T2[keyT, valueT]] {
+	return s.firstFrom(s.header.atomicLoadNext(0))
+}
+
+// Last returns the last entry of the skipmap.
+// If the skipmap is empty, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) Last() goption.O[tuple.T2[keyT, valueT]] {
+	var key keyT
+	return entryOforderedDesc(s.lastBefore(key, false, false))
+}
+
+// Floor returns the last entry whose key is before or equal to the given key
+// in the order of the skipmap.
+// If there is no such entry, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) Floor(key keyT) goption.O[tuple.T2[keyT, valueT]] {
+	return entryOforderedDesc(s.lastBefore(key, true, true))
+}
+
+// Lower returns the last entry whose key is strictly before the given key
+// in the order of the skipmap.
+// If there is no such entry, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) Lower(key keyT) goption.O[tuple.T2[keyT, valueT]] {
+	return entryOforderedDesc(s.lastBefore(key, true, false))
+}
+
+// Ceiling returns the first entry whose key is after or equal to the given key
+// in the order of the skipmap.
+// If there is no such entry, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) Ceiling(key keyT) goption.O[tuple.T2[keyT, valueT]] {
+	return s.firstFrom(s.seek(key, true))
+}
+
+// Higher returns the first entry whose key is strictly after the given key
+// in the order of the skipmap.
+// If there is no such entry, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) Higher(key keyT) goption.O[tuple.T2[keyT, valueT]] {
+	return s.firstFrom(s.seek(key, false))
+}
+
+// seek returns the first node at level 0 whose key is after (or equal to, if inclusive) the given key.
+// The returned node may be invalid, or nil if no such node.
+func (s *OrderedMapDesc[keyT, valueT]) seek(key keyT, inclusive bool) *orderednodeDesc[keyT, valueT] {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		nex := x.atomicLoadNext(i)
+		for nex != nil && ((nex.key > key) || !inclusive && nex.key == key) {
+			x = nex
+			nex = x.atomicLoadNext(i)
+		}
+	}
+	return x.atomicLoadNext(0)
+}
+
+// firstFrom returns the first valid entry starting from node x at level 0.
+func (s *OrderedMapDesc[keyT, valueT]) firstFrom(x *orderednodeDesc[keyT, valueT]) goption.O[tuple.T2[keyT, valueT]] {
+	for x != nil && !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+		x = x.atomicLoadNext(0)
+	}
+	return entryOforderedDesc(x)
+}
+
+// lastBefore returns the last valid node before the given key, or nil if no such node.
+// If bounded is false, the key is ignored and the last valid node of skipmap is returned.
+func (s *OrderedMapDesc[keyT, valueT]) lastBefore(key keyT, bounded, inclusive bool) *orderednodeDesc[keyT, valueT] {
+	for {
+		x := s.header
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && (!bounded || (nex.key > key) || inclusive && nex.key == key) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+		if x == s.header {
+			return nil
+		}
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x
+		}
+		// The node is being inserted or deleted, find the one before it.
+		key, bounded, inclusive = x.key, true, false
+	}
+}
+
+func entryOforderedDesc[keyT constraints.Ordered, valueT any](x *orderednodeDesc[keyT, valueT]) goption.O[tuple.T2[keyT, valueT]] {
+	if x == nil {
+		return goption.Nil[tuple.T2[keyT, valueT]]()
+	}
+	return goption.OK(tuple.Make2(x.key, x.loadVal()))
+}
+
+// PopFirst deletes the first entry of the skipmap and returns it.
+// If the skipmap is empty, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) PopFirst() goption.O[tuple.T2[keyT, valueT]] {
+	s.enterWrite()
+	defer s.exitWrite()
+	for {
+		// No node can be inserted before the first one without locking the header.
+		s.header.mu.Lock()
+		x := s.header.atomicLoadNext(0)
+		for x != nil && x.flags.Get(marked) {
+			x = x.atomicLoadNext(0)
+		}
+		if x == nil {
+			s.header.mu.Unlock()
+			return entryOforderedDesc(x)
+		}
+		// Nodes are always locked before their predecessors, so we can not wait
+		// for x while holding the header.
+		if !x.mu.TryLock() {
+			s.header.mu.Unlock()
+			continue
+		}
+		if x.flags.Get(marked) {
+			x.mu.Unlock()
+			s.header.mu.Unlock()
+			continue
+		}
+		x.flags.SetTrue(marked)
+		s.header.mu.Unlock()
+		s.unlinkMarked(x)
+		return entryOforderedDesc(x)
+	}
+}
+
+// PopLast deletes the last entry of the skipmap and returns it.
+// If the skipmap is empty, return nil.
+func (s *OrderedMapDesc[keyT, valueT]) PopLast() goption.O[tuple.T2[keyT, valueT]] {
+	s.enterWrite()
+	defer s.exitWrite()
+	var key keyT
+	for {
+		x := s.lastBefore(key, false, false)
+		if x == nil {
+			return entryOforderedDesc(x)
+		}
+		// No node can be inserted after x without locking it.
+		x.mu.Lock()
+		if x.flags.Get(marked) {
+			x.mu.Unlock()
+			continue
+		}
+		nex := x.atomicLoadNext(0)
+		for nex != nil && nex.flags.Get(marked) {
+			nex = nex.atomicLoadNext(0)
+		}
+		if nex != nil { // x is no longer the last one
+			x.mu.Unlock()
+			continue
+		}
+		x.flags.SetTrue(marked)
+		s.unlinkMarked(x)
+		return entryOforderedDesc(x)
+	}
+}
+
+// unlinkMarked accomplishes the physical deletion of node x,
+// which has been locked and marked by the caller.
+// (Modified from Delete)
+func (s *OrderedMapDesc[keyT, valueT]) unlinkMarked(x *orderednodeDesc[keyT, valueT]) {
+	var (
+		topLayer     = int(x.level) - 1
+		preds, succs [maxLevel]*orderednodeDesc[keyT, valueT]
+	)
+	for {
+		s.findNodeDelete(x.key, &preds, &succs)
+		var (
+			highestLocked        = -1 // the highest level being locked by this process
+			valid                = true
+			pred, succ, prevPred *orderednodeDesc[keyT, valueT]
+		)
+		for layer := 0; valid && (layer <= topLayer); layer++ {
+			pred, succ = preds[layer], succs[layer]
+			if pred != prevPred { // the node in this layer could be locked by previous loop
+				pred.mu.Lock()
+				highestLocked = layer
+				prevPred = pred
+			}
+			valid = !pred.flags.Get(marked) && pred.atomicLoadNext(layer) == succ
+		}
+		if !valid {
+			unlockorderedDesc(preds, highestLocked)
+			continue
+		}
+		for i := topLayer; i >= 0; i-- {
+			preds[i].atomicStoreNext(i, x.loadNext(i))
+		}
+		x.mu.Unlock()
+		unlockorderedDesc(preds, highestLocked)
+		atomic.AddInt64(&s.length, -1)
+		return
+	}
+}
+
 // ToMap converts this skipmap into a map.
 func (s *OrderedMapDesc[keyT, valueT]) ToMap() map[keyT]valueT {
 	m := make(map[keyT]valueT, s.Len())
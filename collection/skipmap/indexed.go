@@ -0,0 +1,336 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"sync"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// IndexedMap is a sorted map based on skip list which supports order
+// statistics: [IndexedMap.Rank], [IndexedMap.At] and [IndexedMap.CountBetween]
+// run in O(log n).
+//
+// Each link of skip list records its span (the number of entries it skips),
+// which can not be maintained by fine-grained locks, so all operations are
+// protected by a read-write mutex. Use [OrderedMap] if order statistics are
+// not needed, it scales better under concurrent writes.
+type IndexedMap[keyT any, valueT any] struct {
+	mu     sync.RWMutex
+	header *indexedNode[keyT, valueT]
+	level  int // current number of levels
+	length int
+	less   func(a, b keyT) bool
+	levels *levelGenerator // nil means the default
+}
+
+type indexedNode[keyT any, valueT any] struct {
+	key   keyT
+	value valueT
+	next  []indexedLink[keyT, valueT]
+}
+
+// indexedLink is a link to the next node in a level.
+type indexedLink[keyT any, valueT any] struct {
+	node *indexedNode[keyT, valueT]
+	span int // number of level 0 steps to node
+}
+
+// NewIndexed returns an empty indexed skipmap in ascending order.
+func NewIndexed[keyT constraints.Ordered, valueT any]() *IndexedMap[keyT, valueT] {
+	return NewIndexedFunc[keyT, valueT](func(a, b keyT) bool { return a < b })
+}
+
+// NewIndexedDesc returns an empty indexed skipmap in descending order.
+func NewIndexedDesc[keyT constraints.Ordered, valueT any]() *IndexedMap[keyT, valueT] {
+	return NewIndexedFunc[keyT, valueT](func(a, b keyT) bool { return a > b })
+}
+
+// NewIndexedFunc returns an empty indexed skipmap in ascending order
+// defined by less.
+//
+// Note that the less function requires a strict weak ordering,
+// see https://en.wikipedia.org/wiki/Weak_ordering#Strict_weak_orderings,
+// or undefined behavior will happen.
+func NewIndexedFunc[keyT any, valueT any](less func(a, b keyT) bool) *IndexedMap[keyT, valueT] {
+	return &IndexedMap[keyT, valueT]{
+		header: &indexedNode[keyT, valueT]{next: make([]indexedLink[keyT, valueT], maxLevel)},
+		level:  1,
+		less:   less,
+	}
+}
+
+// NewIndexedWithOptions is a variant of [NewIndexed], returns an empty indexed
+// skipmap in ascending order with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedWithOptions[keyT constraints.Ordered, valueT any](opts Options) *IndexedMap[keyT, valueT] {
+	s := NewIndexed[keyT, valueT]()
+	s.levels = newLevelGenerator(opts)
+	return s
+}
+
+// NewIndexedDescWithOptions is a variant of [NewIndexedDesc], returns an empty
+// indexed skipmap in descending order with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedDescWithOptions[keyT constraints.Ordered, valueT any](opts Options) *IndexedMap[keyT, valueT] {
+	s := NewIndexedDesc[keyT, valueT]()
+	s.levels = newLevelGenerator(opts)
+	return s
+}
+
+// NewIndexedFuncWithOptions is a variant of [NewIndexedFunc], returns an empty
+// indexed skipmap in ascending order defined by less with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedFuncWithOptions[keyT any, valueT any](less func(a, b keyT) bool, opts Options) *IndexedMap[keyT, valueT] {
+	s := NewIndexedFunc[keyT, valueT](less)
+	s.levels = newLevelGenerator(opts)
+	return s
+}
+
+func (s *IndexedMap[keyT, valueT]) equal(a, b keyT) bool {
+	return !s.less(a, b) && !s.less(b, a)
+}
+
+// findNode returns the last node before key in each level, and the rank
+// (1-based position, 0 for header) of them.
+func (s *IndexedMap[keyT, valueT]) findNode(key keyT, update *[maxLevel]*indexedNode[keyT, valueT], rank *[maxLevel]int) {
+	x, r := s.header, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for nex := x.next[i]; nex.node != nil && s.less(nex.node.key, key); nex = x.next[i] {
+			r += nex.span
+			x = nex.node
+		}
+		update[i], rank[i] = x, r
+	}
+}
+
+// Store sets the value for a key.
+func (s *IndexedMap[keyT, valueT]) Store(key keyT, value valueT) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(key, value, false)
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (s *IndexedMap[keyT, valueT]) LoadOrStore(key keyT, value valueT) (actual valueT, loaded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store(key, value, true)
+}
+
+func (s *IndexedMap[keyT, valueT]) store(key keyT, value valueT, keep bool) (actual valueT, loaded bool) {
+	var (
+		update [maxLevel]*indexedNode[keyT, valueT]
+		rank   [maxLevel]int
+	)
+	s.findNode(key, &update, &rank)
+	if x := update[0].next[0].node; x != nil && s.equal(x.key, key) {
+		if !keep {
+			x.value = value
+		}
+		return x.value, true
+	}
+
+	level := s.levels.next()
+	for i := s.level; i < level; i++ {
+		update[i], rank[i] = s.header, 0
+		s.header.next[i].span = s.length
+	}
+	if level > s.level {
+		s.level = level
+	}
+	n := &indexedNode[keyT, valueT]{key: key, value: value, next: make([]indexedLink[keyT, valueT], level)}
+	for i := 0; i < level; i++ {
+		prev := &update[i].next[i]
+		n.next[i] = indexedLink[keyT, valueT]{prev.node, prev.span - (rank[0] - rank[i])}
+		*prev = indexedLink[keyT, valueT]{n, rank[0] - rank[i] + 1}
+	}
+	for i := level; i < s.level; i++ {
+		update[i].next[i].span++
+	}
+	s.length++
+	return value, false
+}
+
+// Load returns the value stored in the map for a key, or zero value if no
+// value is present.
+// The ok result indicates whether value was found in the map.
+func (s *IndexedMap[keyT, valueT]) Load(key keyT) (value valueT, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var (
+		update [maxLevel]*indexedNode[keyT, valueT]
+		rank   [maxLevel]int
+	)
+	s.findNode(key, &update, &rank)
+	if x := update[0].next[0].node; x != nil && s.equal(x.key, key) {
+		return x.value, true
+	}
+	return
+}
+
+// Delete deletes the value for a key.
+func (s *IndexedMap[keyT, valueT]) Delete(key keyT) bool {
+	_, ok := s.LoadAndDelete(key)
+	return ok
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (s *IndexedMap[keyT, valueT]) LoadAndDelete(key keyT) (value valueT, loaded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		update [maxLevel]*indexedNode[keyT, valueT]
+		rank   [maxLevel]int
+	)
+	s.findNode(key, &update, &rank)
+	x := update[0].next[0].node
+	if x == nil || !s.equal(x.key, key) {
+		return
+	}
+	for i := 0; i < s.level; i++ {
+		prev := &update[i].next[i]
+		if prev.node == x {
+			*prev = indexedLink[keyT, valueT]{x.next[i].node, prev.span + x.next[i].span - 1}
+		} else {
+			prev.span--
+		}
+	}
+	for s.level > 1 && s.header.next[s.level-1].node == nil {
+		s.level--
+	}
+	s.length--
+	return x.value, true
+}
+
+// Len returns the length of this skipmap.
+func (s *IndexedMap[keyT, valueT]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.length
+}
+
+// Range calls f sequentially for each key and value present in the skipmap.
+// If f returns false, range stops the iteration.
+//
+// The lock is not held while calling f, so f can modify the skipmap.
+// Like [OrderedMap.Range], it does not necessarily correspond to any
+// consistent snapshot of the map's contents.
+func (s *IndexedMap[keyT, valueT]) Range(f func(key keyT, value valueT) bool) {
+	const batchSize = 64
+	batch := make([]tuple.T2[keyT, valueT], 0, batchSize)
+	for {
+		// Entries may be inserted or deleted between batches,
+		// so we locate the next batch by key instead of by rank.
+		s.mu.RLock()
+		var x *indexedNode[keyT, valueT]
+		if len(batch) == 0 {
+			x = s.header.next[0].node
+		} else {
+			x = s.ceiling(batch[len(batch)-1].First, false)
+		}
+		batch = batch[:0]
+		for ; x != nil && len(batch) < batchSize; x = x.next[0].node {
+			batch = append(batch, tuple.Make2(x.key, x.value))
+		}
+		s.mu.RUnlock()
+
+		for _, e := range batch {
+			if !f(e.First, e.Second) {
+				return
+			}
+		}
+		if len(batch) < batchSize {
+			return
+		}
+	}
+}
+
+// ceiling returns the first node after (or equal to, if inclusive) key.
+func (s *IndexedMap[keyT, valueT]) ceiling(key keyT, inclusive bool) *indexedNode[keyT, valueT] {
+	x := s.header
+	for i := s.level - 1; i >= 0; i-- {
+		for nex := x.next[i].node; nex != nil && (s.less(nex.key, key) || !inclusive && !s.less(key, nex.key)); nex = x.next[i].node {
+			x = nex
+		}
+	}
+	return x.next[0].node
+}
+
+// countBefore returns the number of keys before (or equal to, if inclusive) key.
+func (s *IndexedMap[keyT, valueT]) countBefore(key keyT, inclusive bool) int {
+	x, r := s.header, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for nex := x.next[i]; nex.node != nil && (s.less(nex.node.key, key) || inclusive && !s.less(key, nex.node.key)); nex = x.next[i] {
+			r += nex.span
+			x = nex.node
+		}
+	}
+	return r
+}
+
+// Rank returns the 0-based position of key in the order of skipmap,
+// and whether the key is present.
+// If the key is not present, the position is where it would be inserted,
+// which is the number of keys before it.
+// The complexity is O(log n).
+func (s *IndexedMap[keyT, valueT]) Rank(key keyT) (rank int, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rank = s.countBefore(key, false)
+	return rank, s.countBefore(key, true) > rank
+}
+
+// At returns the entry at 0-based position i in the order of skipmap.
+// If i is out of range, return nil.
+// The complexity is O(log n).
+func (s *IndexedMap[keyT, valueT]) At(i int) goption.O[tuple.T2[keyT, valueT]] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i < 0 || i >= s.length {
+		return goption.Nil[tuple.T2[keyT, valueT]]()
+	}
+	x, r := s.header, 0
+	for l := s.level - 1; l >= 0; l-- {
+		for nex := x.next[l]; nex.node != nil && r+nex.span <= i+1; nex = x.next[l] {
+			r += nex.span
+			x = nex.node
+		}
+	}
+	return goption.OK(tuple.Make2(x.key, x.value))
+}
+
+// CountBetween returns the number of keys between lo and hi in the order of
+// skipmap, loInclusive and hiInclusive control whether lo and hi themselves
+// are counted.
+// The complexity is O(log n).
+func (s *IndexedMap[keyT, valueT]) CountBetween(lo, hi keyT, loInclusive, hiInclusive bool) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := s.countBefore(hi, hiInclusive) - s.countBefore(lo, !loInclusive)
+	if n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+// checkIndexed verifies spans of all levels.
+func checkIndexed[K, V any](t *testing.T, s *IndexedMap[K, V]) {
+	pos := map[*indexedNode[K, V]]int{s.header: 0}
+	i := 0
+	for x := s.header.next[0].node; x != nil; x = x.next[0].node {
+		i++
+		pos[x] = i
+	}
+	assert.Equal(t, s.length, i)
+	for l := 0; l < s.level; l++ {
+		for x := s.header; x != nil; x = x.next[l].node {
+			if x.next[l].node != nil {
+				assert.Equal(t, pos[x.next[l].node]-pos[x], x.next[l].span)
+			}
+		}
+	}
+}
+
+func TestIndexed(t *testing.T) {
+	m := NewIndexed[int, string]()
+	assert.Equal(t, 0, m.Len())
+	assert.False(t, m.At(0).IsOK())
+	assert.Equal(t, tuple.Make2(0, false), tuple.Make2(m.Rank(1)))
+	assert.Equal(t, 0, m.CountBetween(0, 10, true, true))
+
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		m.Store(k, "")
+	}
+	m.Store(30, "thirty")
+	assert.Equal(t, 5, m.Len())
+	assert.Equal(t, tuple.Make2("thirty", true), tuple.Make2(m.Load(30)))
+	assert.Equal(t, tuple.Make2("thirty", true), tuple.Make2(m.LoadOrStore(30, "x")))
+	assert.Equal(t, tuple.Make2("x", false), tuple.Make2(m.LoadOrStore(35, "x")))
+	assert.True(t, m.Delete(35))
+	assert.False(t, m.Delete(35))
+
+	assert.Equal(t, tuple.Make2(2, true), tuple.Make2(m.Rank(30)))
+	assert.Equal(t, tuple.Make2(3, false), tuple.Make2(m.Rank(35)))
+	assert.Equal(t, tuple.Make2(0, false), tuple.Make2(m.Rank(0)))
+	assert.Equal(t, tuple.Make2(5, false), tuple.Make2(m.Rank(60)))
+	assert.Equal(t, tuple.Make2(30, "thirty"), m.At(2).Value())
+	assert.False(t, m.At(5).IsOK())
+	assert.False(t, m.At(-1).IsOK())
+
+	assert.Equal(t, 3, m.CountBetween(20, 40, true, true))
+	assert.Equal(t, 1, m.CountBetween(20, 40, false, false))
+	assert.Equal(t, 2, m.CountBetween(15, 40, true, false))
+	assert.Equal(t, 0, m.CountBetween(40, 20, true, true))
+
+	assert.Equal(t, tuple.Make2("", true), tuple.Make2(m.LoadAndDelete(10)))
+	assert.Equal(t, 20, m.At(0).Value().First)
+	checkIndexed(t, m)
+
+	d := NewIndexedDesc[int, int]()
+	for i := 0; i < 10; i++ {
+		d.Store(i, i)
+	}
+	assert.Equal(t, 9, d.At(0).Value().First)
+	assert.Equal(t, tuple.Make2(7, true), tuple.Make2(d.Rank(2)))
+	assert.Equal(t, 4, d.CountBetween(8, 5, true, true))
+}
+
+func TestIndexedOptions(t *testing.T) {
+	levels := func(s *IndexedMap[int, int]) []int {
+		var res []int
+		for x := s.header.next[0].node; x != nil; x = x.next[0].node {
+			res = append(res, len(x.next))
+		}
+		return res
+	}
+	newmap := func(seed uint64) *IndexedMap[int, int] {
+		s := NewIndexedWithOptions[int, int](Options{Seed: seed})
+		for i := 0; i < 1000; i++ {
+			s.Store(i, i)
+		}
+		checkIndexed(t, s)
+		return s
+	}
+	s1, s2, s3 := newmap(42), newmap(42), newmap(43)
+	assert.Equal(t, levels(s1), levels(s2))
+	assert.NotEqual(t, levels(s1), levels(s3))
+
+	d := NewIndexedDescWithOptions[int, int](Options{MaxLevel: 2, P: 0.9})
+	f := NewIndexedFuncWithOptions[int, int](func(a, b int) bool { return a < b }, Options{MaxLevel: 1})
+	for i := 0; i < 100; i++ {
+		d.Store(i, i)
+		f.Store(i, i)
+	}
+	checkIndexed(t, d)
+	checkIndexed(t, f)
+	assert.Equal(t, 2, d.level)
+	assert.Equal(t, 1, f.level)
+	assert.Equal(t, tuple.Make2(99, 99), d.At(0).Value())
+
+	assert.Panic(t, func() { NewIndexedWithOptions[int, int](Options{P: 1}) })
+}
+
+func TestIndexedRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewIndexedFunc[int, int](func(a, b int) bool { return a < b })
+	ref := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			_, ok := ref[k]
+			assert.Equal(t, ok, m.Delete(k))
+			delete(ref, k)
+		} else {
+			m.Store(k, i)
+			ref[k] = i
+		}
+	}
+	checkIndexed(t, m)
+
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, len(keys), m.Len())
+	for i, k := range keys {
+		assert.Equal(t, tuple.Make2(k, ref[k]), m.At(i).Value())
+		assert.Equal(t, tuple.Make2(i, true), tuple.Make2(m.Rank(k)))
+	}
+	for i := 0; i < 100; i++ {
+		lo, hi := r.Intn(1100)-50, r.Intn(1100)-50
+		loIn, hiIn := r.Intn(2) == 0, r.Intn(2) == 0
+		expect := 0
+		for _, k := range keys {
+			if (lo < k || loIn && k == lo) && (k < hi || hiIn && k == hi) {
+				expect++
+			}
+		}
+		assert.Equal(t, expect, m.CountBetween(lo, hi, loIn, hiIn))
+	}
+
+	var got []int
+	m.Range(func(key, value int) bool {
+		got = append(got, key)
+		return true
+	})
+	assert.Equal(t, keys, got)
+	got = got[:0]
+	m.Range(func(key, value int) bool {
+		got = append(got, key)
+		return len(got) < 100
+	})
+	assert.Equal(t, keys[:100], got)
+}
+
+func TestIndexedConcurrent(t *testing.T) {
+	m := NewIndexed[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := g*1000 + i
+				m.Store(k, k)
+				if i%2 == 1 {
+					m.Delete(k - 1)
+				}
+				m.Rank(k)
+				m.At(i)
+				m.CountBetween(0, k, true, true)
+			}
+		}()
+	}
+	// Range can modify the map.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.Range(func(key, value int) bool {
+			m.Store(-key, value)
+			return true
+		})
+	}()
+	wg.Wait()
+	checkIndexed(t, m)
+	for g := 0; g < 8; g++ {
+		for i := 1; i < 1000; i += 2 {
+			k := g*1000 + i
+			r, ok := m.Rank(k)
+			assert.True(t, ok)
+			assert.Equal(t, k, m.At(r).Value().First)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package skipmap
+
+import "iter"
+
+// All returns an iterator over entries of the skipmap in order.
+// It has the same semantics as [OrderedMap.Range].
+func (s *OrderedMap[keyT, valueT]) All() iter.Seq2[keyT, valueT] {
+	return s.Range
+}
+
+// Backward returns an iterator over entries of the skipmap in reverse order.
+// It has the same semantics as [OrderedMap.RangeReverse].
+func (s *OrderedMap[keyT, valueT]) Backward() iter.Seq2[keyT, valueT] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over entries whose key is after or equal to start
+// in order.
+// It has the same semantics as [OrderedMap.RangeFrom].
+func (s *OrderedMap[keyT, valueT]) From(start keyT) iter.Seq2[keyT, valueT] {
+	return func(yield func(keyT, valueT) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
+
+// All returns an iterator over entries of the skipmap in order.
+// It has the same semantics as [OrderedMapDesc.Range].
+func (s *OrderedMapDesc[keyT, valueT]) All() iter.Seq2[keyT, valueT] {
+	return s.Range
+}
+
+// Backward returns an iterator over entries of the skipmap in reverse order.
+// It has the same semantics as [OrderedMapDesc.RangeReverse].
+func (s *OrderedMapDesc[keyT, valueT]) Backward() iter.Seq2[keyT, valueT] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over entries whose key is after or equal to start
+// in order.
+// It has the same semantics as [OrderedMapDesc.RangeFrom].
+func (s *OrderedMapDesc[keyT, valueT]) From(start keyT) iter.Seq2[keyT, valueT] {
+	return func(yield func(keyT, valueT) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
+
+// All returns an iterator over entries of the skipmap in order.
+// It has the same semantics as [FuncMap.Range].
+func (s *FuncMap[keyT, valueT]) All() iter.Seq2[keyT, valueT] {
+	return s.Range
+}
+
+// Backward returns an iterator over entries of the skipmap in reverse order.
+// It has the same semantics as [FuncMap.RangeReverse].
+func (s *FuncMap[keyT, valueT]) Backward() iter.Seq2[keyT, valueT] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over entries whose key is after or equal to start
+// in order.
+// It has the same semantics as [FuncMap.RangeFrom].
+func (s *FuncMap[keyT, valueT]) From(start keyT) iter.Seq2[keyT, valueT] {
+	return func(yield func(keyT, valueT) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package skipmap
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestIter(t *testing.T) {
+	m := New[int, string]()
+	for _, k := range []int{3, 1, 2} {
+		m.Store(k, string(rune('a'+k)))
+	}
+
+	var keys []int
+	for k, v := range m.All() {
+		assert.Equal(t, string(rune('a'+k)), v)
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{1, 2, 3}, keys)
+
+	keys = nil
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{3, 2, 1}, keys)
+
+	keys = nil
+	for k := range m.From(2) {
+		keys = append(keys, k)
+		break
+	}
+	assert.Equal(t, []int{2}, keys)
+
+	d := NewDesc[int, int]()
+	f := NewFunc[int, int](func(a, b int) bool { return a < b })
+	for i := 0; i < 5; i++ {
+		d.Store(i, i)
+		f.Store(i, i)
+	}
+	keys = nil
+	for k := range d.From(2) {
+		keys = append(keys, k)
+	}
+	for k := range d.Backward() {
+		keys = append(keys, k)
+	}
+	for k := range d.All() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{2, 1, 0, 0, 1, 2, 3, 4, 4, 3, 2, 1, 0}, keys)
+	keys = nil
+	for k := range f.From(3) {
+		keys = append(keys, k)
+	}
+	for k := range f.Backward() {
+		keys = append(keys, k)
+	}
+	for k := range f.All() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{3, 4, 4, 3, 2, 1, 0, 0, 1, 2, 3, 4}, keys)
+}
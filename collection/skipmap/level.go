@@ -0,0 +1,118 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/bytedance/gg/internal/fastrand"
+)
+
+// Options is the options of skipmap.
+//
+// The level of a node decides how many levels of index it is linked in,
+// it is 1 with probability 1-P, 2 with probability P*(1-P), and so on,
+// up to MaxLevel.
+type Options struct {
+	// P is the probability of a node being promoted to the next level,
+	// it must be in (0, 1).
+	// Zero means the default value 0.25.
+	P float64
+
+	// MaxLevel is the max level of nodes, it must be in [1, 16].
+	// Zero means the default value 16.
+	MaxLevel int
+
+	// Seed makes levels generated by a deterministic generator seeded by it,
+	// so the shape of skipmap is reproducible if keys are stored by a single
+	// goroutine in the same order.
+	// Zero means levels are generated by the global random generator.
+	Seed uint64
+
+	// ConsistentSnapshot makes Snapshot and Clone consistent by blocking
+	// writers while they are running, at the cost of a shared barrier entered
+	// by every write operation, see [OrderedMap].
+	// It is ignored by [IndexedMap], whose operations are always serialized.
+	ConsistentSnapshot bool
+}
+
+// Stats is the structural statistics of skipmap.
+type Stats struct {
+	// Nodes is the number of nodes.
+	Nodes int
+	// HighestLevel is the highest level of index.
+	HighestLevel int
+	// Levels is the level histogram of nodes,
+	// Levels[i] is the number of nodes whose level is i+1.
+	Levels []int
+	// AvgSearchPath is the average number of nodes visited by searching
+	// each key.
+	AvgSearchPath float64
+}
+
+// levelGenerator generates levels of nodes with options.
+//
+// A nil *levelGenerator generates levels by randomLevel.
+type levelGenerator struct {
+	threshold uint32 // a level is promoted if a random uint32 is less than it
+	maxLevel  int
+	seeded    bool
+	state     uint64 // state of splitmix64 if seeded
+}
+
+func newLevelGenerator(opts Options) *levelGenerator {
+	if opts.P == 0 {
+		opts.P = p
+	}
+	if opts.MaxLevel == 0 {
+		opts.MaxLevel = maxLevel
+	}
+	if !(opts.P > 0 && opts.P < 1) {
+		panic(fmt.Errorf("p must be in (0, 1): %v", opts.P))
+	}
+	if opts.MaxLevel < 1 || opts.MaxLevel > maxLevel {
+		panic(fmt.Errorf("max level must be in [1, %d]: %d", maxLevel, opts.MaxLevel))
+	}
+	return &levelGenerator{
+		threshold: uint32(opts.P * math.MaxUint32),
+		maxLevel:  opts.MaxLevel,
+		seeded:    opts.Seed != 0,
+		state:     opts.Seed,
+	}
+}
+
+func (g *levelGenerator) next() int {
+	if g == nil {
+		return randomLevel()
+	}
+	level := 1
+	for level < g.maxLevel && g.uint32() < g.threshold {
+		level++
+	}
+	return level
+}
+
+func (g *levelGenerator) uint32() uint32 {
+	if !g.seeded {
+		return fastrand.Uint32()
+	}
+	// splitmix64, see https://prng.di.unimi.it/splitmix64.c
+	z := atomic.AddUint64(&g.state, 0x9e3779b97f4a7c15)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return uint32((z ^ (z >> 31)) >> 32)
+}
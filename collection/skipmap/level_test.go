@@ -0,0 +1,108 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func levelsOf[keyT, valueT any](s *FuncMap[keyT, valueT]) []int {
+	var res []int
+	for x := s.header.loadNext(0); x != nil; x = x.loadNext(0) {
+		res = append(res, int(x.level))
+	}
+	return res
+}
+
+func TestOptions(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	// Deterministic with the same seed.
+	newmap := func(seed uint64) *FuncMap[int, int] {
+		s := NewFuncWithOptions[int, int](less, Options{Seed: seed})
+		for i := 0; i < 1000; i++ {
+			s.Store(i, i)
+		}
+		return s
+	}
+	s1, s2, s3 := newmap(42), newmap(42), newmap(43)
+	assert.Equal(t, levelsOf(s1), levelsOf(s2))
+	assert.Equal(t, s1.Stats(), s2.Stats())
+	assert.NotEqual(t, levelsOf(s1), levelsOf(s3))
+
+	// Probability.
+	s := NewWithOptions[int, int](Options{P: 0.5, Seed: 1})
+	for i := 0; i < 10000; i++ {
+		s.Store(i, i)
+	}
+	st := s.Stats()
+	assert.Equal(t, 10000, st.Nodes)
+	assert.True(t, st.Levels[0] > 4500 && st.Levels[0] < 5500)
+	assert.True(t, st.Levels[1] > 2000 && st.Levels[1] < 3000)
+
+	// Max level.
+	d := NewDescWithOptions[int, int](Options{MaxLevel: 2, P: 0.9})
+	for i := 0; i < 1000; i++ {
+		d.Store(i, i)
+	}
+	st = d.Stats()
+	assert.Equal(t, 2, len(st.Levels))
+	assert.Equal(t, 1000, st.Levels[0]+st.Levels[1])
+
+	// Invalid options.
+	assert.Panic(t, func() { NewWithOptions[int, int](Options{P: 1}) })
+	assert.Panic(t, func() { NewWithOptions[int, int](Options{P: -0.5}) })
+	assert.Panic(t, func() { NewWithOptions[int, int](Options{MaxLevel: 17}) })
+	assert.Panic(t, func() { NewWithOptions[int, int](Options{MaxLevel: -1}) })
+}
+
+func TestStats(t *testing.T) {
+	st := New[int, int]().Stats()
+	assert.Equal(t, 0, st.Nodes)
+	assert.Equal(t, defaultHighestLevel, st.HighestLevel)
+	assert.Equal(t, []int{}, st.Levels)
+	assert.Equal(t, 0.0, st.AvgSearchPath)
+
+	// A linked list.
+	s := NewWithOptions[int, int](Options{MaxLevel: 1})
+	for i := 1; i <= 4; i++ {
+		s.Store(i, i)
+	}
+	st = s.Stats()
+	assert.Equal(t, 4, st.Nodes)
+	assert.Equal(t, []int{4}, st.Levels)
+	assert.Equal(t, 2.5, st.AvgSearchPath) // (1+2+3+4)/4
+
+	// Deleted nodes are not counted.
+	s.Delete(4)
+	assert.Equal(t, 2.0, s.Stats().AvgSearchPath)
+
+	// The index makes search path much shorter than a linked list.
+	m := New[int, int]()
+	for i := 0; i < 10000; i++ {
+		m.Store(i, i)
+	}
+	st = m.Stats()
+	assert.Equal(t, 10000, st.Nodes)
+	sum := 0
+	for _, n := range st.Levels {
+		sum += n
+	}
+	assert.Equal(t, 10000, sum)
+	assert.True(t, len(st.Levels) <= st.HighestLevel)
+	assert.True(t, st.AvgSearchPath > 1 && st.AvgSearchPath < 100)
+}
@@ -19,7 +19,20 @@
 //go:generate go run gen.go
 package skipmap
 
-import "github.com/bytedance/gg/internal/constraints"
+import (
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// ComputeOp tells [OrderedMap.Compute] what to do with the entry.
+type ComputeOp int
+
+const (
+	// ComputeKeep stores the computed value for the key.
+	ComputeKeep ComputeOp = iota
+	// ComputeDelete deletes the key, the computed value is ignored.
+	ComputeDelete
+)
 
 // NewFunc returns an empty skipmap in ascending order.
 //
@@ -67,3 +80,66 @@ func NewDesc[keyT constraints.Ordered, valueT any]() *OrderedMapDesc[keyT, value
 		highestLevel: defaultHighestLevel,
 	}
 }
+
+// NewFromSorted returns a skipmap in ascending order which contains entries,
+// the entries must be sorted by key in ascending order, the latter one wins if
+// there are duplicate keys.
+//
+// The skipmap is built in O(n) without locking, which is much faster than
+// storing entries one by one.
+//
+// It panics if entries are not sorted.
+func NewFromSorted[keyT constraints.Ordered, valueT any](entries []tuple.T2[keyT, valueT]) *OrderedMap[keyT, valueT] {
+	s := New[keyT, valueT]()
+	s.buildSorted(entries)
+	return s
+}
+
+// NewDescFromSorted is a variant of [NewFromSorted], returns a skipmap in
+// descending order, the entries must be sorted by key in descending order.
+func NewDescFromSorted[keyT constraints.Ordered, valueT any](entries []tuple.T2[keyT, valueT]) *OrderedMapDesc[keyT, valueT] {
+	s := NewDesc[keyT, valueT]()
+	s.buildSorted(entries)
+	return s
+}
+
+// NewFuncFromSorted is a variant of [NewFromSorted], returns a skipmap in
+// ascending order defined by less, the entries must be sorted by less.
+func NewFuncFromSorted[keyT any, valueT any](less func(a, b keyT) bool, entries []tuple.T2[keyT, valueT]) *FuncMap[keyT, valueT] {
+	s := NewFunc[keyT, valueT](less)
+	s.buildSorted(entries)
+	return s
+}
+
+// NewWithOptions is a variant of [New], returns an empty skipmap in ascending
+// order with opts.
+//
+// It panics if opts is invalid.
+func NewWithOptions[keyT constraints.Ordered, valueT any](opts Options) *OrderedMap[keyT, valueT] {
+	s := New[keyT, valueT]()
+	s.levels = newLevelGenerator(opts)
+	s.consistent = opts.ConsistentSnapshot
+	return s
+}
+
+// NewDescWithOptions is a variant of [NewDesc], returns an empty skipmap in
+// descending order with opts.
+//
+// It panics if opts is invalid.
+func NewDescWithOptions[keyT constraints.Ordered, valueT any](opts Options) *OrderedMapDesc[keyT, valueT] {
+	s := NewDesc[keyT, valueT]()
+	s.levels = newLevelGenerator(opts)
+	s.consistent = opts.ConsistentSnapshot
+	return s
+}
+
+// NewFuncWithOptions is a variant of [NewFunc], returns an empty skipmap in
+// ascending order defined by less with opts.
+//
+// It panics if opts is invalid.
+func NewFuncWithOptions[keyT any, valueT any](less func(a, b keyT) bool, opts Options) *FuncMap[keyT, valueT] {
+	s := NewFunc[keyT, valueT](less)
+	s.levels = newLevelGenerator(opts)
+	s.consistent = opts.ConsistentSnapshot
+	return s
+}
@@ -19,7 +19,9 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/bytedance/gg/collection/tuple"
 	"github.com/bytedance/gg/internal/fastrand"
 )
 
@@ -47,6 +49,115 @@ func BenchmarkStore(b *testing.B) {
 	})
 }
 
+func BenchmarkBuildSorted(b *testing.B) {
+	entries := make([]tuple.T2[int64, any], 1<<16)
+	for i := range entries {
+		entries[i].First = int64(i)
+	}
+	b.Run("Store", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			l := New[int64, any]()
+			for _, e := range entries {
+				l.Store(e.First, e.Second)
+			}
+		}
+	})
+	b.Run("StoreBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			New[int64, any]().StoreBatch(entries)
+		}
+	})
+	b.Run("LoadSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			New[int64, any]().LoadSorted(entries)
+		}
+	})
+	b.Run("NewFromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewFromSorted(entries)
+		}
+	})
+}
+
+// BenchmarkParallelWrite measures the cost of write barrier taken by every
+// write operation if snapshots are consistent, see [Options.ConsistentSnapshot].
+func BenchmarkParallelWrite(b *testing.B) {
+	for _, c := range []struct {
+		name string
+		opts Options
+	}{
+		{"Default", Options{}},
+		{"ConsistentSnapshot", Options{ConsistentSnapshot: true}},
+	} {
+		opts := c.opts
+		b.Run(c.name, func(b *testing.B) {
+			b.Run("Insert", func(b *testing.B) {
+				l := NewWithOptions[int64, any](opts)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						l.Store(int64(fastrand.Uint32n(randN)), nil)
+					}
+				})
+			})
+			b.Run("Update", func(b *testing.B) {
+				l := NewWithOptions[int64, any](opts)
+				for i := 0; i < initsize; i++ {
+					l.Store(int64(i), nil)
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						l.Store(int64(fastrand.Uint32n(initsize)), nil)
+					}
+				})
+			})
+			b.Run("StoreDelete", func(b *testing.B) {
+				l := NewWithOptions[int64, any](opts)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						k := int64(fastrand.Uint32n(initsize))
+						if k&1 == 0 {
+							l.Store(k, nil)
+						} else {
+							l.Delete(k - 1)
+						}
+					}
+				})
+			})
+		})
+	}
+	b.Run("UpdateWithSnapshot", func(b *testing.B) {
+		l := NewWithOptions[int64, any](Options{ConsistentSnapshot: true})
+		for i := 0; i < initsize; i++ {
+			l.Store(int64(i), nil)
+		}
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				select {
+				case <-stop:
+					return
+				case <-time.After(time.Millisecond):
+					l.Snapshot()
+				}
+			}
+		}()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				l.Store(int64(fastrand.Uint32n(initsize)), nil)
+			}
+		})
+		b.StopTimer()
+		close(stop)
+		<-done
+	})
+}
+
 func BenchmarkLoad100Hits(b *testing.B) {
 	b.Run("skipmap", func(b *testing.B) {
 		l := New[int64, any]()
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/bytedance/gg/collection/tuple"
 	"github.com/bytedance/gg/gson"
 )
 
@@ -59,3 +61,219 @@ func Example() {
 	// {"a":11,"b":2,"c":3}
 	// 1000
 }
+
+func ExampleOrderedMap_Floor() {
+	s := New[int, string]()
+	s.Store(10, "ten")
+	s.Store(20, "twenty")
+	s.Store(30, "thirty")
+
+	fmt.Println(s.Floor(25).Value())
+	fmt.Println(s.Ceiling(25).Value())
+	fmt.Println(s.Lower(10).IsOK(), s.Higher(30).IsOK())
+	fmt.Println(s.First().Value(), s.Last().Value())
+
+	fmt.Println(s.PopFirst().Value(), s.Len())
+
+	// Output:
+	// {20 twenty}
+	// {30 thirty}
+	// false false
+	// {10 ten} {30 thirty}
+	// {10 ten} 2
+}
+
+func ExampleOrderedMap_RangeBetween() {
+	s := New[int, string]()
+	for i := 1; i <= 5; i++ {
+		s.Store(i*10, strconv.Itoa(i*10))
+	}
+
+	var keys []int
+	collect := func(key int, value string) bool {
+		keys = append(keys, key)
+		return true
+	}
+
+	// Visit keys in (10, 40].
+	s.RangeBetween(10, 40, false, true, collect)
+	fmt.Println(keys)
+
+	keys = nil
+	s.RangeBetweenReverse(10, 40, true, false, collect)
+	fmt.Println(keys)
+
+	keys = nil
+	s.RangeFrom(35, collect)
+	fmt.Println(keys)
+
+	// Output:
+	// [20 30 40]
+	// [30 20 10]
+	// [40 50]
+}
+
+func ExampleOrderedMap_Compute() {
+	s := New[string, int]()
+
+	incr := func(old int, loaded bool) (int, ComputeOp) {
+		return old + 1, ComputeKeep
+	}
+	s.Compute("a", incr)
+	fmt.Println(s.Compute("a", incr))
+
+	// Delete the key when its value reaches zero.
+	decr := func(old int, loaded bool) (int, ComputeOp) {
+		if old <= 1 {
+			return 0, ComputeDelete
+		}
+		return old - 1, ComputeKeep
+	}
+	fmt.Println(s.Compute("a", decr))
+	fmt.Println(s.Compute("a", decr))
+
+	fmt.Println(s.Swap("b", 1))
+	fmt.Println(s.CompareAndSwap("b", 1, 2))
+	fmt.Println(s.CompareAndDelete("b", 1))
+
+	// Output:
+	// 2 true
+	// 1 true
+	// 0 false
+	// 0 false
+	// true
+	// false
+}
+
+func ExampleNewFromSorted() {
+	// Warm start from a snapshot sorted by key.
+	snapshot := tuple.Zip2([]string{"a", "b", "c"}, []int{1, 2, 3})
+	s := NewFromSorted(snapshot)
+
+	s.StoreBatch([]tuple.T2[string, int]{tuple.Make2("d", 4), tuple.Make2("a", 0)})
+	fmt.Println(s.DeleteBatch([]string{"c", "b", "x"}))
+	fmt.Println(s.ToMap())
+
+	// Output:
+	// 2
+	// map[a:0 d:4]
+}
+
+func ExampleNewWithOptions() {
+	// The shape of skipmap is reproducible with a seed.
+	s := NewWithOptions[int, int](Options{P: 0.5, MaxLevel: 4, Seed: 1})
+	for i := 0; i < 100; i++ {
+		s.Store(i, i)
+	}
+
+	st := s.Stats()
+	fmt.Println(st.Nodes)
+	fmt.Println(st.Levels)
+	fmt.Printf("%.2f\n", st.AvgSearchPath)
+
+	// Output:
+	// 100
+	// [57 13 19 11]
+	// 10.08
+}
+
+func ExampleOrderedMap_Snapshot() {
+	s := NewWithOptions[string, int](Options{ConsistentSnapshot: true})
+	s.Store("a", 1)
+	s.Store("b", 2)
+
+	gen1 := s.Snapshot()
+	s.Store("c", 3)
+	s.Delete("a")
+	gen2 := s.Snapshot()
+	fmt.Println(gen1)
+	fmt.Println(gen2)
+
+	// Restore the first generation.
+	c := NewFromSorted(gen1)
+	fmt.Println(c.ToMap())
+
+	// Output:
+	// [{a 1} {b 2}]
+	// [{b 2} {c 3}]
+	// map[a:1 b:2]
+}
+
+func ExampleIndexedMap() {
+	// A leaderboard ordered by score descending.
+	board := NewIndexedDesc[int, string]()
+	board.Store(90, "alice")
+	board.Store(75, "bob")
+	board.Store(82, "carol")
+
+	fmt.Println(board.Rank(82))
+	fmt.Println(board.At(0).Value())
+	fmt.Println(board.CountBetween(100, 80, true, true))
+
+	// Output:
+	// 1 true
+	// {90 alice}
+	// 2
+}
+
+func ExampleCursor() {
+	s := New[int, string]()
+	for i := 1; i <= 7; i++ {
+		s.Store(i, strconv.Itoa(i))
+	}
+
+	// Paginate with page size 3, each page resumes from the last key.
+	page := func(after int) (keys []int, last int) {
+		c := s.Cursor()
+		c.Seek(after)
+		if c.Valid() && c.Key() == after {
+			c.Next()
+		}
+		for ; c.Valid() && len(keys) < 3; c.Next() {
+			keys = append(keys, c.Key())
+		}
+		if len(keys) > 0 {
+			last = keys[len(keys)-1]
+		}
+		return keys, last
+	}
+	keys, last := page(0)
+	fmt.Println(keys)
+	keys, last = page(last)
+	fmt.Println(keys)
+	keys, _ = page(last)
+	fmt.Println(keys)
+
+	// Output:
+	// [1 2 3]
+	// [4 5 6]
+	// [7]
+}
+
+func ExampleTTLMap() {
+	now := time.Unix(0, 0)
+	m := NewTTL(TTLOptions[string, int]{
+		TTL:      time.Minute,
+		Now:      func() time.Time { return now },
+		OnExpire: func(key string, _ int) { fmt.Println("expired:", key) },
+	})
+	defer m.Stop()
+
+	m.Store("session-1", 1)
+	m.StoreWithTTL("session-2", 2, 2*time.Minute)
+	m.StoreWithTTL("session-3", 3, 0) // never expire
+
+	now = now.Add(time.Minute)
+	fmt.Println(m.Load("session-1"))
+
+	now = now.Add(time.Minute)
+	fmt.Println(m.RemoveExpired())
+	fmt.Println(m.Len())
+
+	// Output:
+	// expired: session-1
+	// 0 false
+	// expired: session-2
+	// 1
+	// 1
+}
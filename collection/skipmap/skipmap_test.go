@@ -16,12 +16,15 @@ package skipmap
 
 import (
 	"encoding/json"
+	"math"
 	"reflect"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
 
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/gcond"
 	"github.com/bytedance/gg/goption"
 	"github.com/bytedance/gg/internal/assert"
 	"github.com/bytedance/gg/internal/constraints"
@@ -686,3 +689,753 @@ func testSkipMapIntUnmarshalJSON[T int | uint](t *testing.T, newset func() anysk
 		}
 	}
 }
+
+type navskipmap[T any] interface {
+	anyskipmap[T]
+	First() goption.O[tuple.T2[T, any]]
+	Last() goption.O[tuple.T2[T, any]]
+	Floor(key T) goption.O[tuple.T2[T, any]]
+	Lower(key T) goption.O[tuple.T2[T, any]]
+	Ceiling(key T) goption.O[tuple.T2[T, any]]
+	Higher(key T) goption.O[tuple.T2[T, any]]
+	PopFirst() goption.O[tuple.T2[T, any]]
+	PopLast() goption.O[tuple.T2[T, any]]
+}
+
+func TestNavigation(t *testing.T) {
+	testSkipMapNavigation(t, func() navskipmap[int] { return New[int, any]() }, false)
+	testSkipMapNavigation(t, func() navskipmap[int] { return NewDesc[int, any]() }, true)
+	testSkipMapNavigation(t, func() navskipmap[int] { return NewFunc[int, any](func(a, b int) bool { return a < b }) }, false)
+	testSkipMapNavigation(t, func() navskipmap[int] { return NewFunc[int, any](func(a, b int) bool { return a > b }) }, true)
+	testSkipMapPopConcurrent(t, func() navskipmap[int] { return New[int, any]() }, false)
+	testSkipMapPopConcurrent(t, func() navskipmap[int] { return NewDesc[int, any]() }, true)
+	testSkipMapPopConcurrent(t, func() navskipmap[int] { return NewFunc[int, any](func(a, b int) bool { return a < b }) }, false)
+}
+
+func keyOf[T any](o goption.O[tuple.T2[T, any]]) goption.O[T] {
+	if !o.IsOK() {
+		return goption.Nil[T]()
+	}
+	return goption.OK(o.Value().First)
+}
+
+func testSkipMapNavigation(t *testing.T, newmap func() navskipmap[int], desc bool) {
+	m := newmap()
+	assert.False(t, m.First().IsOK())
+	assert.False(t, m.Last().IsOK())
+	assert.False(t, m.Floor(1).IsOK())
+	assert.False(t, m.Ceiling(1).IsOK())
+	assert.False(t, m.PopFirst().IsOK())
+	assert.False(t, m.PopLast().IsOK())
+
+	// Keys: 0, 10, 20, ..., 90
+	for i := 0; i < 10; i++ {
+		m.Store(i*10, strconv.Itoa(i*10))
+	}
+	// before and after are in the order of skipmap.
+	before, after := func(a, b int) bool { return a < b }, func(a, b int) bool { return a > b }
+	first, last := 0, 90
+	if desc {
+		before, after = after, before
+		first, last = last, first
+	}
+	assert.Equal(t, tuple.Make2[int, any](first, strconv.Itoa(first)), m.First().Value())
+	assert.Equal(t, tuple.Make2[int, any](last, strconv.Itoa(last)), m.Last().Value())
+
+	find := func(pred func(k int) bool, fromLast bool) goption.O[int] {
+		res := goption.Nil[int]()
+		for i := 0; i < 10; i++ {
+			k := i * 10
+			if !pred(k) {
+				continue
+			}
+			if !res.IsOK() || (fromLast && after(k, res.Value())) || (!fromLast && before(k, res.Value())) {
+				res = goption.OK(k)
+			}
+		}
+		return res
+	}
+	for key := -5; key <= 95; key += 5 {
+		assert.Equal(t, find(func(k int) bool { return !after(k, key) }, true), keyOf(m.Floor(key)))
+		assert.Equal(t, find(func(k int) bool { return before(k, key) }, true), keyOf(m.Lower(key)))
+		assert.Equal(t, find(func(k int) bool { return !before(k, key) }, false), keyOf(m.Ceiling(key)))
+		assert.Equal(t, find(func(k int) bool { return after(k, key) }, false), keyOf(m.Higher(key)))
+	}
+	assert.Equal(t, "50", m.Floor(50).Value().Second)
+
+	// Deleted keys are skipped.
+	m.Delete(50)
+	assert.Equal(t, find(func(k int) bool { return k != 50 && !after(k, 50) }, true), keyOf(m.Floor(50)))
+	assert.Equal(t, find(func(k int) bool { return k != 50 && !before(k, 50) }, false), keyOf(m.Ceiling(50)))
+
+	assert.Equal(t, goption.OK(first), keyOf(m.PopFirst()))
+	assert.Equal(t, goption.OK(last), keyOf(m.PopLast()))
+	assert.Equal(t, 7, m.Len())
+	_, ok := m.Load(first)
+	assert.False(t, ok)
+	for m.Len() > 0 {
+		k := keyOf(m.First())
+		assert.Equal(t, k, keyOf(m.PopFirst()))
+	}
+	assert.False(t, m.PopLast().IsOK())
+	assert.False(t, m.First().IsOK())
+}
+
+// testSkipMapPopConcurrent checks that every key is popped at most once,
+// and keys popped from the same end by a goroutine are in order.
+func testSkipMapPopConcurrent(t *testing.T, newmap func() navskipmap[int], desc bool) {
+	const n = 10000
+	m := newmap()
+	for i := 0; i < n; i++ {
+		m.Store(i, i)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		popped = make([]int32, 2*n)
+		count  int64
+	)
+	// Keys in [n, 2n) are stored while popping.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := n; i < 2*n; i++ {
+			m.Store(i, i)
+		}
+	}()
+	for g := 0; g < 8; g++ {
+		fromFirst := g%2 == 0
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pop := m.PopLast
+			if fromFirst {
+				pop = m.PopFirst
+			}
+			prev := -1
+			for i := 0; i < n/8; i++ {
+				e := pop()
+				if !e.IsOK() {
+					continue
+				}
+				k := e.Value().First
+				atomic.AddInt32(&popped[k], 1)
+				atomic.AddInt64(&count, 1)
+				// Stored keys are all after popped ones from the first end.
+				if fromFirst != desc && k < n {
+					if prev > k {
+						t.Errorf("popped %d after %d", k, prev)
+					}
+					prev = k
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for k, c := range popped {
+		if c > 1 {
+			t.Fatalf("key %d is popped %d times", k, c)
+		}
+		if _, ok := m.Load(k); ok == (c == 1) {
+			t.Fatalf("key %d is popped %d times but present: %v", k, c, ok)
+		}
+	}
+	assert.Equal(t, 2*n-int(count), m.Len())
+}
+
+type rangeskipmap[T any] interface {
+	anyskipmap[T]
+	RangeFrom(start T, f func(key T, value any) bool)
+	RangeBetween(lo, hi T, loInclusive, hiInclusive bool, f func(key T, value any) bool)
+	RangeReverse(f func(key T, value any) bool)
+	RangeBetweenReverse(lo, hi T, loInclusive, hiInclusive bool, f func(key T, value any) bool)
+}
+
+func TestRangeBounded(t *testing.T) {
+	testSkipMapRangeBounded(t, func() rangeskipmap[int] { return New[int, any]() }, false)
+	testSkipMapRangeBounded(t, func() rangeskipmap[int] { return NewDesc[int, any]() }, true)
+	testSkipMapRangeBounded(t, func() rangeskipmap[int] { return NewFunc[int, any](func(a, b int) bool { return a < b }) }, false)
+	testSkipMapRangeBounded(t, func() rangeskipmap[int] { return NewFunc[int, any](func(a, b int) bool { return a > b }) }, true)
+
+	// Concurrent modifications: keys are visited in order and at most once.
+	m := New[int, any]()
+	for i := 0; i < 1000; i++ {
+		m.Store(i, i)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if i%2 == 0 {
+				m.Delete(i)
+			} else {
+				m.Store(1000+i, 1000+i)
+			}
+		}
+	}()
+	for r := 0; r < 10; r++ {
+		prev := math.MaxInt
+		m.RangeReverse(func(key int, _ any) bool {
+			if key >= prev {
+				t.Fatalf("visited %d after %d", key, prev)
+			}
+			prev = key
+			return true
+		})
+		prev = -1
+		m.RangeBetween(100, 1500, true, false, func(key int, _ any) bool {
+			if key <= prev || key < 100 || key >= 1500 {
+				t.Fatalf("visited %d after %d", key, prev)
+			}
+			prev = key
+			return true
+		})
+	}
+	wg.Wait()
+}
+
+func testSkipMapRangeBounded(t *testing.T, newmap func() rangeskipmap[int], desc bool) {
+	collect := func(r func(f func(key int, value any) bool)) []int {
+		res := []int{}
+		r(func(key int, value any) bool {
+			assert.Equal(t, any(key), value)
+			res = append(res, key)
+			return true
+		})
+		return res
+	}
+	reverse := func(s []int) []int {
+		res := make([]int, len(s))
+		for i, v := range s {
+			res[len(s)-1-i] = v
+		}
+		return res
+	}
+
+	m := newmap()
+	assert.Equal(t, []int{}, collect(m.RangeReverse))
+	assert.Equal(t, []int{}, collect(func(f func(int, any) bool) { m.RangeFrom(0, f) }))
+
+	// Keys: 0, 2, 4, ..., 98
+	for i := 0; i < 50; i++ {
+		m.Store(i*2, i*2)
+	}
+	all := collect(m.Range)
+	assert.Equal(t, 50, len(all))
+	assert.Equal(t, reverse(all), collect(m.RangeReverse))
+
+	// before reports whether a is before b in the order of skipmap.
+	before := func(a, b int) bool { return orderLess(a, b, desc) }
+	for _, lo := range []int{-1, 0, 1, 10, 11, 50, 98, 99} {
+		var expect []int
+		for _, k := range all {
+			if !before(k, lo) {
+				expect = append(expect, k)
+			}
+		}
+		if expect == nil {
+			expect = []int{}
+		}
+		assert.Equal(t, expect, collect(func(f func(int, any) bool) { m.RangeFrom(lo, f) }))
+
+		for _, hi := range []int{-1, 0, 10, 11, 50, 98, 99} {
+			for _, loIn := range []bool{true, false} {
+				for _, hiIn := range []bool{true, false} {
+					expect := []int{}
+					for _, k := range all {
+						if (before(lo, k) || loIn && k == lo) && (before(k, hi) || hiIn && k == hi) {
+							expect = append(expect, k)
+						}
+					}
+					assert.Equal(t, expect, collect(func(f func(int, any) bool) { m.RangeBetween(lo, hi, loIn, hiIn, f) }))
+					assert.Equal(t, reverse(expect), collect(func(f func(int, any) bool) { m.RangeBetweenReverse(lo, hi, loIn, hiIn, f) }))
+				}
+			}
+		}
+	}
+
+	// Stop early.
+	var got []int
+	m.RangeBetweenReverse(all[0], all[49], true, true, func(key int, _ any) bool {
+		got = append(got, key)
+		return len(got) < 3
+	})
+	assert.Equal(t, []int{all[49], all[48], all[47]}, got)
+	got = nil
+	m.RangeFrom(all[10], func(key int, _ any) bool {
+		got = append(got, key)
+		return len(got) < 2
+	})
+	assert.Equal(t, []int{all[10], all[11]}, got)
+
+	// Deleted keys are skipped.
+	m.Delete(all[1])
+	m.Delete(all[48])
+	assert.Equal(t, []int{all[0], all[2]}, collect(func(f func(int, any) bool) { m.RangeBetween(all[0], all[2], true, true, f) }))
+	assert.Equal(t, []int{all[49], all[47]}, collect(func(f func(int, any) bool) { m.RangeBetweenReverse(all[47], all[49], true, true, f) }))
+}
+
+// orderLess reports whether a is before b in ascending or descending order.
+func orderLess(a, b int, desc bool) bool {
+	if desc {
+		return a > b
+	}
+	return a < b
+}
+
+type atomicskipmap[T any] interface {
+	Load(key T) (int, bool)
+	Store(key T, value int)
+	Delete(key T) bool
+	Len() int
+	Compute(key T, f func(old int, loaded bool) (int, ComputeOp)) (int, bool)
+	Swap(key T, value int) (int, bool)
+	CompareAndSwap(key T, old, new int) bool
+	CompareAndSwapFunc(key T, old, new int, eq func(a, b int) bool) bool
+	CompareAndDelete(key T, old int) bool
+	CompareAndDeleteFunc(key T, old int, eq func(a, b int) bool) bool
+}
+
+func TestAtomic(t *testing.T) {
+	testSkipMapAtomic(t, func() atomicskipmap[int] { return New[int, int]() })
+	testSkipMapAtomic(t, func() atomicskipmap[int] { return NewDesc[int, int]() })
+	testSkipMapAtomic(t, func() atomicskipmap[int] { return NewFunc[int, int](func(a, b int) bool { return a < b }) })
+
+	m := New[int, []int]()
+	m.Store(1, []int{1})
+	assert.Panic(t, func() { m.CompareAndSwap(1, nil, nil) })
+	assert.True(t, m.CompareAndSwapFunc(1, []int{1}, []int{2}, func(a, b []int) bool { return reflect.DeepEqual(a, b) }))
+	assert.Equal(t, []int{2}, goption.Of(m.Load(1)).Value())
+}
+
+func testSkipMapAtomic(t *testing.T, newmap func() atomicskipmap[int]) {
+	m := newmap()
+	incr := func(old int, _ bool) (int, ComputeOp) { return old + 1, ComputeKeep }
+	del := func(old int, _ bool) (int, ComputeOp) { return 0, ComputeDelete }
+
+	// Compute inserts, updates and deletes.
+	assert.Equal(t, tuple.Make2(1, true), tuple.Make2(m.Compute(1, incr)))
+	assert.Equal(t, tuple.Make2(2, true), tuple.Make2(m.Compute(1, incr)))
+	assert.Equal(t, tuple.Make2(0, false), tuple.Make2(m.Compute(1, del)))
+	assert.Equal(t, tuple.Make2(0, false), tuple.Make2(m.Compute(1, del)))
+	assert.Equal(t, 0, m.Len())
+	m.Compute(2, func(old int, loaded bool) (int, ComputeOp) {
+		assert.Equal(t, 0, old)
+		assert.False(t, loaded)
+		return 20, ComputeKeep
+	})
+	m.Compute(2, func(old int, loaded bool) (int, ComputeOp) {
+		assert.Equal(t, 20, old)
+		assert.True(t, loaded)
+		return 0, ComputeKeep
+	})
+	assert.Equal(t, tuple.Make2(0, true), tuple.Make2(m.Load(2)))
+	assert.Equal(t, 1, m.Len())
+
+	// Swap.
+	assert.Equal(t, tuple.Make2(0, true), tuple.Make2(m.Swap(2, 200)))
+	assert.Equal(t, tuple.Make2(0, false), tuple.Make2(m.Swap(3, 300)))
+	assert.Equal(t, tuple.Make2(300, true), tuple.Make2(m.Load(3)))
+	assert.Equal(t, 2, m.Len())
+
+	// CompareAndSwap and CompareAndDelete.
+	assert.False(t, m.CompareAndSwap(2, 0, 1))
+	assert.True(t, m.CompareAndSwap(2, 200, 201))
+	assert.False(t, m.CompareAndSwap(4, 0, 1))
+	assert.Equal(t, tuple.Make2(201, true), tuple.Make2(m.Load(2)))
+	mod10 := func(a, b int) bool { return a%10 == b%10 }
+	assert.True(t, m.CompareAndSwapFunc(2, 1, 202, mod10))
+	assert.False(t, m.CompareAndDelete(2, 201))
+	assert.False(t, m.CompareAndDelete(4, 0))
+	assert.True(t, m.CompareAndDelete(2, 202))
+	assert.False(t, m.CompareAndDeleteFunc(3, 1, mod10))
+	assert.True(t, m.CompareAndDeleteFunc(3, 0, mod10))
+	assert.Equal(t, 0, m.Len())
+
+	// Concurrent counters.
+	const (
+		goroutines = 8
+		rounds     = 1000
+		keys       = 10
+	)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				k := i % keys
+				if g%2 == 0 {
+					m.Compute(k, incr)
+					continue
+				}
+				// CAS loop, fall back to Compute if absent.
+				for {
+					old, ok := m.Load(k)
+					if !ok {
+						m.Compute(k, incr)
+						break
+					}
+					if m.CompareAndSwap(k, old, old+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for k := 0; k < keys; k++ {
+		v, _ := m.Load(k)
+		assert.Equal(t, goroutines*rounds/keys, v)
+	}
+
+	// Concurrent delete and reinsert: each value is deleted at most once.
+	var deleted int64
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if m.CompareAndDelete(i%keys, goroutines*rounds/keys) {
+					atomic.AddInt64(&deleted, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(keys), deleted)
+}
+
+type batchskipmap[T any] interface {
+	Load(key T) (int, bool)
+	Store(key T, value int)
+	Delete(key T) bool
+	Len() int
+	Range(f func(key T, value int) bool)
+	StoreBatch(entries []tuple.T2[T, int])
+	LoadSorted(entries []tuple.T2[T, int])
+	DeleteBatch(keys []T) int
+}
+
+func TestBatch(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	testSkipMapBatch(t, func(entries []tuple.T2[int, int]) batchskipmap[int] { return NewFromSorted(entries) }, false)
+	testSkipMapBatch(t, func(entries []tuple.T2[int, int]) batchskipmap[int] { return NewDescFromSorted(entries) }, true)
+	testSkipMapBatch(t, func(entries []tuple.T2[int, int]) batchskipmap[int] { return NewFuncFromSorted(less, entries) }, false)
+
+	// Works with tuple.S2.
+	m := NewFromSorted(tuple.Zip2([]string{"a", "b", "b"}, []int{1, 2, 3}))
+	assert.Equal(t, map[string]int{"a": 1, "b": 3}, m.ToMap())
+	checkTowers(t, m)
+
+	keys := make([]int, 10000)
+	for i := range keys {
+		keys[i] = i
+	}
+	om := NewFromSorted(tuple.Zip2(keys, keys))
+	checkTowers(t, om)
+	assert.Equal(t, 5000, om.DeleteBatch(keys[5000:]))
+	om.StoreBatch(tuple.Zip2(keys[7000:], keys[7000:]))
+	checkTowers(t, om)
+	assert.Equal(t, 8000, om.Len())
+
+	assert.Panic(t, func() { NewFromSorted(tuple.Zip2([]int{1, 3, 2}, []int{0, 0, 0})) })
+	assert.Panic(t, func() { NewDescFromSorted(tuple.Zip2([]int{1, 2}, []int{0, 0})) })
+	assert.Panic(t, func() { New[int, int]().LoadSorted(tuple.Zip2([]int{2, 1}, []int{0, 0})) })
+}
+
+func testSkipMapBatch(t *testing.T, newmap func(entries []tuple.T2[int, int]) batchskipmap[int], desc bool) {
+	collect := func(m batchskipmap[int]) []tuple.T2[int, int] {
+		res := []tuple.T2[int, int]{}
+		m.Range(func(key, value int) bool {
+			res = append(res, tuple.Make2(key, value))
+			return true
+		})
+		return res
+	}
+
+	// Build from sorted entries.
+	var entries, expected []tuple.T2[int, int]
+	for i := 0; i < 1000; i++ {
+		key := i
+		if desc {
+			key = 1000 - i
+		}
+		entries = append(entries, tuple.Make2(key, i))
+		if i%10 == 0 { // duplicate key, the latter one wins
+			entries = append(entries, tuple.Make2(key, -i))
+			expected = append(expected, tuple.Make2(key, -i))
+		} else {
+			expected = append(expected, tuple.Make2(key, i))
+		}
+	}
+	assert.Equal(t, []tuple.T2[int, int]{}, collect(newmap(nil)))
+	m := newmap(entries)
+	assert.Equal(t, 1000, m.Len())
+	assert.Equal(t, expected, collect(m))
+	for _, e := range expected {
+		assert.Equal(t, e.Second, goption.Of(m.Load(e.First)).Value())
+	}
+	// The built skipmap is fully functional.
+	for i := 0; i < 1000; i += 2 {
+		assert.True(t, m.Delete(expected[i].First))
+	}
+	m.Store(-1, -1)
+	m.Store(2000, 2000)
+	assert.Equal(t, 502, m.Len())
+	_, ok := m.Load(expected[0].First)
+	assert.False(t, ok)
+	assert.Equal(t, expected[1].Second, goption.Of(m.Load(expected[1].First)).Value())
+
+	// Batch operations with unsorted keys.
+	m = newmap(nil)
+	ref := map[int]int{}
+	for round := 0; round < 20; round++ {
+		var batch []tuple.T2[int, int]
+		for i := 0; i < 100; i++ {
+			key := fastrand.Intn(300)
+			batch = append(batch, tuple.Make2(key, round*1000+i))
+			ref[key] = round*1000 + i
+		}
+		m.StoreBatch(batch)
+
+		var keys []int
+		deleted := map[int]bool{}
+		for i := 0; i < 50; i++ {
+			key := fastrand.Intn(300)
+			keys = append(keys, key)
+			if _, ok := ref[key]; ok {
+				deleted[key] = true
+				delete(ref, key)
+			}
+		}
+		assert.Equal(t, len(deleted), m.DeleteBatch(keys))
+		assert.Equal(t, len(ref), m.Len())
+		for key, value := range ref {
+			assert.Equal(t, value, goption.Of(m.Load(key)).Value())
+		}
+	}
+	assert.Equal(t, 0, m.DeleteBatch(nil))
+
+	// LoadSorted merges into existing entries.
+	m = newmap(nil)
+	m.Store(expected[0].First, 0)
+	m.Store(3000, 3000)
+	m.LoadSorted(entries)
+	assert.Equal(t, 1001, m.Len())
+	assert.Equal(t, expected[0].Second, goption.Of(m.Load(expected[0].First)).Value())
+
+	// Concurrent batch operations.
+	m = newmap(nil)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for round := 0; round < 50; round++ {
+				var batch []tuple.T2[int, int]
+				var keys []int
+				for i := 0; i < 20; i++ {
+					key := fastrand.Intn(200)
+					batch = append(batch, tuple.Make2(key, key))
+					keys = append(keys, fastrand.Intn(200))
+				}
+				switch (g + round) % 4 {
+				case 0:
+					m.StoreBatch(batch)
+				case 1:
+					m.DeleteBatch(keys)
+				case 2:
+					m.Store(keys[0], keys[0])
+				default:
+					m.Delete(keys[0])
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	prev, n := 0, 0
+	m.Range(func(key, value int) bool {
+		assert.Equal(t, key, value)
+		if n > 0 {
+			assert.True(t, orderLess(prev, key, desc))
+		}
+		prev = key
+		n++
+		return true
+	})
+	assert.Equal(t, n, m.Len())
+}
+
+// checkTowers checks the invariants of every level of skipmap.
+func checkTowers[keyT constraints.Ordered, valueT any](t *testing.T, s *OrderedMap[keyT, valueT]) {
+	level0 := map[*orderednode[keyT, valueT]]bool{}
+	for x := s.header.loadNext(0); x != nil; x = x.loadNext(0) {
+		level0[x] = true
+	}
+	assert.Equal(t, s.Len(), len(level0))
+	for i := 0; i < maxLevel; i++ {
+		var prev *orderednode[keyT, valueT]
+		for x := s.header.loadNext(i); x != nil; x = x.loadNext(i) {
+			assert.True(t, i < int(atomic.LoadUint64(&s.highestLevel)))
+			assert.True(t, i < int(x.level))
+			assert.True(t, level0[x])
+			assert.True(t, x.flags.Get(fullyLinked))
+			if prev != nil {
+				assert.True(t, prev.key < x.key)
+			}
+			prev = x
+		}
+	}
+}
+
+type snapshotskipmap[S any] interface {
+	Store(key int, value int)
+	Delete(key int) bool
+	Len() int
+	Range(f func(key int, value int) bool)
+	Snapshot() []tuple.T2[int, int]
+	Clone() S
+}
+
+func TestSnapshot(t *testing.T) {
+	opts := Options{ConsistentSnapshot: true}
+	testSkipMapSnapshot(t, func() *OrderedMap[int, int] { return NewWithOptions[int, int](opts) }, false)
+	testSkipMapSnapshot(t, func() *OrderedMapDesc[int, int] { return NewDescWithOptions[int, int](opts) }, true)
+	testSkipMapSnapshot(t, func() *FuncMap[int, int] {
+		return NewFuncWithOptions[int, int](func(a, b int) bool { return a > b }, opts)
+	}, true)
+
+	// Without the option, writers never enter the barrier.
+	m := New[int, int]()
+	m.Store(1, 10)
+	m.barrier.Lock()
+	m.Store(2, 20)
+	m.Delete(1)
+	assert.Equal(t, []tuple.T2[int, int]{{2, 20}}, m.Snapshot())
+	assert.False(t, m.Clone().consistent)
+	m.barrier.Unlock()
+	assert.True(t, NewWithOptions[int, int](opts).Clone().consistent)
+
+	// Clone keeps the level generator.
+	s := NewFuncWithOptions[int, int](func(a, b int) bool { return a < b }, Options{Seed: 1})
+	for i := 0; i < 100; i++ {
+		s.Store(i, i)
+	}
+	c1, c2 := s.Clone(), s.Clone()
+	assert.Equal(t, levelsOf(c1), levelsOf(c2))
+	c1.Store(100, 100)
+	c2.Store(100, 100)
+	assert.Equal(t, levelsOf(c1), levelsOf(c2))
+}
+
+func testSkipMapSnapshot[S snapshotskipmap[S]](t *testing.T, newmap func() S, desc bool) {
+	collect := func(m S) []tuple.T2[int, int] {
+		res := []tuple.T2[int, int]{}
+		m.Range(func(key, value int) bool {
+			res = append(res, tuple.Make2(key, value))
+			return true
+		})
+		return res
+	}
+
+	m := newmap()
+	assert.Equal(t, []tuple.T2[int, int]{}, m.Snapshot())
+	assert.Equal(t, 0, m.Clone().Len())
+	for i := 0; i < 100; i++ {
+		m.Store(i, i*10)
+	}
+	snapshot := m.Snapshot()
+	assert.Equal(t, collect(m), snapshot)
+	assert.Equal(t, 100, len(snapshot))
+	assert.Equal(t, gcond.If(desc, 99, 0), snapshot[0].First)
+
+	// The clone is independent.
+	c := m.Clone()
+	assert.Equal(t, snapshot, collect(c))
+	assert.Equal(t, 100, c.Len())
+	c.Store(100, 1000)
+	c.Delete(0)
+	m.Delete(1)
+	assert.Equal(t, 99, m.Len())
+	assert.Equal(t, 100, c.Len())
+
+	// Snapshot is consistent with concurrent writers.
+	// Each writer moves its key forward by storing the next key then deleting
+	// the current one, so a consistent snapshot contains either one key or two
+	// adjacent keys of each writer.
+	const writers, moves = 4, 2000
+	m = newmap()
+	for g := 0; g < writers; g++ {
+		m.Store(g*moves*2, g)
+	}
+	check := func(entries []tuple.T2[int, int]) {
+		keys := map[int][]int{}
+		for _, e := range entries {
+			keys[e.Second] = append(keys[e.Second], e.First)
+		}
+		for g := 0; g < writers; g++ {
+			ks := keys[g]
+			if len(ks) == 2 {
+				assert.Equal(t, 1, gcond.If(desc, ks[0]-ks[1], ks[1]-ks[0]))
+			} else {
+				assert.Equal(t, 1, len(ks))
+			}
+		}
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < writers; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g * moves * 2; i < g*moves*2+moves; i++ {
+				m.Store(i+1, g)
+				m.Delete(i)
+			}
+		}(g)
+	}
+	stop := make(chan struct{})
+	checked := make(chan struct{})
+	go func() {
+		defer close(checked)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				check(m.Snapshot())
+				check(collect(m.Clone()))
+			}
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-checked
+	check(m.Snapshot())
+	assert.Equal(t, writers, m.Len())
+}
+
+func TestBatchStaleHint(t *testing.T) {
+	s := New[int, int]()
+	for _, k := range []int{0, 1, 5} {
+		s.Store(k, k)
+	}
+	var preds, succs [maxLevel]*orderednode[int, int]
+	assert.True(t, s.delete(1, &preds, &succs)) // preds[0] is node 0
+
+	// The hint is deleted, then a key is inserted after its position.
+	assert.True(t, s.Delete(0))
+	s.Store(3, 3)
+	hints := preds
+	assert.True(t, s.delete(3, &preds, &succs))
+	_, ok := s.Load(3)
+	assert.False(t, ok)
+
+	// Storing with the stale hint does not duplicate the key.
+	s.Store(3, 3)
+	s.store(3, 33, &hints, &succs)
+	assert.Equal(t, map[int]int{3: 33, 5: 5}, s.ToMap())
+	checkTowers(t, s)
+}
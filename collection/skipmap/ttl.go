@@ -0,0 +1,314 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// TTLOptions is the options for creating a [TTLMap].
+type TTLOptions[keyT any, valueT any] struct {
+	// TTL is the default time-to-live of entries stored by [TTLMap.Store].
+	// Zero means never expire.
+	TTL time.Duration
+
+	// Now returns the current time, [time.Now] is used if it is nil.
+	//
+	// The clock is assumed to be monotonic.
+	Now func() time.Time
+
+	// OnExpire is called synchronously after an expired entry is removed,
+	// by [TTLMap.Load], [TTLMap.RemoveExpired] or the janitor.
+	// It is not called for entries replaced or deleted explicitly.
+	OnExpire func(key keyT, value valueT)
+
+	// JanitorInterval is the interval of the background janitor which calls
+	// [TTLMap.RemoveExpired] periodically.
+	// Zero means no janitor, expired entries are removed lazily
+	// when they are loaded, or explicitly by [TTLMap.RemoveExpired].
+	//
+	// 💡 NOTE: [TTLMap.Stop] must be called to stop the janitor.
+	JanitorInterval time.Duration
+}
+
+// TTLMap is a concurrent-safe sorted map whose entries can expire.
+//
+// Entries are indexed by both key and expiration time, so expired entries
+// are evicted in order of expiration without scanning the whole map.
+// An expired entry is invisible to reads even if it has not been removed yet.
+type TTLMap[keyT any, valueT any] struct {
+	entries *FuncMap[keyT, *ttlEntry[keyT, valueT]]
+	expiry  *FuncMap[expiryKey, *ttlEntry[keyT, valueT]]
+	seq     uint64
+	opts    TTLOptions[keyT, valueT]
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+type ttlEntry[keyT any, valueT any] struct {
+	key      keyT
+	value    valueT
+	expireAt int64 // in unix nanoseconds, 0 means never expire
+	seq      uint64
+}
+
+// expiryKey orders entries by expiration time, seq breaks ties.
+type expiryKey struct {
+	expireAt int64
+	seq      uint64
+}
+
+func lessExpiryKey(a, b expiryKey) bool {
+	return a.expireAt < b.expireAt || a.expireAt == b.expireAt && a.seq < b.seq
+}
+
+func sameEntry[keyT any, valueT any](a, b *ttlEntry[keyT, valueT]) bool {
+	return a == b
+}
+
+// NewTTL returns an empty TTL skipmap in ascending order.
+func NewTTL[keyT constraints.Ordered, valueT any](opts TTLOptions[keyT, valueT]) *TTLMap[keyT, valueT] {
+	return NewTTLFunc(func(a, b keyT) bool { return a < b }, opts)
+}
+
+// NewTTLDesc returns an empty TTL skipmap in descending order.
+func NewTTLDesc[keyT constraints.Ordered, valueT any](opts TTLOptions[keyT, valueT]) *TTLMap[keyT, valueT] {
+	return NewTTLFunc(func(a, b keyT) bool { return a > b }, opts)
+}
+
+// NewTTLFunc returns an empty TTL skipmap in ascending order defined by less.
+//
+// Note that the less function requires a strict weak ordering,
+// see https://en.wikipedia.org/wiki/Weak_ordering#Strict_weak_orderings,
+// or undefined behavior will happen.
+func NewTTLFunc[keyT any, valueT any](less func(a, b keyT) bool, opts TTLOptions[keyT, valueT]) *TTLMap[keyT, valueT] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	s := &TTLMap[keyT, valueT]{
+		entries: NewFunc[keyT, *ttlEntry[keyT, valueT]](less),
+		expiry:  NewFunc[expiryKey, *ttlEntry[keyT, valueT]](lessExpiryKey),
+		opts:    opts,
+		stop:    make(chan struct{}),
+	}
+	if opts.JanitorInterval > 0 {
+		go s.janitor(opts.JanitorInterval)
+	}
+	return s
+}
+
+func (s *TTLMap[keyT, valueT]) now() int64 {
+	return s.opts.Now().UnixNano()
+}
+
+func (e *ttlEntry[keyT, valueT]) expired(now int64) bool {
+	return e.expireAt != 0 && now >= e.expireAt
+}
+
+func (e *ttlEntry[keyT, valueT]) expiryKey() expiryKey {
+	return expiryKey{e.expireAt, e.seq}
+}
+
+// Store sets the value for a key with the default TTL.
+func (s *TTLMap[keyT, valueT]) Store(key keyT, value valueT) {
+	s.StoreWithTTL(key, value, s.opts.TTL)
+}
+
+// StoreWithTTL sets the value for a key with a specific TTL,
+// zero or negative TTL means never expire.
+func (s *TTLMap[keyT, valueT]) StoreWithTTL(key keyT, value valueT, ttl time.Duration) {
+	e := &ttlEntry[keyT, valueT]{
+		key:   key,
+		value: value,
+		seq:   atomic.AddUint64(&s.seq, 1),
+	}
+	now := s.now()
+	if ttl > 0 {
+		e.expireAt = now + int64(ttl)
+		// Index the entry before publishing it, so that an entry in the map
+		// is always reachable from the expiry index.
+		s.expiry.Store(e.expiryKey(), e)
+	}
+	old, loaded := s.entries.Swap(key, e)
+	if loaded && old.expireAt != 0 {
+		s.expiry.Delete(old.expiryKey())
+	}
+	// The index of e may have been consumed by an eviction between Store and
+	// Swap, in which case no one else will remove it.
+	if e.expireAt != 0 && e.expired(s.now()) {
+		s.removeExpired(e)
+	}
+}
+
+// Load returns the value stored in the map for a key,
+// ok is false if the key is not present or expired.
+//
+// An expired entry is removed by Load.
+func (s *TTLMap[keyT, valueT]) Load(key keyT) (value valueT, ok bool) {
+	e, ok := s.entries.Load(key)
+	if !ok {
+		return
+	}
+	if e.expired(s.now()) {
+		s.removeExpired(e)
+		return value, false
+	}
+	return e.value, true
+}
+
+// ExpireAt returns the expiration time of a key,
+// a zero [time.Time] is returned if the entry never expires.
+// ok is false if the key is not present or expired.
+func (s *TTLMap[keyT, valueT]) ExpireAt(key keyT) (t time.Time, ok bool) {
+	e, ok := s.entries.Load(key)
+	if !ok || e.expired(s.now()) {
+		return t, false
+	}
+	if e.expireAt != 0 {
+		t = time.Unix(0, e.expireAt)
+	}
+	return t, true
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if
+// any. The loaded result reports whether the key was present and not expired.
+func (s *TTLMap[keyT, valueT]) LoadAndDelete(key keyT) (value valueT, loaded bool) {
+	e, ok := s.entries.LoadAndDelete(key)
+	if !ok {
+		return
+	}
+	if e.expireAt != 0 {
+		s.expiry.Delete(e.expiryKey())
+	}
+	if e.expired(s.now()) {
+		return value, false
+	}
+	return e.value, true
+}
+
+// Delete deletes the value for a key.
+// It returns true if the key was present and not expired.
+func (s *TTLMap[keyT, valueT]) Delete(key keyT) bool {
+	_, loaded := s.LoadAndDelete(key)
+	return loaded
+}
+
+// Range calls f sequentially for each key and value present in the map
+// and not expired, in key order.
+// If f returns false, range stops the iteration.
+//
+// Range does not remove expired entries.
+func (s *TTLMap[keyT, valueT]) Range(f func(key keyT, value valueT) bool) {
+	now := s.now()
+	s.entries.Range(func(key keyT, e *ttlEntry[keyT, valueT]) bool {
+		if e.expired(now) {
+			return true
+		}
+		return f(key, e.value)
+	})
+}
+
+// RangeByExpiry calls f sequentially for each key and value which will expire,
+// in order of expiration time.
+// Entries which never expire are not visited.
+// If f returns false, range stops the iteration.
+func (s *TTLMap[keyT, valueT]) RangeByExpiry(f func(key keyT, value valueT, expireAt time.Time) bool) {
+	now := s.now()
+	s.expiry.Range(func(_ expiryKey, e *ttlEntry[keyT, valueT]) bool {
+		if e.expired(now) || !s.live(e) {
+			return true
+		}
+		return f(e.key, e.value, time.Unix(0, e.expireAt))
+	})
+}
+
+// live reports whether e is the current entry of its key.
+func (s *TTLMap[keyT, valueT]) live(e *ttlEntry[keyT, valueT]) bool {
+	cur, ok := s.entries.Load(e.key)
+	return ok && cur == e
+}
+
+// Len returns the number of entries, including expired ones which have not
+// been removed yet.
+func (s *TTLMap[keyT, valueT]) Len() int {
+	return s.entries.Len()
+}
+
+// RemoveExpired removes all expired entries in order of expiration time,
+// and returns their count.
+// The complexity is O(k log n), where k is the number of expired entries.
+func (s *TTLMap[keyT, valueT]) RemoveExpired() int {
+	now := s.now()
+	n := 0
+	for {
+		first := s.expiry.First()
+		if !first.IsOK() {
+			break
+		}
+		k, e := first.Value().Values()
+		if k.expireAt > now {
+			break
+		}
+		// Only one of concurrent callers can delete the index.
+		if s.expiry.Delete(k) && s.removeEntry(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// removeExpired removes the expired entry e if it is still present.
+func (s *TTLMap[keyT, valueT]) removeExpired(e *ttlEntry[keyT, valueT]) {
+	if s.removeEntry(e) {
+		s.expiry.Delete(e.expiryKey())
+	}
+}
+
+// removeEntry removes e from entries if it is still the current entry of its
+// key, and calls OnExpire.
+func (s *TTLMap[keyT, valueT]) removeEntry(e *ttlEntry[keyT, valueT]) bool {
+	if !s.entries.CompareAndDeleteFunc(e.key, e, sameEntry[keyT, valueT]) {
+		return false
+	}
+	if s.opts.OnExpire != nil {
+		s.opts.OnExpire(e.key, e.value)
+	}
+	return true
+}
+
+func (s *TTLMap[keyT, valueT]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.RemoveExpired()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the background janitor if any.
+// It is safe to call Stop multiple times.
+// The map is still usable after Stop.
+func (s *TTLMap[keyT, valueT]) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipmap
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+type fakeClock struct {
+	now int64
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.now))
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	atomic.AddInt64(&c.now, int64(d))
+}
+
+func TestTTLMap(t *testing.T) {
+	clock := &fakeClock{now: int64(time.Hour)}
+	var expired []string
+	m := NewTTL(TTLOptions[string, int]{
+		TTL:      time.Minute,
+		Now:      clock.Now,
+		OnExpire: func(key string, value int) { expired = append(expired, key+"="+strconv.Itoa(value)) },
+	})
+	defer m.Stop()
+
+	m.Store("a", 1)                       // expire at +1m
+	m.StoreWithTTL("b", 2, 3*time.Minute) // expire at +3m
+	m.StoreWithTTL("c", 3, 0)             // never expire
+	m.StoreWithTTL("d", 4, 2*time.Minute) // expire at +2m
+	m.StoreWithTTL("e", 5, -time.Second)  // never expire
+	assert.Equal(t, 5, m.Len())
+
+	v, ok := m.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	at, ok := m.ExpireAt("b")
+	assert.True(t, ok)
+	assert.Equal(t, clock.Now().Add(3*time.Minute), at)
+	at, ok = m.ExpireAt("c")
+	assert.True(t, ok)
+	assert.True(t, at.IsZero())
+
+	var order []string
+	m.RangeByExpiry(func(key string, _ int, _ time.Time) bool {
+		order = append(order, key)
+		return true
+	})
+	assert.Equal(t, []string{"a", "d", "b"}, order)
+
+	// Lazy expiry on Load.
+	clock.Add(time.Minute)
+	_, ok = m.Load("a")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a=1"}, expired)
+	assert.Equal(t, 4, m.Len())
+	_, ok = m.Load("a")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a=1"}, expired)
+
+	// Expired entries are invisible before removed.
+	clock.Add(2 * time.Minute)
+	_, ok = m.ExpireAt("b")
+	assert.False(t, ok)
+	var keys []string
+	m.Range(func(key string, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []string{"c", "e"}, keys)
+	assert.Equal(t, 4, m.Len())
+
+	// Evicted in order of expiration.
+	assert.Equal(t, 2, m.RemoveExpired())
+	assert.Equal(t, []string{"a=1", "d=4", "b=2"}, expired)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 0, m.RemoveExpired())
+}
+
+func TestTTLMapReplaceAndDelete(t *testing.T) {
+	clock := &fakeClock{}
+	expired := 0
+	m := NewTTLDesc(TTLOptions[int, string]{
+		Now:      clock.Now,
+		OnExpire: func(int, string) { expired++ },
+	})
+
+	// Replacing refreshes TTL.
+	m.StoreWithTTL(1, "a", time.Second)
+	m.StoreWithTTL(1, "b", 3*time.Second)
+	clock.Add(2 * time.Second)
+	assert.Equal(t, 0, m.RemoveExpired())
+	v, ok := m.Load(1)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+
+	// Replacing by an entry which never expires.
+	m.StoreWithTTL(1, "c", 0)
+	clock.Add(time.Hour)
+	assert.Equal(t, 0, m.RemoveExpired())
+	v, _ = m.Load(1)
+	assert.Equal(t, "c", v)
+
+	// Deleting is not expiring.
+	m.StoreWithTTL(2, "x", time.Second)
+	v, ok = m.LoadAndDelete(2)
+	assert.True(t, ok)
+	assert.Equal(t, "x", v)
+	assert.False(t, m.Delete(2))
+	m.StoreWithTTL(2, "x", time.Second)
+	clock.Add(time.Second)
+	assert.False(t, m.Delete(2))
+	assert.Equal(t, 0, expired)
+	assert.Equal(t, 0, m.RemoveExpired())
+	assert.Equal(t, 1, m.Len())
+
+	// Desc order.
+	m.Store(3, "z")
+	m.Store(0, "z")
+	var keys []int
+	m.Range(func(key int, _ string) bool {
+		keys = append(keys, key)
+		return false
+	})
+	assert.Equal(t, []int{3}, keys)
+}
+
+func TestTTLMapJanitor(t *testing.T) {
+	clock := &fakeClock{}
+	done := make(chan string, 1)
+	m := NewTTLFunc(func(a, b string) bool { return a < b }, TTLOptions[string, int]{
+		Now:             clock.Now,
+		TTL:             time.Second,
+		OnExpire:        func(key string, _ int) { done <- key },
+		JanitorInterval: time.Millisecond,
+	})
+	defer m.Stop()
+
+	m.Store("a", 1)
+	m.StoreWithTTL("b", 2, 0)
+	clock.Add(time.Second)
+	select {
+	case key := <-done:
+		assert.Equal(t, "a", key)
+	case <-time.After(10 * time.Second):
+		t.Fatal("janitor does not remove expired entry")
+	}
+	assert.Equal(t, 1, m.Len())
+	m.Stop()
+	m.Stop()
+}
+
+func TestTTLMapConcurrent(t *testing.T) {
+	clock := &fakeClock{}
+	var expired int64
+	m := NewTTL(TTLOptions[int, int]{
+		Now:      clock.Now,
+		OnExpire: func(int, int) { atomic.AddInt64(&expired, 1) },
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := i % 100
+				switch (i + g) % 4 {
+				case 0, 1:
+					m.StoreWithTTL(key, i, time.Duration(1+i%5)*time.Millisecond)
+				case 2:
+					m.Load(key)
+				default:
+					m.RemoveExpired()
+				}
+				if i%100 == 0 {
+					clock.Add(time.Millisecond)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	clock.Add(time.Hour)
+	m.RemoveExpired()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.expiry.Len())
+	assert.True(t, atomic.LoadInt64(&expired) >= 100)
+}
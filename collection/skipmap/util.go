@@ -34,3 +34,8 @@ func randomLevel() int {
 	}
 	return level
 }
+
+// equalAny reports whether a and b are equal, it panics if T is not comparable.
+func equalAny[T any](a, b T) bool {
+	return any(a) == any(b)
+}
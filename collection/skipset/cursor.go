@@ -0,0 +1,120 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipset
+
+// navigator is implemented by [OrderedSet], [OrderedSetDesc] and [FuncSet].
+type navigator[T any] interface {
+	firstAfter(value T, bounded, inclusive bool) (T, bool)
+	lastBefore(value T, bounded bool) (T, bool)
+}
+
+type cursorPos int
+
+const (
+	beforeFirst cursorPos = iota
+	atValue
+	afterLast
+)
+
+// Cursor is a bidirectional cursor over values of a skip set.
+//
+// A cursor remembers current value instead of the node, so it can be resumed
+// from any value by [Cursor.Seek], and each move locates the adjacent value
+// by the index of skip list in O(log n).
+//
+// Cursor is weakly consistent: values added or removed concurrently may or
+// may not be visited, but no value is visited twice in the same direction.
+//
+// 💡 NOTE: Cursor is not concurrent-safe, but the underlying skip set can be
+// modified concurrently.
+type Cursor[T any] struct {
+	s     navigator[T]
+	pos   cursorPos
+	value T
+}
+
+func newCursor[T any](s navigator[T]) *Cursor[T] {
+	return &Cursor[T]{s: s}
+}
+
+// Cursor returns a cursor positioned before the first value.
+func (s *OrderedSet[T]) Cursor() *Cursor[T] {
+	return newCursor[T](s)
+}
+
+// Cursor returns a cursor positioned before the first value.
+func (s *OrderedSetDesc[T]) Cursor() *Cursor[T] {
+	return newCursor[T](s)
+}
+
+// Cursor returns a cursor positioned before the first value.
+func (s *FuncSet[T]) Cursor() *Cursor[T] {
+	return newCursor[T](s)
+}
+
+func (c *Cursor[T]) moveTo(value T, ok bool, otherwise cursorPos) bool {
+	if ok {
+		c.pos, c.value = atValue, value
+		return true
+	}
+	var zero T
+	c.pos, c.value = otherwise, zero
+	return false
+}
+
+// Seek moves the cursor to the first value which is after or equal to the
+// given value, and reports whether there is such value.
+// If not, the cursor is positioned after the last value.
+func (c *Cursor[T]) Seek(value T) bool {
+	v, ok := c.s.firstAfter(value, true, true)
+	return c.moveTo(v, ok, afterLast)
+}
+
+// Next moves the cursor to the next value, and reports whether there is one.
+// If the cursor is positioned before the first value, it moves to the first one.
+func (c *Cursor[T]) Next() bool {
+	if c.pos == afterLast {
+		return false
+	}
+	v, ok := c.s.firstAfter(c.value, c.pos == atValue, false)
+	return c.moveTo(v, ok, afterLast)
+}
+
+// Prev moves the cursor to the previous value, and reports whether there is one.
+// If the cursor is positioned after the last value, it moves to the last one.
+func (c *Cursor[T]) Prev() bool {
+	if c.pos == beforeFirst {
+		return false
+	}
+	v, ok := c.s.lastBefore(c.value, c.pos == atValue)
+	return c.moveTo(v, ok, beforeFirst)
+}
+
+// Valid reports whether the cursor is positioned at a value.
+func (c *Cursor[T]) Valid() bool {
+	return c.pos == atValue
+}
+
+// Value returns current value.
+// If the cursor is not positioned at a value, return zero value.
+func (c *Cursor[T]) Value() T {
+	return c.value
+}
+
+// Key is an alias of [Cursor.Value], so that code paginating skipmap and
+// skip set by cursors looks the same.
+func (c *Cursor[T]) Key() T {
+	return c.value
+}
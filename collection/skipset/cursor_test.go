@@ -0,0 +1,103 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipset
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+type cursorskipset interface {
+	Add(value int) bool
+	Remove(value int) bool
+	Cursor() *Cursor[int]
+}
+
+func TestCursor(t *testing.T) {
+	testSkipSetCursor(t, New[int](), false)
+	testSkipSetCursor(t, NewDesc[int](), true)
+	testSkipSetCursor(t, NewFunc[int](func(a, b int) bool { return a < b }), false)
+}
+
+func testSkipSetCursor(t *testing.T, s cursorskipset, desc bool) {
+	c := s.Cursor()
+	assert.False(t, c.Valid())
+	assert.False(t, c.Next())
+	assert.False(t, c.Prev())
+	assert.False(t, c.Seek(0))
+
+	for i := 1; i <= 5; i++ {
+		s.Add(i * 10)
+	}
+	ordered := []int{10, 20, 30, 40, 50}
+	if desc {
+		ordered = []int{50, 40, 30, 20, 10}
+	}
+
+	// Forward and backward.
+	c = s.Cursor()
+	var values []int
+	for c.Next() {
+		assert.True(t, c.Valid())
+		assert.Equal(t, c.Value(), c.Key())
+		values = append(values, c.Value())
+	}
+	assert.Equal(t, ordered, values)
+	assert.False(t, c.Valid())
+	assert.Equal(t, 0, c.Value())
+	assert.False(t, c.Next())
+
+	values = nil
+	for c.Prev() {
+		values = append(values, c.Value())
+	}
+	assert.Equal(t, []int{ordered[4], ordered[3], ordered[2], ordered[1], ordered[0]}, values)
+	assert.False(t, c.Prev())
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[0], c.Value())
+
+	// Resume from any value, even if it does not exist.
+	assert.True(t, c.Seek(25))
+	if desc {
+		assert.Equal(t, 20, c.Value())
+	} else {
+		assert.Equal(t, 30, c.Value())
+	}
+	assert.True(t, c.Seek(ordered[3]))
+	assert.Equal(t, ordered[3], c.Value())
+	assert.True(t, c.Prev())
+	assert.Equal(t, ordered[2], c.Value())
+
+	// Concurrent modifications are visible for the following moves.
+	s.Remove(ordered[3])
+	s.Add(ordered[2] + orderedStep(desc))
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[2]+orderedStep(desc), c.Value())
+	assert.True(t, c.Next())
+	assert.Equal(t, ordered[4], c.Value())
+	assert.False(t, c.Next())
+	assert.False(t, c.Seek(ordered[4]+orderedStep(desc)))
+	assert.True(t, c.Prev())
+	assert.Equal(t, ordered[4], c.Value())
+}
+
+// orderedStep is a small step forward in the order of set.
+func orderedStep(desc bool) int {
+	if desc {
+		return -1
+	}
+	return 1
+}
@@ -72,7 +72,7 @@ func main() {
 		Package:         "skipset",
 		Name:            "ordered",
 		Path:            "gen_ordered.go",
-		Imports:         "\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n\n\"github.com/bytedance/gg/internal/constraints\"\n",
+		Imports:         "\"fmt\"\n\"sort\"\n\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n\n\"github.com/bytedance/gg/internal/constraints\"\n",
 		Type:            "T",
 		TypeArgument:    "[T]",
 		TypeParam:       "[T constraints.Ordered]",
@@ -107,7 +107,7 @@ func main() {
 		Package:         "skipset",
 		Name:            "func",
 		Path:            "gen_func.go",
-		Imports:         "\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n",
+		Imports:         "\"fmt\"\n\"sort\"\n\"sync\"\n\"sync/atomic\"\n\"unsafe\"\n",
 		Type:            "T",
 		TypeArgument:    "[T]",
 		TypeParam:       "[T any]",
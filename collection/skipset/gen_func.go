@@ -17,6 +17,8 @@
 package skipset
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -27,6 +29,7 @@ type FuncSet[T any] struct {
 	length       int64
 	highestLevel uint64 // highest level for now
 	header       *funcnode[T]
+	levels       *levelGenerator // nil means the default
 
 	less func(a, b T) bool
 }
@@ -109,6 +112,66 @@ func (s *FuncSet[T]) findNodeAdd(value T, preds *[maxLevel]*funcnode[T], succs *
 	return -1
 }
 
+// findNodeAddHint is a variant of findNodeAdd for batch operations on values
+// in ascending order, the search at level i starts from preds[i] left by
+// the previous search if it is a better start point.
+// The preds must precede the value or be nil.
+func (s *FuncSet[T]) findNodeAddHint(value T, preds *[maxLevel]*funcnode[T], succs *[maxLevel]*funcnode[T]) int {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && s.less(succ.value, value) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if succ != nil && !s.less(value, succ.value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findNodeRemoveHint is a variant of findNodeRemove which uses preds as hints,
+// see findNodeAddHint.
+func (s *FuncSet[T]) findNodeRemoveHint(value T, preds *[maxLevel]*funcnode[T], succs *[maxLevel]*funcnode[T]) int {
+	// lFound represents the index of the first layer at which it found a node.
+	lFound, x := -1, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && s.less(succ.value, value) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if lFound == -1 && succ != nil && !s.less(value, succ.value) {
+			lFound = i
+		}
+	}
+	return lFound
+}
+
+// further returns the one of x and hint which is closer to the tail.
+// A marked hint is ignored, it may have been unlinked and its next nodes are
+// no longer up to date.
+func (s *FuncSet[T]) further(x, hint *funcnode[T]) *funcnode[T] {
+	if hint == nil || hint == s.header || hint.flags.Get(marked) {
+		return x
+	}
+	if x == s.header || s.less(x.value, hint.value) {
+		return hint
+	}
+	return x
+}
+
 func unlockfunc[T any](preds [maxLevel]*funcnode[T], highestLevel int) {
 	var prevPred *funcnode[T]
 	for i := highestLevel; i >= 0; i-- {
@@ -124,10 +187,16 @@ func unlockfunc[T any](preds [maxLevel]*funcnode[T], highestLevel int) {
 //
 // If the value is in the skip set but not fully linked, this process will wait until it is.
 func (s *FuncSet[T]) Add(value T) bool {
-	level := s.randomlevel()
 	var preds, succs [maxLevel]*funcnode[T]
+	return s.add(value, &preds, &succs)
+}
+
+// add adds the value into skip set, the preds are used as hints of search,
+// see findNodeAddHint.
+func (s *FuncSet[T]) add(value T, preds, succs *[maxLevel]*funcnode[T]) bool {
+	level := s.randomlevel()
 	for {
-		lFound := s.findNodeAdd(value, &preds, &succs)
+		lFound := s.findNodeAddHint(value, preds, succs)
 		if lFound != -1 { // indicating the value is already in the skip-list
 			nodeFound := succs[lFound]
 			if !nodeFound.flags.Get(marked) {
@@ -138,6 +207,7 @@ func (s *FuncSet[T]) Add(value T) bool {
 			}
 			// If the node is marked, represents some other thread is in the process of deleting this node,
 			// we need to add this node in next loop.
+			*preds = [maxLevel]*funcnode[T]{}
 			continue
 		}
 		// Add this node into skip list.
@@ -161,7 +231,8 @@ func (s *FuncSet[T]) Add(value T) bool {
 			valid = !pred.flags.Get(marked) && (succ == nil || !succ.flags.Get(marked)) && pred.loadNext(layer) == succ
 		}
 		if !valid {
-			unlockfunc(preds, highestLocked)
+			unlockfunc(*preds, highestLocked)
+			*preds = [maxLevel]*funcnode[T]{}
 			continue
 		}
 
@@ -171,7 +242,7 @@ func (s *FuncSet[T]) Add(value T) bool {
 			preds[layer].atomicStoreNext(layer, nn)
 		}
 		nn.flags.SetTrue(fullyLinked)
-		unlockfunc(preds, highestLocked)
+		unlockfunc(*preds, highestLocked)
 		atomic.AddInt64(&s.length, 1)
 		return true
 	}
@@ -179,7 +250,7 @@ func (s *FuncSet[T]) Add(value T) bool {
 
 func (s *FuncSet[T]) randomlevel() int {
 	// Generate random level.
-	level := randomLevel()
+	level := s.levels.next()
 	// Update highest level if possible.
 	for {
 		hl := atomic.LoadUint64(&s.highestLevel)
@@ -213,14 +284,20 @@ func (s *FuncSet[T]) Contains(value T) bool {
 
 // Remove removes a node from the skip set.
 func (s *FuncSet[T]) Remove(value T) bool {
+	var preds, succs [maxLevel]*funcnode[T]
+	return s.remove(value, &preds, &succs)
+}
+
+// remove removes a node from the skip set, the preds are used as hints of
+// search, see findNodeAddHint.
+func (s *FuncSet[T]) remove(value T, preds, succs *[maxLevel]*funcnode[T]) bool {
 	var (
 		nodeToRemove *funcnode[T]
 		isMarked     bool // represents if this operation mark the node
 		topLayer     = -1
-		preds, succs [maxLevel]*funcnode[T]
 	)
 	for {
-		lFound := s.findNodeRemove(value, &preds, &succs)
+		lFound := s.findNodeRemoveHint(value, preds, succs)
 		if isMarked || // this process mark this node or we can find this node in the skip list
 			lFound != -1 && succs[lFound].flags.MGet(fullyLinked|marked, fullyLinked) && (int(succs[lFound].level)-1) == lFound {
 			if !isMarked { // we don't mark this node for now
@@ -257,7 +334,8 @@ func (s *FuncSet[T]) Remove(value T) bool {
 				valid = !pred.flags.Get(marked) && pred.loadNext(layer) == succ
 			}
 			if !valid {
-				unlockfunc(preds, highestLocked)
+				unlockfunc(*preds, highestLocked)
+				*preds = [maxLevel]*funcnode[T]{}
 				continue
 			}
 			for i := topLayer; i >= 0; i-- {
@@ -266,7 +344,7 @@ func (s *FuncSet[T]) Remove(value T) bool {
 				preds[i].atomicStoreNext(i, nodeToRemove.loadNext(i))
 			}
 			nodeToRemove.mu.Unlock()
-			unlockfunc(preds, highestLocked)
+			unlockfunc(*preds, highestLocked)
 			atomic.AddInt64(&s.length, -1)
 			return true
 		}
@@ -274,6 +352,96 @@ func (s *FuncSet[T]) Remove(value T) bool {
 	}
 }
 
+// AddBatch adds values into skip set and returns the number of added values.
+//
+// The values are added in order of skip set and the search of each value
+// starts from the position of the previous one, which is faster than
+// separate calls of [FuncSet.Add] when values are adjacent.
+func (s *FuncSet[T]) AddBatch(values []T) int {
+	return s.addSorted(s.sorted(values))
+}
+
+// LoadSorted is a variant of [FuncSet.AddBatch] for values already sorted
+// in the order of skip set, it adds values without copying and sorting.
+//
+// It panics if values are not sorted.
+func (s *FuncSet[T]) LoadSorted(values []T) int {
+	s.checkSorted(values)
+	return s.addSorted(values)
+}
+
+func (s *FuncSet[T]) addSorted(values []T) int {
+	var (
+		preds, succs [maxLevel]*funcnode[T]
+		n            int
+	)
+	for _, v := range values {
+		if s.add(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveBatch removes values from skip set and returns the number of removed values.
+//
+// Like [FuncSet.AddBatch], the values are removed in order of skip set and
+// the search of each value starts from the position of the previous one.
+func (s *FuncSet[T]) RemoveBatch(values []T) int {
+	var (
+		preds, succs [maxLevel]*funcnode[T]
+		n            int
+	)
+	for _, v := range s.sorted(values) {
+		if s.remove(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// sorted returns a sorted copy of values.
+func (s *FuncSet[T]) sorted(values []T) []T {
+	res := make([]T, len(values))
+	copy(res, values)
+	sort.Slice(res, func(i, j int) bool {
+		return s.less(res[i], res[j])
+	})
+	return res
+}
+
+// buildSorted links sorted values into the empty skip set in O(n),
+// duplicate values are ignored.
+// It must be called before the skip set is shared.
+func (s *FuncSet[T]) buildSorted(values []T) {
+	s.checkSorted(values)
+	var tails [maxLevel]*funcnode[T]
+	for i := range tails {
+		tails[i] = s.header
+	}
+	for i, v := range values {
+		if i > 0 && !s.less(values[i-1], v) {
+			continue
+		}
+		level := s.randomlevel()
+		nn := newFuncNode(v, level)
+		nn.flags.SetTrue(fullyLinked)
+		for l := 0; l < level; l++ {
+			tails[l].storeNext(l, nn)
+			tails[l] = nn
+		}
+		s.length++
+	}
+}
+
+func (s *FuncSet[T]) checkSorted(values []T) {
+	for i := 1; i < len(values); i++ {
+		if s.less(values[i], values[i-1]) {
+			panic(fmt.Errorf("values are not sorted at index %d", i))
+		}
+	}
+}
+
 // Range calls f sequentially for each value present in the skip set.
 // If f returns false, range stops the iteration.
 func (s *FuncSet[T]) Range(f func(value T) bool) {
@@ -290,6 +458,267 @@ func (s *FuncSet[T]) Range(f func(value T) bool) {
 	}
 }
 
+// RangeFrom is a variant of [FuncSet.Range], calls f sequentially for
+// each value which is after or equal to start in the order of the skip set.
+//
+// The start position is located by the index of skip list, so the complexity
+// of visiting k values is O(log n + k).
+func (s *FuncSet[T]) RangeFrom(start T, f func(value T) bool) {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		nex := x.atomicLoadNext(i)
+		for nex != nil && s.less(nex.value, start) {
+			x = nex
+			nex = x.atomicLoadNext(i)
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// RangeReverse is a variant of [FuncSet.Range], calls f sequentially for
+// each value in the reverse order of the skip set.
+//
+// 💡 NOTE: Skip list has no backward links, the values are collected by a
+// forward scan before f is called, so it always takes O(n) time and memory
+// even if f stops the iteration early.
+func (s *FuncSet[T]) RangeReverse(f func(value T) bool) {
+	var nodes []*funcnode[T]
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			nodes = append(nodes, x)
+		}
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		x := nodes[i]
+		if x.flags.Get(marked) {
+			continue // removed after being collected
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// firstAfter returns the first value after the given value, or equal to it if inclusive.
+// If bounded is false, the value is ignored and the first value of skip set is returned.
+func (s *FuncSet[T]) firstAfter(value T, bounded, inclusive bool) (T, bool) {
+	x := s.header
+	if bounded {
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && (s.less(nex.value, value) || !inclusive && !s.less(value, nex.value)) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// lastBefore returns the last value strictly before the given value.
+// If bounded is false, the value is ignored and the last value of skip set is returned.
+func (s *FuncSet[T]) lastBefore(value T, bounded bool) (T, bool) {
+	for {
+		x := s.header
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && (!bounded || s.less(nex.value, value)) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+		if x == s.header {
+			var zero T
+			return zero, false
+		}
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+		// The node is being inserted or removed, find the one before it.
+		value, bounded = x.value, true
+	}
+}
+
+// Union returns the union of the skip set and other as a new skip set.
+//
+// The sets are walked with a sorted merge in O(n + m).
+// Like [FuncSet.Range], it does not necessarily correspond to any
+// consistent snapshot if the sets are modified concurrently.
+func (s *FuncSet[T]) Union(other *FuncSet[T]) *FuncSet[T] {
+	var values []T
+	s.merge(other, func(value T, _, _ bool) bool {
+		values = append(values, value)
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Intersect returns the intersection of the skip set and other as a new skip set.
+//
+// See [FuncSet.Union] for complexity and consistency.
+func (s *FuncSet[T]) Intersect(other *FuncSet[T]) *FuncSet[T] {
+	var values []T
+	s.merge(other, func(value T, inS, inOther bool) bool {
+		if inS && inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Diff returns the values of the skip set which are not in other as a new skip set.
+//
+// See [FuncSet.Union] for complexity and consistency.
+func (s *FuncSet[T]) Diff(other *FuncSet[T]) *FuncSet[T] {
+	var values []T
+	s.merge(other, func(value T, inS, inOther bool) bool {
+		if !inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// fromMerged builds a new skip set from the sorted result of merge in O(n).
+func (s *FuncSet[T]) fromMerged(values []T) *FuncSet[T] {
+	res := s.empty()
+	res.buildSorted(values)
+	return res
+}
+
+// IsSubset returns whether other contains all values of the skip set.
+//
+// See [FuncSet.Union] for complexity and consistency.
+func (s *FuncSet[T]) IsSubset(other *FuncSet[T]) bool {
+	res := true
+	s.merge(other, func(_ T, _, inOther bool) bool {
+		res = inOther
+		return res
+	})
+	return res
+}
+
+// Equal returns whether the skip set and other contain the same values.
+//
+// See [FuncSet.Union] for complexity and consistency.
+func (s *FuncSet[T]) Equal(other *FuncSet[T]) bool {
+	res := true
+	s.merge(other, func(_ T, inS, inOther bool) bool {
+		res = inS && inOther
+		return res
+	})
+	return res
+}
+
+// empty returns an empty skip set with the same order.
+func (s *FuncSet[T]) empty() *FuncSet[T] {
+	return NewFunc(s.less)
+}
+
+// merge walks the values of the skip set and other in order,
+// and calls f with each value and whether it is in each set.
+// If f returns false, merge stops the walk.
+//
+// The order of the skip set is used, other must have the same order.
+func (s *FuncSet[T]) merge(other *FuncSet[T], f func(value T, inS, inOther bool) bool) {
+	a, b := s.header.atomicLoadNext(0), other.header.atomicLoadNext(0)
+	for {
+		a, b = validFromfunc(a), validFromfunc(b)
+		switch {
+		case a == nil && b == nil:
+			return
+		case b == nil || a != nil && s.less(a.value, b.value):
+			if !f(a.value, true, false) {
+				return
+			}
+			a = a.atomicLoadNext(0)
+		case a == nil || s.less(b.value, a.value):
+			if !f(b.value, false, true) {
+				return
+			}
+			b = b.atomicLoadNext(0)
+		default:
+			if !f(a.value, true, true) {
+				return
+			}
+			a, b = a.atomicLoadNext(0), b.atomicLoadNext(0)
+		}
+	}
+}
+
+// validFrom returns the first valid node starting from node x at level 0.
+func validFromfunc[T any](x *funcnode[T]) *funcnode[T] {
+	for x != nil && !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+		x = x.atomicLoadNext(0)
+	}
+	return x
+}
+
+// Stats returns the structural statistics of the skip set.
+//
+// It visits every node and searches every value, so the complexity is O(n log n),
+// it is intended for debugging and tuning.
+// Like [FuncSet.Range], it does not necessarily correspond to any
+// consistent snapshot if the skip set is modified concurrently.
+func (s *FuncSet[T]) Stats() Stats {
+	st := Stats{
+		HighestLevel: int(atomic.LoadUint64(&s.highestLevel)),
+		Levels:       make([]int, maxLevel),
+	}
+	var path, highest int
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		st.Nodes++
+		st.Levels[x.level-1]++
+		if int(x.level) > highest {
+			highest = int(x.level)
+		}
+		path += s.searchPath(x.value)
+	}
+	st.Levels = st.Levels[:highest]
+	if st.Nodes > 0 {
+		st.AvgSearchPath = float64(path) / float64(st.Nodes)
+	}
+	return st
+}
+
+// searchPath returns the number of nodes visited by searching the value.
+func (s *FuncSet[T]) searchPath(value T) int {
+	n, x := 0, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		succ := x.atomicLoadNext(i)
+		for succ != nil {
+			n++
+			if !s.less(succ.value, value) {
+				break
+			}
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		if succ != nil && !s.less(value, succ.value) {
+			return n
+		}
+	}
+	return n
+}
+
 // Len returns the length of this skip set.
 func (s *FuncSet[T]) Len() int {
 	return int(atomic.LoadInt64(&s.length))
@@ -17,6 +17,8 @@
 package skipset
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -29,6 +31,8 @@ type OrderedSetDesc[T constraints.Ordered] struct {
 	length       int64
 	highestLevel uint64 // highest level for now
 	header       *orderednodeDesc[T]
+	levels       *levelGenerator // nil means the default
+
 }
 
 type orderednodeDesc[T constraints.Ordered] struct {
@@ -109,6 +113,66 @@ func (s *OrderedSetDesc[T]) findNodeAdd(value T, preds *[maxLevel]*orderednodeDe
 	return -1
 }
 
+// findNodeAddHint is a variant of findNodeAdd for batch operations on values
+// in ascending order, the search at level i starts from preds[i] left by
+// the previous search if it is a better start point.
+// The preds must precede the value or be nil.
+func (s *OrderedSetDesc[T]) findNodeAddHint(value T, preds *[maxLevel]*orderednodeDesc[T], succs *[maxLevel]*orderednodeDesc[T]) int {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && (succ.value > value) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if succ != nil && succ.value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// findNodeRemoveHint is a variant of findNodeRemove which uses preds as hints,
+// see findNodeAddHint.
+func (s *OrderedSetDesc[T]) findNodeRemoveHint(value T, preds *[maxLevel]*orderednodeDesc[T], succs *[maxLevel]*orderednodeDesc[T]) int {
+	// lFound represents the index of the first layer at which it found a node.
+	lFound, x := -1, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && (succ.value > value) {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if lFound == -1 && succ != nil && succ.value == value {
+			lFound = i
+		}
+	}
+	return lFound
+}
+
+// further returns the one of x and hint which is closer to the tail.
+// A marked hint is ignored, it may have been unlinked and its next nodes are
+// no longer up to date.
+func (s *OrderedSetDesc[T]) further(x, hint *orderednodeDesc[T]) *orderednodeDesc[T] {
+	if hint == nil || hint == s.header || hint.flags.Get(marked) {
+		return x
+	}
+	if x == s.header || (x.value > hint.value) {
+		return hint
+	}
+	return x
+}
+
 func unlockorderedDesc[T constraints.Ordered](preds [maxLevel]*orderednodeDesc[T], highestLevel int) {
 	var prevPred *orderednodeDesc[T]
 	for i := highestLevel; i >= 0; i-- {
@@ -124,10 +188,16 @@ func unlockorderedDesc[T constraints.Ordered](preds [maxLevel]*orderednodeDesc[T
 //
 // If the value is in the skip set but not fully linked, this process will wait until it is.
 func (s *OrderedSetDesc[T]) Add(value T) bool {
-	level := s.randomlevel()
 	var preds, succs [maxLevel]*orderednodeDesc[T]
+	return s.add(value, &preds, &succs)
+}
+
+// add adds the value into skip set, the preds are used as hints of search,
+// see findNodeAddHint.
+func (s *OrderedSetDesc[T]) add(value T, preds, succs *[maxLevel]*orderednodeDesc[T]) bool {
+	level := s.randomlevel()
 	for {
-		lFound := s.findNodeAdd(value, &preds, &succs)
+		lFound := s.findNodeAddHint(value, preds, succs)
 		if lFound != -1 { // indicating the value is already in the skip-list
 			nodeFound := succs[lFound]
 			if !nodeFound.flags.Get(marked) {
@@ -138,6 +208,7 @@ func (s *OrderedSetDesc[T]) Add(value T) bool {
 			}
 			// If the node is marked, represents some other thread is in the process of deleting this node,
 			// we need to add this node in next loop.
+			*preds = [maxLevel]*orderednodeDesc[T]{}
 			continue
 		}
 		// Add this node into skip list.
@@ -161,7 +232,8 @@ func (s *OrderedSetDesc[T]) Add(value T) bool {
 			valid = !pred.flags.Get(marked) && (succ == nil || !succ.flags.Get(marked)) && pred.loadNext(layer) == succ
 		}
 		if !valid {
-			unlockorderedDesc(preds, highestLocked)
+			unlockorderedDesc(*preds, highestLocked)
+			*preds = [maxLevel]*orderednodeDesc[T]{}
 			continue
 		}
 
@@ -171,7 +243,7 @@ func (s *OrderedSetDesc[T]) Add(value T) bool {
 			preds[layer].atomicStoreNext(layer, nn)
 		}
 		nn.flags.SetTrue(fullyLinked)
-		unlockorderedDesc(preds, highestLocked)
+		unlockorderedDesc(*preds, highestLocked)
 		atomic.AddInt64(&s.length, 1)
 		return true
 	}
@@ -179,7 +251,7 @@ func (s *OrderedSetDesc[T]) Add(value T) bool {
 
 func (s *OrderedSetDesc[T]) randomlevel() int {
 	// Generate random level.
-	level := randomLevel()
+	level := s.levels.next()
 	// Update highest level if possible.
 	for {
 		hl := atomic.LoadUint64(&s.highestLevel)
@@ -213,14 +285,20 @@ func (s *OrderedSetDesc[T]) Contains(value T) bool {
 
 // Remove removes a node from the skip set.
 func (s *OrderedSetDesc[T]) Remove(value T) bool {
+	var preds, succs [maxLevel]*orderednodeDesc[T]
+	return s.remove(value, &preds, &succs)
+}
+
+// remove removes a node from the skip set, the preds are used as hints of
+// search, see findNodeAddHint.
+func (s *OrderedSetDesc[T]) remove(value T, preds, succs *[maxLevel]*orderednodeDesc[T]) bool {
 	var (
 		nodeToRemove *orderednodeDesc[T]
 		isMarked     bool // represents if this operation mark the node
 		topLayer     = -1
-		preds, succs [maxLevel]*orderednodeDesc[T]
 	)
 	for {
-		lFound := s.findNodeRemove(value, &preds, &succs)
+		lFound := s.findNodeRemoveHint(value, preds, succs)
 		if isMarked || // this process mark this node or we can find this node in the skip list
 			lFound != -1 && succs[lFound].flags.MGet(fullyLinked|marked, fullyLinked) && (int(succs[lFound].level)-1) == lFound {
 			if !isMarked { // we don't mark this node for now
@@ -257,7 +335,8 @@ func (s *OrderedSetDesc[T]) Remove(value T) bool {
 				valid = !pred.flags.Get(marked) && pred.loadNext(layer) == succ
 			}
 			if !valid {
-				unlockorderedDesc(preds, highestLocked)
+				unlockorderedDesc(*preds, highestLocked)
+				*preds = [maxLevel]*orderednodeDesc[T]{}
 				continue
 			}
 			for i := topLayer; i >= 0; i-- {
@@ -266,7 +345,7 @@ func (s *OrderedSetDesc[T]) Remove(value T) bool {
 				preds[i].atomicStoreNext(i, nodeToRemove.loadNext(i))
 			}
 			nodeToRemove.mu.Unlock()
-			unlockorderedDesc(preds, highestLocked)
+			unlockorderedDesc(*preds, highestLocked)
 			atomic.AddInt64(&s.length, -1)
 			return true
 		}
@@ -274,6 +353,96 @@ func (s *OrderedSetDesc[T]) Remove(value T) bool {
 	}
 }
 
+// AddBatch adds values into skip set and returns the number of added values.
+//
+// The values are added in order of skip set and the search of each value
+// starts from the position of the previous one, which is faster than
+// separate calls of [OrderedSetDesc.Add] when values are adjacent.
+func (s *OrderedSetDesc[T]) AddBatch(values []T) int {
+	return s.addSorted(s.sorted(values))
+}
+
+// LoadSorted is a variant of [OrderedSetDesc.AddBatch] for values already sorted
+// in the order of skip set, it adds values without copying and sorting.
+//
+// It panics if values are not sorted.
+func (s *OrderedSetDesc[T]) LoadSorted(values []T) int {
+	s.checkSorted(values)
+	return s.addSorted(values)
+}
+
+func (s *OrderedSetDesc[T]) addSorted(values []T) int {
+	var (
+		preds, succs [maxLevel]*orderednodeDesc[T]
+		n            int
+	)
+	for _, v := range values {
+		if s.add(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveBatch removes values from skip set and returns the number of removed values.
+//
+// Like [OrderedSetDesc.AddBatch], the values are removed in order of skip set and
+// the search of each value starts from the position of the previous one.
+func (s *OrderedSetDesc[T]) RemoveBatch(values []T) int {
+	var (
+		preds, succs [maxLevel]*orderednodeDesc[T]
+		n            int
+	)
+	for _, v := range s.sorted(values) {
+		if s.remove(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// sorted returns a sorted copy of values.
+func (s *OrderedSetDesc[T]) sorted(values []T) []T {
+	res := make([]T, len(values))
+	copy(res, values)
+	sort.Slice(res, func(i, j int) bool {
+		return (res[i] > res[j])
+	})
+	return res
+}
+
+// buildSorted links sorted values into the empty skip set in O(n),
+// duplicate values are ignored.
+// It must be called before the skip set is shared.
+func (s *OrderedSetDesc[T]) buildSorted(values []T) {
+	s.checkSorted(values)
+	var tails [maxLevel]*orderednodeDesc[T]
+	for i := range tails {
+		tails[i] = s.header
+	}
+	for i, v := range values {
+		if i > 0 && !(values[i-1] > v) {
+			continue
+		}
+		level := s.randomlevel()
+		nn := newOrderedNodeDesc(v, level)
+		nn.flags.SetTrue(fullyLinked)
+		for l := 0; l < level; l++ {
+			tails[l].storeNext(l, nn)
+			tails[l] = nn
+		}
+		s.length++
+	}
+}
+
+func (s *OrderedSetDesc[T]) checkSorted(values []T) {
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			panic(fmt.Errorf("values are not sorted at index %d", i))
+		}
+	}
+}
+
 // Range calls f sequentially for each value present in the skip set.
 // If f returns false, range stops the iteration.
 func (s *OrderedSetDesc[T]) Range(f func(value T) bool) {
@@ -290,6 +459,267 @@ func (s *OrderedSetDesc[T]) Range(f func(value T) bool) {
 	}
 }
 
+// RangeFrom is a variant of [OrderedSetDesc.Range], calls f sequentially for
+// each value which is after or equal to start in the order of the skip set.
+//
+// The start position is located by the index of skip list, so the complexity
+// of visiting k values is O(log n + k).
+func (s *OrderedSetDesc[T]) RangeFrom(start T, f func(value T) bool) {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		nex := x.atomicLoadNext(i)
+		for nex != nil && (nex.value > start) {
+			x = nex
+			nex = x.atomicLoadNext(i)
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// RangeReverse is a variant of [OrderedSetDesc.Range], calls f sequentially for
+// each value in the reverse order of the skip set.
+//
+// 💡 NOTE: Skip list has no backward links, the values are collected by a
+// forward scan before f is called, so it always takes O(n) time and memory
+// even if f stops the iteration early.
+func (s *OrderedSetDesc[T]) RangeReverse(f func(value T) bool) {
+	var nodes []*orderednodeDesc[T]
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			nodes = append(nodes, x)
+		}
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		x := nodes[i]
+		if x.flags.Get(marked) {
+			continue // removed after being collected
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// firstAfter returns the first value after the given value, or equal to it if inclusive.
+// If bounded is false, the value is ignored and the first value of skip set is returned.
+func (s *OrderedSetDesc[T]) firstAfter(value T, bounded, inclusive bool) (T, bool) {
+	x := s.header
+	if bounded {
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && ((nex.value > value) || !inclusive && nex.value == value) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// lastBefore returns the last value strictly before the given value.
+// If bounded is false, the value is ignored and the last value of skip set is returned.
+func (s *OrderedSetDesc[T]) lastBefore(value T, bounded bool) (T, bool) {
+	for {
+		x := s.header
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && (!bounded || (nex.value > value)) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+		if x == s.header {
+			var zero T
+			return zero, false
+		}
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+		// The node is being inserted or removed, find the one before it.
+		value, bounded = x.value, true
+	}
+}
+
+// Union returns the union of the skip set and other as a new skip set.
+//
+// The sets are walked with a sorted merge in O(n + m).
+// Like [OrderedSetDesc.Range], it does not necessarily correspond to any
+// consistent snapshot if the sets are modified concurrently.
+func (s *OrderedSetDesc[T]) Union(other *OrderedSetDesc[T]) *OrderedSetDesc[T] {
+	var values []T
+	s.merge(other, func(value T, _, _ bool) bool {
+		values = append(values, value)
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Intersect returns the intersection of the skip set and other as a new skip set.
+//
+// See [OrderedSetDesc.Union] for complexity and consistency.
+func (s *OrderedSetDesc[T]) Intersect(other *OrderedSetDesc[T]) *OrderedSetDesc[T] {
+	var values []T
+	s.merge(other, func(value T, inS, inOther bool) bool {
+		if inS && inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Diff returns the values of the skip set which are not in other as a new skip set.
+//
+// See [OrderedSetDesc.Union] for complexity and consistency.
+func (s *OrderedSetDesc[T]) Diff(other *OrderedSetDesc[T]) *OrderedSetDesc[T] {
+	var values []T
+	s.merge(other, func(value T, inS, inOther bool) bool {
+		if !inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// fromMerged builds a new skip set from the sorted result of merge in O(n).
+func (s *OrderedSetDesc[T]) fromMerged(values []T) *OrderedSetDesc[T] {
+	res := s.empty()
+	res.buildSorted(values)
+	return res
+}
+
+// IsSubset returns whether other contains all values of the skip set.
+//
+// See [OrderedSetDesc.Union] for complexity and consistency.
+func (s *OrderedSetDesc[T]) IsSubset(other *OrderedSetDesc[T]) bool {
+	res := true
+	s.merge(other, func(_ T, _, inOther bool) bool {
+		res = inOther
+		return res
+	})
+	return res
+}
+
+// Equal returns whether the skip set and other contain the same values.
+//
+// See [OrderedSetDesc.Union] for complexity and consistency.
+func (s *OrderedSetDesc[T]) Equal(other *OrderedSetDesc[T]) bool {
+	res := true
+	s.merge(other, func(_ T, inS, inOther bool) bool {
+		res = inS && inOther
+		return res
+	})
+	return res
+}
+
+// empty returns an empty skip set with the same order.
+func (s *OrderedSetDesc[T]) empty() *OrderedSetDesc[T] {
+	return NewDesc[T]()
+}
+
+// merge walks the values of the skip set and other in order,
+// and calls f with each value and whether it is in each set.
+// If f returns false, merge stops the walk.
+//
+// The order of the skip set is used, other must have the same order.
+func (s *OrderedSetDesc[T]) merge(other *OrderedSetDesc[T], f func(value T, inS, inOther bool) bool) {
+	a, b := s.header.atomicLoadNext(0), other.header.atomicLoadNext(0)
+	for {
+		a, b = validFromorderedDesc(a), validFromorderedDesc(b)
+		switch {
+		case a == nil && b == nil:
+			return
+		case b == nil || a != nil && (a.value > b.value):
+			if !f(a.value, true, false) {
+				return
+			}
+			a = a.atomicLoadNext(0)
+		case a == nil || (b.value > a.value):
+			if !f(b.value, false, true) {
+				return
+			}
+			b = b.atomicLoadNext(0)
+		default:
+			if !f(a.value, true, true) {
+				return
+			}
+			a, b = a.atomicLoadNext(0), b.atomicLoadNext(0)
+		}
+	}
+}
+
+// validFrom returns the first valid node starting from node x at level 0.
+func validFromorderedDesc[T constraints.Ordered](x *orderednodeDesc[T]) *orderednodeDesc[T] {
+	for x != nil && !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+		x = x.atomicLoadNext(0)
+	}
+	return x
+}
+
+// Stats returns the structural statistics of the skip set.
+//
+// It visits every node and searches every value, so the complexity is O(n log n),
+// it is intended for debugging and tuning.
+// Like [OrderedSetDesc.Range], it does not necessarily correspond to any
+// consistent snapshot if the skip set is modified concurrently.
+func (s *OrderedSetDesc[T]) Stats() Stats {
+	st := Stats{
+		HighestLevel: int(atomic.LoadUint64(&s.highestLevel)),
+		Levels:       make([]int, maxLevel),
+	}
+	var path, highest int
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		st.Nodes++
+		st.Levels[x.level-1]++
+		if int(x.level) > highest {
+			highest = int(x.level)
+		}
+		path += s.searchPath(x.value)
+	}
+	st.Levels = st.Levels[:highest]
+	if st.Nodes > 0 {
+		st.AvgSearchPath = float64(path) / float64(st.Nodes)
+	}
+	return st
+}
+
+// searchPath returns the number of nodes visited by searching the value.
+func (s *OrderedSetDesc[T]) searchPath(value T) int {
+	n, x := 0, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		succ := x.atomicLoadNext(i)
+		for succ != nil {
+			n++
+			if !(succ.value > value) {
+				break
+			}
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		if succ != nil && succ.value == value {
+			return n
+		}
+	}
+	return n
+}
+
 // Len returns the length of this skip set.
 func (s *OrderedSetDesc[T]) Len() int {
 	return int(atomic.LoadInt64(&s.length))
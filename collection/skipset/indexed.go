@@ -0,0 +1,149 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipset
+
+import (
+	"github.com/bytedance/gg/collection/skipmap"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/constraints"
+)
+
+// IndexedSet is a sorted set based on skip list which supports order
+// statistics: [IndexedSet.Rank], [IndexedSet.At] and [IndexedSet.CountBetween]
+// run in O(log n).
+//
+// All operations are protected by a read-write mutex, see [skipmap.IndexedMap].
+type IndexedSet[T any] struct {
+	m *skipmap.IndexedMap[T, struct{}]
+}
+
+// NewIndexed returns an empty indexed skip set in ascending order.
+func NewIndexed[T constraints.Ordered]() *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexed[T, struct{}]()}
+}
+
+// NewIndexedDesc returns an empty indexed skip set in descending order.
+func NewIndexedDesc[T constraints.Ordered]() *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexedDesc[T, struct{}]()}
+}
+
+// NewIndexedFunc returns an empty indexed skip set in ascending order
+// defined by less.
+//
+// Note that the less function requires a strict weak ordering,
+// see https://en.wikipedia.org/wiki/Weak_ordering#Strict_weak_orderings,
+// or undefined behavior will happen.
+func NewIndexedFunc[T any](less func(a, b T) bool) *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexedFunc[T, struct{}](less)}
+}
+
+// NewIndexedWithOptions is a variant of [NewIndexed], returns an empty indexed
+// skip set in ascending order with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedWithOptions[T constraints.Ordered](opts Options) *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexedWithOptions[T, struct{}](opts.mapOptions())}
+}
+
+// NewIndexedDescWithOptions is a variant of [NewIndexedDesc], returns an empty
+// indexed skip set in descending order with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedDescWithOptions[T constraints.Ordered](opts Options) *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexedDescWithOptions[T, struct{}](opts.mapOptions())}
+}
+
+// NewIndexedFuncWithOptions is a variant of [NewIndexedFunc], returns an empty
+// indexed skip set in ascending order defined by less with opts.
+//
+// It panics if opts is invalid.
+func NewIndexedFuncWithOptions[T any](less func(a, b T) bool, opts Options) *IndexedSet[T] {
+	return &IndexedSet[T]{skipmap.NewIndexedFuncWithOptions[T, struct{}](less, opts.mapOptions())}
+}
+
+// mapOptions converts opts to the options of the underlying indexed skipmap.
+func (opts Options) mapOptions() skipmap.Options {
+	return skipmap.Options{P: opts.P, MaxLevel: opts.MaxLevel, Seed: opts.Seed}
+}
+
+// Add adds the value into the set, returns true if this process insert the value into the set,
+// returns false if this process can't insert this value, because another process has inserted the same value.
+func (s *IndexedSet[T]) Add(value T) bool {
+	_, loaded := s.m.LoadOrStore(value, struct{}{})
+	return !loaded
+}
+
+// Contains checks if the value is in the skip set.
+func (s *IndexedSet[T]) Contains(value T) bool {
+	_, ok := s.m.Load(value)
+	return ok
+}
+
+// Remove removes a node from the skip set.
+func (s *IndexedSet[T]) Remove(value T) bool {
+	return s.m.Delete(value)
+}
+
+// Range calls f sequentially for each value present in the skip set.
+// If f returns false, range stops the iteration.
+//
+// The lock is not held while calling f, so f can modify the set.
+func (s *IndexedSet[T]) Range(f func(value T) bool) {
+	s.m.Range(func(value T, _ struct{}) bool {
+		return f(value)
+	})
+}
+
+// Len returns the length of this skip set.
+func (s *IndexedSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// ToSlice returns all values of the skip set in order.
+func (s *IndexedSet[T]) ToSlice() []T {
+	res := make([]T, 0, s.Len())
+	s.Range(func(value T) bool {
+		res = append(res, value)
+		return true
+	})
+	return res
+}
+
+// Rank returns the 0-based position of value in the order of set,
+// and whether the value is present.
+// If the value is not present, the position is where it would be inserted.
+// The complexity is O(log n).
+func (s *IndexedSet[T]) Rank(value T) (rank int, ok bool) {
+	return s.m.Rank(value)
+}
+
+// At returns the value at 0-based position i in the order of set.
+// If i is out of range, return nil.
+// The complexity is O(log n).
+func (s *IndexedSet[T]) At(i int) goption.O[T] {
+	e := s.m.At(i)
+	if !e.IsOK() {
+		return goption.Nil[T]()
+	}
+	return goption.OK(e.Value().First)
+}
+
+// CountBetween returns the number of values between lo and hi in the order of
+// set, loInclusive and hiInclusive control whether lo and hi themselves are
+// counted.
+// The complexity is O(log n).
+func (s *IndexedSet[T]) CountBetween(lo, hi T, loInclusive, hiInclusive bool) int {
+	return s.m.CountBetween(lo, hi, loInclusive, hiInclusive)
+}
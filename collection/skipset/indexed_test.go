@@ -0,0 +1,87 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipset
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestIndexed(t *testing.T) {
+	s := NewIndexed[int]()
+	assert.False(t, s.At(0).IsOK())
+	for _, v := range []int{30, 10, 20} {
+		assert.True(t, s.Add(v))
+	}
+	assert.False(t, s.Add(20))
+	assert.True(t, s.Contains(20))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []int{10, 20, 30}, s.ToSlice())
+
+	rank, ok := s.Rank(20)
+	assert.Equal(t, 1, rank)
+	assert.True(t, ok)
+	rank, ok = s.Rank(25)
+	assert.Equal(t, 2, rank)
+	assert.False(t, ok)
+	assert.Equal(t, 30, s.At(2).Value())
+	assert.False(t, s.At(3).IsOK())
+	assert.Equal(t, 2, s.CountBetween(10, 30, true, false))
+
+	assert.True(t, s.Remove(10))
+	assert.False(t, s.Remove(10))
+	assert.Equal(t, 20, s.At(0).Value())
+
+	d := NewIndexedDesc[int]()
+	f := NewIndexedFunc[int](func(a, b int) bool { return a > b })
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g; i < 1000; i += 4 {
+				d.Add(i)
+				f.Add(i)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, 999-i, d.At(i).Value())
+		assert.Equal(t, 999-i, f.At(i).Value())
+	}
+	assert.Equal(t, 100, d.CountBetween(99, 0, true, true))
+}
+
+func TestIndexedOptions(t *testing.T) {
+	s := NewIndexedWithOptions[int](Options{Seed: 1})
+	d := NewIndexedDescWithOptions[int](Options{MaxLevel: 2})
+	f := NewIndexedFuncWithOptions(func(a, b int) bool { return a < b }, Options{P: 0.5})
+	for _, v := range []int{30, 10, 20} {
+		s.Add(v)
+		d.Add(v)
+		f.Add(v)
+	}
+	assert.Equal(t, []int{10, 20, 30}, s.ToSlice())
+	assert.Equal(t, []int{30, 20, 10}, d.ToSlice())
+	assert.Equal(t, []int{10, 20, 30}, f.ToSlice())
+	assert.Equal(t, 30, s.At(2).Value())
+
+	assert.Panic(t, func() { NewIndexedWithOptions[int](Options{P: 1}) })
+	assert.Panic(t, func() { NewIndexedWithOptions[int](Options{MaxLevel: 17}) })
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package skipset
+
+import "iter"
+
+// All returns an iterator over values of the skip set in order.
+// It has the same semantics as [OrderedSet.Range].
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return s.Range
+}
+
+// Backward returns an iterator over values of the skip set in reverse order.
+// It has the same semantics as [OrderedSet.RangeReverse].
+func (s *OrderedSet[T]) Backward() iter.Seq[T] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over values which are after or equal to start
+// in order.
+// It has the same semantics as [OrderedSet.RangeFrom].
+func (s *OrderedSet[T]) From(start T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
+
+// All returns an iterator over values of the skip set in order.
+// It has the same semantics as [OrderedSetDesc.Range].
+func (s *OrderedSetDesc[T]) All() iter.Seq[T] {
+	return s.Range
+}
+
+// Backward returns an iterator over values of the skip set in reverse order.
+// It has the same semantics as [OrderedSetDesc.RangeReverse].
+func (s *OrderedSetDesc[T]) Backward() iter.Seq[T] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over values which are after or equal to start
+// in order.
+// It has the same semantics as [OrderedSetDesc.RangeFrom].
+func (s *OrderedSetDesc[T]) From(start T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
+
+// All returns an iterator over values of the skip set in order.
+// It has the same semantics as [FuncSet.Range].
+func (s *FuncSet[T]) All() iter.Seq[T] {
+	return s.Range
+}
+
+// Backward returns an iterator over values of the skip set in reverse order.
+// It has the same semantics as [FuncSet.RangeReverse].
+func (s *FuncSet[T]) Backward() iter.Seq[T] {
+	return s.RangeReverse
+}
+
+// From returns an iterator over values which are after or equal to start
+// in order.
+// It has the same semantics as [FuncSet.RangeFrom].
+func (s *FuncSet[T]) From(start T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.RangeFrom(start, yield)
+	}
+}
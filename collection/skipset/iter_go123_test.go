@@ -0,0 +1,74 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package skipset
+
+import (
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestIter(t *testing.T) {
+	s := New[int]()
+	d := NewDesc[int]()
+	f := NewFunc[int](func(a, b int) bool { return a > b })
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+		d.Add(i)
+		f.Add(i)
+	}
+
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	for v := range s.Backward() {
+		got = append(got, v)
+	}
+	for v := range s.From(3) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 4, 3, 2, 1, 0, 3, 4}, got)
+
+	got = nil
+	for v := range d.All() {
+		got = append(got, v)
+	}
+	for v := range d.Backward() {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	for v := range d.From(1) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{4, 3, 2, 1, 0, 0, 1, 2, 1, 0}, got)
+
+	got = nil
+	for v := range f.All() {
+		got = append(got, v)
+	}
+	for v := range f.Backward() {
+		got = append(got, v)
+	}
+	for v := range f.From(1) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{4, 3, 2, 1, 0, 0, 1, 2, 3, 4, 1, 0}, got)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skipset
+
+import (
+	"encoding/json"
+
+	"github.com/bytedance/gg/internal/jsonbuilder"
+)
+
+// MarshalJSON returns s as the JSON encoding of s.
+//
+// The returned bytes is null or JSON array, elements are in the order of set.
+func (s *OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	enc := jsonbuilder.NewArray()
+	var err error
+	s.Range(func(value T) bool {
+		err = enc.Append(value)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc.Build()
+}
+
+// UnmarshalJSON adds the values of JSON array data into s.
+func (s *OrderedSet[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return nil
+}
+
+// MarshalJSON returns s as the JSON encoding of s.
+//
+// The returned bytes is null or JSON array, elements are in the order of set.
+func (s *OrderedSetDesc[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	enc := jsonbuilder.NewArray()
+	var err error
+	s.Range(func(value T) bool {
+		err = enc.Append(value)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc.Build()
+}
+
+// UnmarshalJSON adds the values of JSON array data into s.
+func (s *OrderedSetDesc[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return nil
+}
+
+// MarshalJSON returns s as the JSON encoding of s.
+//
+// The returned bytes is null or JSON array, elements are in the order of set.
+func (s *FuncSet[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	enc := jsonbuilder.NewArray()
+	var err error
+	s.Range(func(value T) bool {
+		err = enc.Append(value)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc.Build()
+}
+
+// UnmarshalJSON adds the values of JSON array data into s.
+func (s *FuncSet[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return nil
+}
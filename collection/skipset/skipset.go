@@ -58,3 +58,62 @@ func NewFunc[T any](less func(a, b T) bool) *FuncSet[T] {
 		less:         less,
 	}
 }
+
+// NewFromSorted returns a skip set in ascending order which contains values,
+// the values must be sorted in ascending order, duplicate values are ignored.
+//
+// The skip set is built in O(n) without locking, which is much faster than
+// adding values one by one.
+//
+// It panics if values are not sorted.
+func NewFromSorted[T constraints.Ordered](values []T) *OrderedSet[T] {
+	s := New[T]()
+	s.buildSorted(values)
+	return s
+}
+
+// NewDescFromSorted is a variant of [NewFromSorted], returns a skip set in
+// descending order, the values must be sorted in descending order.
+func NewDescFromSorted[T constraints.Ordered](values []T) *OrderedSetDesc[T] {
+	s := NewDesc[T]()
+	s.buildSorted(values)
+	return s
+}
+
+// NewFuncFromSorted is a variant of [NewFromSorted], returns a skip set in
+// ascending order defined by less, the values must be sorted by less.
+func NewFuncFromSorted[T any](less func(a, b T) bool, values []T) *FuncSet[T] {
+	s := NewFunc(less)
+	s.buildSorted(values)
+	return s
+}
+
+// NewWithOptions is a variant of [New], returns an empty skip set in ascending
+// order whose levels of nodes are generated with opts.
+//
+// It panics if opts is invalid.
+func NewWithOptions[T constraints.Ordered](opts Options) *OrderedSet[T] {
+	s := New[T]()
+	s.levels = newLevelGenerator(opts)
+	return s
+}
+
+// NewDescWithOptions is a variant of [NewDesc], returns an empty skip set in
+// descending order whose levels of nodes are generated with opts.
+//
+// It panics if opts is invalid.
+func NewDescWithOptions[T constraints.Ordered](opts Options) *OrderedSetDesc[T] {
+	s := NewDesc[T]()
+	s.levels = newLevelGenerator(opts)
+	return s
+}
+
+// NewFuncWithOptions is a variant of [NewFunc], returns an empty skip set in
+// ascending order defined by less whose levels of nodes are generated with opts.
+//
+// It panics if opts is invalid.
+func NewFuncWithOptions[T any](less func(a, b T) bool, opts Options) *FuncSet[T] {
+	s := NewFunc(less)
+	s.levels = newLevelGenerator(opts)
+	return s
+}
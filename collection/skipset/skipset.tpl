@@ -11,6 +11,7 @@ type {{.StructPrefix}}Set{{.StructSuffix}}{{.TypeParam}} struct {
 	length       int64
 	highestLevel uint64 // highest level for now
 	header       *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+	levels       *levelGenerator // nil means the default
     {{.ExtraFields}}
 }
 
@@ -92,6 +93,66 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) findNodeAdd(val
 	return -1
 }
 
+// findNodeAddHint is a variant of findNodeAdd for batch operations on values
+// in ascending order, the search at level i starts from preds[i] left by
+// the previous search if it is a better start point.
+// The preds must precede the value or be nil.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) findNodeAddHint(value {{.Type}}, preds *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}, succs *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) int {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && {{Less "succ.value" "value"}} {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if succ != nil && {{Equal "succ.value" "value"}} {
+			return i
+		}
+	}
+	return -1
+}
+
+// findNodeRemoveHint is a variant of findNodeRemove which uses preds as hints,
+// see findNodeAddHint.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) findNodeRemoveHint(value {{.Type}}, preds *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}, succs *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) int {
+	// lFound represents the index of the first layer at which it found a node.
+	lFound, x := -1, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		x = s.further(x, preds[i])
+		succ := x.atomicLoadNext(i)
+		for succ != nil && {{Less "succ.value" "value"}} {
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		preds[i] = x
+		succs[i] = succ
+
+		// Check if the value already in the skip list.
+		if lFound == -1 && succ != nil && {{Equal "succ.value" "value"}} {
+			lFound = i
+		}
+	}
+	return lFound
+}
+
+// further returns the one of x and hint which is closer to the tail.
+// A marked hint is ignored, it may have been unlinked and its next nodes are
+// no longer up to date.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) further(x, hint *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}} {
+	if hint == nil || hint == s.header || hint.flags.Get(marked) {
+		return x
+	}
+	if x == s.header || {{Less "x.value" "hint.value"}} {
+		return hint
+	}
+	return x
+}
+
 func unlock{{.Name}}{{.TypeParam}}(preds [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}, highestLevel int) {
 	var prevPred *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
 	for i := highestLevel; i >= 0; i-- {
@@ -107,10 +168,16 @@ func unlock{{.Name}}{{.TypeParam}}(preds [maxLevel]*{{.StructPrefixLow}}node{{.S
 //
 // If the value is in the skip set but not fully linked, this process will wait until it is.
 func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Add(value {{.Type}}) bool {
-	level := s.randomlevel()
 	var preds, succs [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+	return s.add(value, &preds, &succs)
+}
+
+// add adds the value into skip set, the preds are used as hints of search,
+// see findNodeAddHint.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) add(value {{.Type}}, preds, succs *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) bool {
+	level := s.randomlevel()
 	for {
-		lFound := s.findNodeAdd(value, &preds, &succs)
+		lFound := s.findNodeAddHint(value, preds, succs)
 		if lFound != -1 { // indicating the value is already in the skip-list
 			nodeFound := succs[lFound]
 			if !nodeFound.flags.Get(marked) {
@@ -121,6 +188,7 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Add(value {{.Ty
 			}
 			// If the node is marked, represents some other thread is in the process of deleting this node,
 			// we need to add this node in next loop.
+			*preds = [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}{}
 			continue
 		}
 		// Add this node into skip list.
@@ -144,7 +212,8 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Add(value {{.Ty
 			valid = !pred.flags.Get(marked) && (succ == nil || !succ.flags.Get(marked)) && pred.loadNext(layer) == succ
 		}
 		if !valid {
-			unlock{{.Name}}(preds, highestLocked)
+			unlock{{.Name}}(*preds, highestLocked)
+			*preds = [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}{}
 			continue
 		}
 
@@ -154,7 +223,7 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Add(value {{.Ty
 			preds[layer].atomicStoreNext(layer, nn)
 		}
 		nn.flags.SetTrue(fullyLinked)
-		unlock{{.Name}}(preds, highestLocked)
+		unlock{{.Name}}(*preds, highestLocked)
 		atomic.AddInt64(&s.length, 1)
 		return true
 	}
@@ -162,7 +231,7 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Add(value {{.Ty
 
 func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) randomlevel() int {
 	// Generate random level.
-	level := randomLevel()
+	level := s.levels.next()
 	// Update highest level if possible.
 	for {
 		hl := atomic.LoadUint64(&s.highestLevel)
@@ -196,14 +265,20 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Contains(value
 
 // Remove removes a node from the skip set.
 func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Remove(value {{.Type}}) bool {
+	var preds, succs [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+	return s.remove(value, &preds, &succs)
+}
+
+// remove removes a node from the skip set, the preds are used as hints of
+// search, see findNodeAddHint.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) remove(value {{.Type}}, preds, succs *[maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) bool {
 	var (
 		nodeToRemove *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
 		isMarked     bool // represents if this operation mark the node
 		topLayer     = -1
-		preds, succs [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
 	)
 	for {
-		lFound := s.findNodeRemove(value, &preds, &succs)
+		lFound := s.findNodeRemoveHint(value, preds, succs)
 		if isMarked || // this process mark this node or we can find this node in the skip list
 			lFound != -1 && succs[lFound].flags.MGet(fullyLinked|marked, fullyLinked) && (int(succs[lFound].level)-1) == lFound {
 			if !isMarked { // we don't mark this node for now
@@ -240,7 +315,8 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Remove(value {{
 				valid = !pred.flags.Get(marked) && pred.loadNext(layer) == succ
 			}
 			if !valid {
-				unlock{{.Name}}(preds, highestLocked)
+				unlock{{.Name}}(*preds, highestLocked)
+				*preds = [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}{}
 				continue
 			}
 			for i := topLayer; i >= 0; i-- {
@@ -249,7 +325,7 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Remove(value {{
 				preds[i].atomicStoreNext(i, nodeToRemove.loadNext(i))
 			}
 			nodeToRemove.mu.Unlock()
-			unlock{{.Name}}(preds, highestLocked)
+			unlock{{.Name}}(*preds, highestLocked)
 			atomic.AddInt64(&s.length, -1)
 			return true
 		}
@@ -257,6 +333,96 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Remove(value {{
 	}
 }
 
+// AddBatch adds values into skip set and returns the number of added values.
+//
+// The values are added in order of skip set and the search of each value
+// starts from the position of the previous one, which is faster than
+// separate calls of [{{.StructPrefix}}Set{{.StructSuffix}}.Add] when values are adjacent.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) AddBatch(values []{{.Type}}) int {
+	return s.addSorted(s.sorted(values))
+}
+
+// LoadSorted is a variant of [{{.StructPrefix}}Set{{.StructSuffix}}.AddBatch] for values already sorted
+// in the order of skip set, it adds values without copying and sorting.
+//
+// It panics if values are not sorted.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) LoadSorted(values []{{.Type}}) int {
+	s.checkSorted(values)
+	return s.addSorted(values)
+}
+
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) addSorted(values []{{.Type}}) int {
+	var (
+		preds, succs [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+		n            int
+	)
+	for _, v := range values {
+		if s.add(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveBatch removes values from skip set and returns the number of removed values.
+//
+// Like [{{.StructPrefix}}Set{{.StructSuffix}}.AddBatch], the values are removed in order of skip set and
+// the search of each value starts from the position of the previous one.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) RemoveBatch(values []{{.Type}}) int {
+	var (
+		preds, succs [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+		n            int
+	)
+	for _, v := range s.sorted(values) {
+		if s.remove(v, &preds, &succs) {
+			n++
+		}
+	}
+	return n
+}
+
+// sorted returns a sorted copy of values.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) sorted(values []{{.Type}}) []{{.Type}} {
+	res := make([]{{.Type}}, len(values))
+	copy(res, values)
+	sort.Slice(res, func(i, j int) bool {
+		return {{Less "res[i]" "res[j]"}}
+	})
+	return res
+}
+
+// buildSorted links sorted values into the empty skip set in O(n),
+// duplicate values are ignored.
+// It must be called before the skip set is shared.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) buildSorted(values []{{.Type}}) {
+	s.checkSorted(values)
+	var tails [maxLevel]*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+	for i := range tails {
+		tails[i] = s.header
+	}
+	for i, v := range values {
+		if i > 0 && !{{Less "values[i-1]" "v"}} {
+			continue
+		}
+		level := s.randomlevel()
+		nn := new{{.StructPrefix}}Node{{.StructSuffix}}(v, level)
+		nn.flags.SetTrue(fullyLinked)
+		for l := 0; l < level; l++ {
+			tails[l].storeNext(l, nn)
+			tails[l] = nn
+		}
+		s.length++
+	}
+}
+
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) checkSorted(values []{{.Type}}) {
+	for i := 1; i < len(values); i++ {
+		if {{Less "values[i]" "values[i-1]"}} {
+			panic(fmt.Errorf("values are not sorted at index %d", i))
+		}
+	}
+}
+
 // Range calls f sequentially for each value present in the skip set.
 // If f returns false, range stops the iteration.
 func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Range(f func(value {{.Type}}) bool) {
@@ -273,6 +439,271 @@ func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Range(f func(va
 	}
 }
 
+// RangeFrom is a variant of [{{.StructPrefix}}Set{{.StructSuffix}}.Range], calls f sequentially for
+// each value which is after or equal to start in the order of the skip set.
+//
+// The start position is located by the index of skip list, so the complexity
+// of visiting k values is O(log n + k).
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) RangeFrom(start {{.Type}}, f func(value {{.Type}}) bool) {
+	x := s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		nex := x.atomicLoadNext(i)
+		for nex != nil && {{Less "nex.value" "start"}} {
+			x = nex
+			nex = x.atomicLoadNext(i)
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// RangeReverse is a variant of [{{.StructPrefix}}Set{{.StructSuffix}}.Range], calls f sequentially for
+// each value in the reverse order of the skip set.
+//
+// 💡 NOTE: Skip list has no backward links, the values are collected by a
+// forward scan before f is called, so it always takes O(n) time and memory
+// even if f stops the iteration early.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) RangeReverse(f func(value {{.Type}}) bool) {
+	var nodes []*{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			nodes = append(nodes, x)
+		}
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		x := nodes[i]
+		if x.flags.Get(marked) {
+			continue // removed after being collected
+		}
+		if !f(x.value) {
+			break
+		}
+	}
+}
+
+// firstAfter returns the first value after the given value, or equal to it if inclusive.
+// If bounded is false, the value is ignored and the first value of skip set is returned.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) firstAfter(value {{.Type}}, bounded, inclusive bool) ({{.Type}}, bool) {
+	x := s.header
+	if bounded {
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && ({{Less "nex.value" "value"}} || !inclusive && {{Equal "nex.value" "value"}}) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+	}
+	for x = x.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+	}
+	var zero {{.Type}}
+	return zero, false
+}
+
+// lastBefore returns the last value strictly before the given value.
+// If bounded is false, the value is ignored and the last value of skip set is returned.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) lastBefore(value {{.Type}}, bounded bool) ({{.Type}}, bool) {
+	for {
+		x := s.header
+		for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+			nex := x.atomicLoadNext(i)
+			for nex != nil && (!bounded || {{Less "nex.value" "value"}}) {
+				x = nex
+				nex = x.atomicLoadNext(i)
+			}
+		}
+		if x == s.header {
+			var zero {{.Type}}
+			return zero, false
+		}
+		if x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			return x.value, true
+		}
+		// The node is being inserted or removed, find the one before it.
+		value, bounded = x.value, true
+	}
+}
+
+// Union returns the union of the skip set and other as a new skip set.
+//
+// The sets are walked with a sorted merge in O(n + m).
+// Like [{{.StructPrefix}}Set{{.StructSuffix}}.Range], it does not necessarily correspond to any
+// consistent snapshot if the sets are modified concurrently.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Union(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}} {
+	var values []{{.Type}}
+	s.merge(other, func(value {{.Type}}, _, _ bool) bool {
+		values = append(values, value)
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Intersect returns the intersection of the skip set and other as a new skip set.
+//
+// See [{{.StructPrefix}}Set{{.StructSuffix}}.Union] for complexity and consistency.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Intersect(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}} {
+	var values []{{.Type}}
+	s.merge(other, func(value {{.Type}}, inS, inOther bool) bool {
+		if inS && inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// Diff returns the values of the skip set which are not in other as a new skip set.
+//
+// See [{{.StructPrefix}}Set{{.StructSuffix}}.Union] for complexity and consistency.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Diff(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}} {
+	var values []{{.Type}}
+	s.merge(other, func(value {{.Type}}, inS, inOther bool) bool {
+		if !inOther {
+			values = append(values, value)
+		}
+		return true
+	})
+	return s.fromMerged(values)
+}
+
+// fromMerged builds a new skip set from the sorted result of merge in O(n).
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) fromMerged(values []{{.Type}}) *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}} {
+	res := s.empty()
+	res.buildSorted(values)
+	return res
+}
+
+// IsSubset returns whether other contains all values of the skip set.
+//
+// See [{{.StructPrefix}}Set{{.StructSuffix}}.Union] for complexity and consistency.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) IsSubset(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) bool {
+	res := true
+	s.merge(other, func(_ {{.Type}}, _, inOther bool) bool {
+		res = inOther
+		return res
+	})
+	return res
+}
+
+// Equal returns whether the skip set and other contain the same values.
+//
+// See [{{.StructPrefix}}Set{{.StructSuffix}}.Union] for complexity and consistency.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Equal(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) bool {
+	res := true
+	s.merge(other, func(_ {{.Type}}, inS, inOther bool) bool {
+		res = inS && inOther
+		return res
+	})
+	return res
+}
+
+// empty returns an empty skip set with the same order.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) empty() *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}} {
+	{{- if eq .Name "func" }}
+	return NewFunc(s.less)
+	{{- else }}
+	return New{{.StructSuffix}}{{.TypeArgument}}()
+	{{- end }}
+}
+
+// merge walks the values of the skip set and other in order,
+// and calls f with each value and whether it is in each set.
+// If f returns false, merge stops the walk.
+//
+// The order of the skip set is used, other must have the same order.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) merge(other *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}, f func(value {{.Type}}, inS, inOther bool) bool) {
+	a, b := s.header.atomicLoadNext(0), other.header.atomicLoadNext(0)
+	for {
+		a, b = validFrom{{.Name}}(a), validFrom{{.Name}}(b)
+		switch {
+		case a == nil && b == nil:
+			return
+		case b == nil || a != nil && {{Less "a.value" "b.value"}}:
+			if !f(a.value, true, false) {
+				return
+			}
+			a = a.atomicLoadNext(0)
+		case a == nil || {{Less "b.value" "a.value"}}:
+			if !f(b.value, false, true) {
+				return
+			}
+			b = b.atomicLoadNext(0)
+		default:
+			if !f(a.value, true, true) {
+				return
+			}
+			a, b = a.atomicLoadNext(0), b.atomicLoadNext(0)
+		}
+	}
+}
+
+// validFrom returns the first valid node starting from node x at level 0.
+func validFrom{{.Name}}{{.TypeParam}}(x *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}}) *{{.StructPrefixLow}}node{{.StructSuffix}}{{.TypeArgument}} {
+	for x != nil && !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+		x = x.atomicLoadNext(0)
+	}
+	return x
+}
+
+// Stats returns the structural statistics of the skip set.
+//
+// It visits every node and searches every value, so the complexity is O(n log n),
+// it is intended for debugging and tuning.
+// Like [{{.StructPrefix}}Set{{.StructSuffix}}.Range], it does not necessarily correspond to any
+// consistent snapshot if the skip set is modified concurrently.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Stats() Stats {
+	st := Stats{
+		HighestLevel: int(atomic.LoadUint64(&s.highestLevel)),
+		Levels:       make([]int, maxLevel),
+	}
+	var path, highest int
+	for x := s.header.atomicLoadNext(0); x != nil; x = x.atomicLoadNext(0) {
+		if !x.flags.MGet(fullyLinked|marked, fullyLinked) {
+			continue
+		}
+		st.Nodes++
+		st.Levels[x.level-1]++
+		if int(x.level) > highest {
+			highest = int(x.level)
+		}
+		path += s.searchPath(x.value)
+	}
+	st.Levels = st.Levels[:highest]
+	if st.Nodes > 0 {
+		st.AvgSearchPath = float64(path) / float64(st.Nodes)
+	}
+	return st
+}
+
+// searchPath returns the number of nodes visited by searching the value.
+func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) searchPath(value {{.Type}}) int {
+	n, x := 0, s.header
+	for i := int(atomic.LoadUint64(&s.highestLevel)) - 1; i >= 0; i-- {
+		succ := x.atomicLoadNext(i)
+		for succ != nil {
+			n++
+			if !{{Less "succ.value" "value"}} {
+				break
+			}
+			x = succ
+			succ = x.atomicLoadNext(i)
+		}
+		if succ != nil && {{Equal "succ.value" "value"}} {
+			return n
+		}
+	}
+	return n
+}
+
 // Len returns the length of this skip set.
 func (s *{{.StructPrefix}}Set{{.StructSuffix}}{{.TypeArgument}}) Len() int {
 	return int(atomic.LoadInt64(&s.length))
@@ -15,6 +15,7 @@
 package skipset
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 )
@@ -57,3 +58,72 @@ func Example() {
 	// [11 12]
 	// 1000
 }
+
+func ExampleIndexedSet() {
+	s := NewIndexed[int]()
+	for _, v := range []int{50, 10, 30, 20, 40} {
+		s.Add(v)
+	}
+
+	fmt.Println(s.Rank(30))
+	fmt.Println(s.At(4).Value())
+	fmt.Println(s.CountBetween(15, 45, true, true))
+
+	// Output:
+	// 2 true
+	// 50
+	// 3
+}
+
+func ExampleOrderedSet_Union() {
+	a, b := New[int](), New[int]()
+	for _, v := range []int{1, 2, 3} {
+		a.Add(v)
+	}
+	for _, v := range []int{2, 3, 4} {
+		b.Add(v)
+	}
+
+	fmt.Println(a.Union(b).ToSlice())
+	fmt.Println(a.Intersect(b).ToSlice())
+	fmt.Println(a.Diff(b).ToSlice())
+	fmt.Println(a.Intersect(b).IsSubset(a))
+
+	bs, _ := json.Marshal(a.Union(b))
+	fmt.Println(string(bs))
+
+	// Output:
+	// [1 2 3 4]
+	// [2 3]
+	// [1]
+	// true
+	// [1,2,3,4]
+}
+
+func ExampleNewFromSorted() {
+	s := NewFromSorted([]int{1, 2, 3, 5, 8})
+
+	fmt.Println(s.AddBatch([]int{13, 4, 1}))
+	fmt.Println(s.RemoveBatch([]int{8, 2, 100}))
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// 2
+	// 2
+	// [1 3 4 5 13]
+}
+
+func ExampleCursor() {
+	s := NewFromSorted([]int{1, 2, 3, 4, 5, 6, 7})
+
+	// Walk backward from 5.
+	c := s.Cursor()
+	c.Seek(5)
+	for ; c.Valid(); c.Prev() {
+		fmt.Print(c.Value(), " ")
+	}
+	fmt.Println()
+
+	// Output:
+	// 5 4 3 2 1
+}
@@ -15,8 +15,10 @@
 package skipset
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/rand"
 	"sort"
 	"strconv"
 	"sync"
@@ -355,3 +357,329 @@ func testStringSet(t *testing.T, newset func() anyskipset[string], asc bool) {
 		t.Fatal("invalid toslice")
 	}
 }
+
+type rangeskipset interface {
+	Add(value int) bool
+	Remove(value int) bool
+	Range(f func(value int) bool)
+	RangeFrom(start int, f func(value int) bool)
+	RangeReverse(f func(value int) bool)
+}
+
+func TestRangeFromReverse(t *testing.T) {
+	testRangeFromReverse(t, New[int](), false)
+	testRangeFromReverse(t, NewDesc[int](), true)
+	testRangeFromReverse(t, NewFunc(func(a, b int) bool { return a < b }), false)
+}
+
+func testRangeFromReverse(t *testing.T, s rangeskipset, desc bool) {
+	collect := func(r func(f func(int) bool)) []int {
+		res := []int{}
+		r(func(v int) bool {
+			res = append(res, v)
+			return true
+		})
+		return res
+	}
+	assert.Equal(t, []int{}, collect(s.RangeReverse))
+
+	for i := 0; i < 100; i++ {
+		s.Add(i * 2)
+	}
+	all := collect(s.Range)
+	reversed := collect(s.RangeReverse)
+	for i := range all {
+		assert.Equal(t, all[i], reversed[len(all)-1-i])
+	}
+
+	for _, start := range []int{-1, 0, 51, 100, 198, 199} {
+		expect := []int{}
+		for _, v := range all {
+			if gcond.If(desc, v <= start, v >= start) {
+				expect = append(expect, v)
+			}
+		}
+		assert.Equal(t, expect, collect(func(f func(int) bool) { s.RangeFrom(start, f) }))
+	}
+
+	// Concurrent removes: values are visited in order and at most once.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i += 3 {
+			s.Remove(i * 2)
+		}
+	}()
+	prev := gcond.If(desc, math.MinInt, math.MaxInt)
+	s.RangeReverse(func(v int) bool {
+		if gcond.If(desc, v <= prev, v >= prev) {
+			t.Fatalf("visited %d after %d", v, prev)
+		}
+		prev = v
+		return true
+	})
+	wg.Wait()
+}
+
+func TestMarshalJSON(t *testing.T) {
+	var nilSet *OrderedSet[int]
+	bs, err := json.Marshal(nilSet)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(bs))
+
+	s := New[int]()
+	bs, err = json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(bs))
+
+	for _, v := range []int{3, 1, 2} {
+		s.Add(v)
+	}
+	bs, err = json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2,3]", string(bs))
+
+	d := NewDesc[string]()
+	assert.Nil(t, json.Unmarshal([]byte(`["b","a","c","a"]`), d))
+	assert.Equal(t, []string{"c", "b", "a"}, d.ToSlice())
+	bs, err = json.Marshal(d)
+	assert.Nil(t, err)
+	assert.Equal(t, `["c","b","a"]`, string(bs))
+	assert.NotNil(t, json.Unmarshal([]byte(`{}`), d))
+
+	type point struct{ X, Y int }
+	f := NewFunc(func(a, b point) bool { return a.X < b.X || a.X == b.X && a.Y < b.Y })
+	assert.Nil(t, json.Unmarshal([]byte(`[{"X":2,"Y":1},{"X":1,"Y":2}]`), f))
+	bs, err = json.Marshal(f)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"X":1,"Y":2},{"X":2,"Y":1}]`, string(bs))
+
+	// Unmarshal adds values into existing set.
+	assert.Nil(t, json.Unmarshal([]byte(`[0]`), s))
+	assert.Equal(t, []int{0, 1, 2, 3}, s.ToSlice())
+	assert.Nil(t, json.Unmarshal([]byte(`null`), s))
+	assert.Equal(t, 4, s.Len())
+}
+
+type algebraskipset[S any] interface {
+	Add(value int) bool
+	ToSlice() []int
+	Union(other S) S
+	Intersect(other S) S
+	Diff(other S) S
+	IsSubset(other S) bool
+	Equal(other S) bool
+}
+
+func TestSetAlgebra(t *testing.T) {
+	testSetAlgebra(t, func() *OrderedSet[int] { return New[int]() })
+	testSetAlgebra(t, func() *OrderedSetDesc[int] { return NewDesc[int]() })
+	testSetAlgebra(t, func() *FuncSet[int] { return NewFunc(func(a, b int) bool { return a > b }) })
+}
+
+func testSetAlgebra[S algebraskipset[S]](t *testing.T, newset func() S) {
+	sorted := func(s []int) []int {
+		sort.Ints(s)
+		return s
+	}
+	toSet := func(vs []int) S {
+		s := newset()
+		for _, v := range vs {
+			s.Add(v)
+		}
+		return s
+	}
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 100; round++ {
+		var as, bs []int
+		ina, inb := map[int]bool{}, map[int]bool{}
+		for i := 0; i < r.Intn(50); i++ {
+			v := r.Intn(60)
+			as = append(as, v)
+			ina[v] = true
+		}
+		for i := 0; i < r.Intn(50); i++ {
+			v := r.Intn(60)
+			bs = append(bs, v)
+			inb[v] = true
+		}
+		a, b := toSet(as), toSet(bs)
+
+		union, inter, diff := []int{}, []int{}, []int{}
+		for v := 0; v < 60; v++ {
+			if ina[v] || inb[v] {
+				union = append(union, v)
+			}
+			if ina[v] && inb[v] {
+				inter = append(inter, v)
+			}
+			if ina[v] && !inb[v] {
+				diff = append(diff, v)
+			}
+		}
+		assert.Equal(t, union, sorted(a.Union(b).ToSlice()))
+		assert.Equal(t, inter, sorted(a.Intersect(b).ToSlice()))
+		assert.Equal(t, diff, sorted(a.Diff(b).ToSlice()))
+		assert.Equal(t, len(diff) == 0, a.IsSubset(b))
+		assert.Equal(t, len(union) == len(inter), a.Equal(b))
+		assert.True(t, a.IsSubset(a.Union(b)))
+		assert.True(t, a.Intersect(b).IsSubset(b))
+		assert.True(t, a.Equal(toSet(as)))
+	}
+
+	empty := newset()
+	a := toSet([]int{1, 2})
+	assert.True(t, empty.IsSubset(a))
+	assert.False(t, a.IsSubset(empty))
+	assert.True(t, empty.Equal(newset()))
+	assert.False(t, a.Equal(empty))
+	assert.False(t, empty.Equal(a))
+	assert.False(t, a.Equal(toSet([]int{1, 3})))
+	assert.False(t, a.Equal(toSet([]int{1, 2, 3})))
+}
+
+type batchskipset interface {
+	Add(v int) bool
+	Remove(v int) bool
+	Contains(v int) bool
+	Len() int
+	ToSlice() []int
+	AddBatch(values []int) int
+	LoadSorted(values []int) int
+	RemoveBatch(values []int) int
+}
+
+func TestBatch(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	testSetBatch(t, func(values []int) batchskipset { return NewFromSorted(values) }, false)
+	testSetBatch(t, func(values []int) batchskipset { return NewDescFromSorted(values) }, true)
+	testSetBatch(t, func(values []int) batchskipset { return NewFuncFromSorted(less, values) }, false)
+
+	assert.Panic(t, func() { NewFromSorted([]int{1, 3, 2}) })
+	assert.Panic(t, func() { NewDescFromSorted([]int{1, 2}) })
+	assert.Panic(t, func() { New[int]().LoadSorted([]int{2, 1}) })
+}
+
+func testSetBatch(t *testing.T, newset func(values []int) batchskipset, desc bool) {
+	// Build from sorted values.
+	var values, expected []int
+	for i := 0; i < 1000; i++ {
+		v := gcond.If(desc, 1000-i, i)
+		values = append(values, v)
+		if i%10 == 0 {
+			values = append(values, v) // duplicate
+		}
+		expected = append(expected, v)
+	}
+	assert.Equal(t, []int{}, newset(nil).ToSlice())
+	s := newset(values)
+	assert.Equal(t, 1000, s.Len())
+	assert.Equal(t, expected, s.ToSlice())
+	for _, v := range expected {
+		assert.True(t, s.Contains(v))
+	}
+	// The built skip set is fully functional.
+	for i := 0; i < 1000; i += 2 {
+		assert.True(t, s.Remove(expected[i]))
+	}
+	assert.True(t, s.Add(-1))
+	assert.False(t, s.Add(expected[1]))
+	assert.Equal(t, 501, s.Len())
+
+	// Batch operations with unsorted values.
+	s = newset(nil)
+	ref := map[int]bool{}
+	for round := 0; round < 20; round++ {
+		var batch []int
+		added := map[int]bool{}
+		for i := 0; i < 100; i++ {
+			v := rand.Intn(300)
+			batch = append(batch, v)
+			if !ref[v] {
+				added[v] = true
+				ref[v] = true
+			}
+		}
+		assert.Equal(t, len(added), s.AddBatch(batch))
+
+		batch = batch[:0]
+		removed := map[int]bool{}
+		for i := 0; i < 50; i++ {
+			v := rand.Intn(300)
+			batch = append(batch, v)
+			if ref[v] {
+				removed[v] = true
+				delete(ref, v)
+			}
+		}
+		assert.Equal(t, len(removed), s.RemoveBatch(batch))
+		assert.Equal(t, len(ref), s.Len())
+		for v := range ref {
+			assert.True(t, s.Contains(v))
+		}
+	}
+	assert.Equal(t, 0, s.RemoveBatch(nil))
+
+	// LoadSorted merges into existing values.
+	s = newset(nil)
+	s.Add(expected[0])
+	s.Add(3000)
+	assert.Equal(t, 999, s.LoadSorted(values))
+	assert.Equal(t, 1001, s.Len())
+
+	// Concurrent batch operations.
+	s = newset(nil)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(g)))
+			for round := 0; round < 50; round++ {
+				batch := make([]int, 20)
+				for i := range batch {
+					batch[i] = r.Intn(200)
+				}
+				switch (g + round) % 4 {
+				case 0:
+					s.AddBatch(batch)
+				case 1:
+					s.RemoveBatch(batch)
+				case 2:
+					s.Add(batch[0])
+				default:
+					s.Remove(batch[0])
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	res := s.ToSlice()
+	assert.Equal(t, len(res), s.Len())
+	for i := 1; i < len(res); i++ {
+		assert.True(t, gcond.If(desc, res[i-1] > res[i], res[i-1] < res[i]))
+	}
+}
+
+func TestBatchStaleHint(t *testing.T) {
+	s := New[int]()
+	for _, v := range []int{0, 1, 5} {
+		s.Add(v)
+	}
+	var preds, succs [maxLevel]*orderednode[int]
+	assert.True(t, s.remove(1, &preds, &succs)) // preds[0] is node 0
+
+	// The hint is removed, then a value is added after its position.
+	assert.True(t, s.Remove(0))
+	s.Add(3)
+	hints := preds
+	assert.True(t, s.remove(3, &preds, &succs))
+	assert.False(t, s.Contains(3))
+
+	// Adding with the stale hint does not duplicate the value.
+	s.Add(3)
+	assert.False(t, s.add(3, &hints, &succs))
+	assert.Equal(t, []int{3, 5}, s.ToSlice())
+	assert.Equal(t, 2, s.Len())
+}
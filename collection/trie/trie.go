@@ -0,0 +1,316 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trie provides a compressed radix tree for string-like keys.
+//
+// Compared with [github.com/bytedance/gg/collection/skipmap], a [Trie]
+// supports efficient prefix queries, which is useful for routing tables,
+// autocomplete and path matching.
+//
+// 💡 NOTE: Trie is not concurrent-safe.
+//
+// # Structures
+//
+//   - [Trie]
+//
+// # Operations
+//
+//   - Constructor: [New]
+//   - CRUD operations: [Trie.Put], [Trie.Get], [Trie.Delete], …
+//   - Prefix queries: [Trie.LongestPrefixMatch], [Trie.WalkPrefix], [Trie.KeysWithPrefix]
+//
+// # Ordering
+//
+// All iterations over a trie are in lexicographic byte order of keys.
+package trie
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+)
+
+// Key is the constraint of trie key.
+type Key interface {
+	~string | ~[]byte
+}
+
+// Trie is a compressed radix tree which maps keys to values.
+// The zero value for Trie is an empty trie ready to use.
+type Trie[K Key, V any] struct {
+	root node[V]
+	len  int
+}
+
+type node[V any] struct {
+	prefix   string     // label of the edge from parent to this node
+	children []*node[V] // sorted by the first byte of prefix
+	value    V
+	hasValue bool
+}
+
+// New creates an empty trie.
+func New[K Key, V any]() *Trie[K, V] {
+	return &Trie[K, V]{}
+}
+
+// child returns the index of child whose prefix starts with byte c,
+// and whether the child exists.
+func (n *node[V]) child(c byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= c
+	})
+	return i, i < len(n.children) && n.children[i].prefix[0] == c
+}
+
+func (n *node[V]) insertChild(i int, c *node[V]) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+}
+
+func (n *node[V]) removeChild(i int) {
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// mergeChild merges the only child into n, n must have no value.
+func (n *node[V]) mergeChild() {
+	c := n.children[0]
+	n.prefix += c.prefix
+	n.children = c.children
+	n.value = c.value
+	n.hasValue = c.hasValue
+}
+
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie[K, V]) Len() int {
+	if t == nil {
+		return 0
+	}
+	return t.len
+}
+
+// Put sets the value for a key.
+func (t *Trie[K, V]) Put(key K, value V) {
+	s := string(key)
+	n := &t.root
+	for len(s) != 0 {
+		i, ok := n.child(s[0])
+		if !ok {
+			n.insertChild(i, &node[V]{prefix: s, value: value, hasValue: true})
+			t.len++
+			return
+		}
+		c := n.children[i]
+		l := commonPrefixLen(c.prefix, s)
+		if l < len(c.prefix) {
+			// Split the edge at l.
+			mid := &node[V]{prefix: c.prefix[:l], children: []*node[V]{c}}
+			c.prefix = c.prefix[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		n = c
+		s = s[l:]
+	}
+	if !n.hasValue {
+		t.len++
+	}
+	n.value = value
+	n.hasValue = true
+}
+
+// find returns the node exactly matching the key.
+func (t *Trie[K, V]) find(s string) *node[V] {
+	if t == nil {
+		return nil
+	}
+	n := &t.root
+	for len(s) != 0 {
+		i, ok := n.child(s[0])
+		if !ok {
+			return nil
+		}
+		c := n.children[i]
+		if !strings.HasPrefix(s, c.prefix) {
+			return nil
+		}
+		n = c
+		s = s[len(c.prefix):]
+	}
+	return n
+}
+
+// Get returns the value stored in the trie for a key, or zero value if no
+// value is present.
+// The ok result indicates whether value was found in the trie.
+func (t *Trie[K, V]) Get(key K) (value V, ok bool) {
+	n := t.find(string(key))
+	if n == nil || !n.hasValue {
+		return value, false
+	}
+	return n.value, true
+}
+
+// Contains returns whether the key is in the trie.
+func (t *Trie[K, V]) Contains(key K) bool {
+	n := t.find(string(key))
+	return n != nil && n.hasValue
+}
+
+// Delete deletes the value for a key.
+// It returns whether the key was present.
+func (t *Trie[K, V]) Delete(key K) bool {
+	if t == nil {
+		return false
+	}
+	if !t.delete(&t.root, string(key)) {
+		return false
+	}
+	t.len--
+	return true
+}
+
+func (t *Trie[K, V]) delete(n *node[V], s string) bool {
+	if len(s) == 0 {
+		if !n.hasValue {
+			return false
+		}
+		var zero V
+		n.value = zero
+		n.hasValue = false
+		return true
+	}
+	i, ok := n.child(s[0])
+	if !ok {
+		return false
+	}
+	c := n.children[i]
+	if !strings.HasPrefix(s, c.prefix) || !t.delete(c, s[len(c.prefix):]) {
+		return false
+	}
+	// Keep the tree compressed.
+	if !c.hasValue {
+		switch len(c.children) {
+		case 0:
+			n.removeChild(i)
+		case 1:
+			c.mergeChild()
+		}
+	}
+	return true
+}
+
+// LongestPrefixMatch returns the longest key in the trie which is a prefix
+// of the given key, and its value.
+//
+// 💡 HINT: Empty key is a prefix of any key.
+func (t *Trie[K, V]) LongestPrefixMatch(key K) goption.O[tuple.T2[K, V]] {
+	if t == nil {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	s := string(key)
+	n := &t.root
+	matched, depth := (*node[V])(nil), 0
+	for pos := 0; ; {
+		if n.hasValue {
+			matched, depth = n, pos
+		}
+		if pos == len(s) {
+			break
+		}
+		i, ok := n.child(s[pos])
+		if !ok {
+			break
+		}
+		c := n.children[i]
+		if !strings.HasPrefix(s[pos:], c.prefix) {
+			break
+		}
+		n = c
+		pos += len(c.prefix)
+	}
+	if matched == nil {
+		return goption.Nil[tuple.T2[K, V]]()
+	}
+	return goption.OK(tuple.Make2(K(s[:depth]), matched.value))
+}
+
+// WalkPrefix calls f sequentially for each key with the given prefix and its
+// value, in lexicographic order.
+// If f returns false, the iteration stops.
+//
+// 💡 HINT: Use empty prefix to iterate over the whole trie.
+//
+// 💡 NOTE: The trie must not be modified during the iteration.
+func (t *Trie[K, V]) WalkPrefix(prefix K, f func(key K, value V) bool) {
+	if t == nil {
+		return
+	}
+	s := string(prefix)
+	n := &t.root
+	path := make([]byte, 0, 64)
+	for len(s) != 0 {
+		i, ok := n.child(s[0])
+		if !ok {
+			return
+		}
+		c := n.children[i]
+		if strings.HasPrefix(s, c.prefix) {
+			s = s[len(c.prefix):]
+		} else if strings.HasPrefix(c.prefix, s) {
+			s = ""
+		} else {
+			return
+		}
+		path = append(path, c.prefix...)
+		n = c
+	}
+	walk(n, path, f)
+}
+
+// walk visits node n and its descendants in pre-order, path is the key of n.
+func walk[K Key, V any](n *node[V], path []byte, f func(K, V) bool) bool {
+	if n.hasValue && !f(K(string(path)), n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, append(path, c.prefix...), f) {
+			return false
+		}
+	}
+	return true
+}
+
+// KeysWithPrefix returns all keys with the given prefix in lexicographic order.
+func (t *Trie[K, V]) KeysWithPrefix(prefix K) []K {
+	var keys []K
+	t.WalkPrefix(prefix, func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"fmt"
+)
+
+func Example() {
+	routes := New[string, string]()
+	routes.Put("/", "index")
+	routes.Put("/api/", "api")
+	routes.Put("/api/users", "users")
+	routes.Put("/assets/", "assets")
+
+	v, ok := routes.Get("/api/users")
+	fmt.Println(v, ok)
+
+	// Find the handler of the longest matching route.
+	r := routes.LongestPrefixMatch("/api/orders/1").Value()
+	fmt.Println(r.First, r.Second)
+
+	// Keys are in lexicographic order.
+	fmt.Println(routes.KeysWithPrefix("/a"))
+
+	routes.Delete("/api/users")
+	fmt.Println(routes.Len())
+
+	// Output:
+	// users true
+	// /api/ api
+	// [/api/ /api/users /assets/]
+	// 3
+}
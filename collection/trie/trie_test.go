@@ -0,0 +1,237 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bytedance/gg/collection/tuple"
+	"github.com/bytedance/gg/goption"
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestTrie(t *testing.T) {
+	var tr Trie[string, int]
+	assert.Equal(t, 0, tr.Len())
+	_, ok := tr.Get("a")
+	assert.False(t, ok)
+
+	for i, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		tr.Put(k, i)
+	}
+	assert.Equal(t, 7, tr.Len())
+	v, ok := tr.Get("rubens")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	_, ok = tr.Get("rub") // internal node
+	assert.False(t, ok)
+	_, ok = tr.Get("rubensx")
+	assert.False(t, ok)
+	_, ok = tr.Get("x")
+	assert.False(t, ok)
+	assert.False(t, tr.Contains("roman"))
+	assert.True(t, tr.Contains("romane"))
+
+	// Override.
+	tr.Put("ruber", 100)
+	assert.Equal(t, 7, tr.Len())
+	v, _ = tr.Get("ruber")
+	assert.Equal(t, 100, v)
+
+	// Key on internal node.
+	tr.Put("rub", 200)
+	assert.Equal(t, 8, tr.Len())
+	v, _ = tr.Get("rub")
+	assert.Equal(t, 200, v)
+
+	// Empty key.
+	tr.Put("", -1)
+	v, ok = tr.Get("")
+	assert.True(t, ok)
+	assert.Equal(t, -1, v)
+
+	assert.Equal(t, []string{"", "romane", "romanus", "romulus", "rub", "rubens", "ruber", "rubicon", "rubicundus"},
+		tr.KeysWithPrefix(""))
+	assert.Equal(t, []string{"rub", "rubens", "ruber", "rubicon", "rubicundus"}, tr.KeysWithPrefix("ru"))
+	assert.Equal(t, []string{"rubicon", "rubicundus"}, tr.KeysWithPrefix("rubi"))
+	assert.Equal(t, []string{"rubicundus"}, tr.KeysWithPrefix("rubicu"))
+	assert.Equal(t, []string{"romane", "romanus"}, tr.KeysWithPrefix("roman"))
+	assert.Equal(t, []string(nil), tr.KeysWithPrefix("rubx"))
+	assert.Equal(t, []string(nil), tr.KeysWithPrefix("rubiconx"))
+	assert.Equal(t, []string(nil), tr.KeysWithPrefix("x"))
+
+	// Delete.
+	assert.False(t, tr.Delete("rubi"))
+	assert.False(t, tr.Delete("x"))
+	assert.False(t, tr.Delete("rubiconx"))
+	assert.True(t, tr.Delete("rubicon"))
+	assert.False(t, tr.Delete("rubicon"))
+	assert.True(t, tr.Delete("rub"))
+	assert.True(t, tr.Delete(""))
+	assert.Equal(t, 6, tr.Len())
+	assert.Equal(t, []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicundus"}, tr.KeysWithPrefix(""))
+	for _, k := range tr.KeysWithPrefix("") {
+		assert.True(t, tr.Delete(k))
+	}
+	assert.Equal(t, 0, tr.Len())
+	assert.Equal(t, 0, len(tr.root.children))
+}
+
+func TestTrieCompressed(t *testing.T) {
+	tr := New[string, int]()
+	tr.Put("abc", 1)
+	tr.Put("abd", 2)
+	assert.Equal(t, 1, len(tr.root.children))
+	assert.Equal(t, "ab", tr.root.children[0].prefix)
+
+	tr.Delete("abd")
+	// "ab" and "c" are merged.
+	assert.Equal(t, 1, len(tr.root.children))
+	assert.Equal(t, "abc", tr.root.children[0].prefix)
+	assert.Equal(t, 0, len(tr.root.children[0].children))
+}
+
+func TestTrieNil(t *testing.T) {
+	var tr *Trie[string, int]
+	assert.Equal(t, 0, tr.Len())
+	_, ok := tr.Get("a")
+	assert.False(t, ok)
+	assert.False(t, tr.Contains("a"))
+	assert.False(t, tr.Delete("a"))
+	assert.Equal(t, goption.Nil[tuple.T2[string, int]](), tr.LongestPrefixMatch("a"))
+	assert.Equal(t, []string(nil), tr.KeysWithPrefix(""))
+}
+
+func TestLongestPrefixMatch(t *testing.T) {
+	tr := New[string, string]()
+	tr.Put("/", "root")
+	tr.Put("/api", "api")
+	tr.Put("/api/v1", "v1")
+	tr.Put("/static/", "static")
+
+	match := func(k string) string {
+		return tr.LongestPrefixMatch(k).Value().First
+	}
+	assert.Equal(t, "/api/v1", match("/api/v1/users"))
+	assert.Equal(t, "/api/v1", match("/api/v1"))
+	assert.Equal(t, "/api", match("/api/v2"))
+	assert.Equal(t, "/api", match("/apix"))
+	assert.Equal(t, "/", match("/static"))
+	assert.Equal(t, "/static/", match("/static/a.js"))
+	assert.Equal(t, "", match("api"))
+
+	r := tr.LongestPrefixMatch("/api/v1/x")
+	assert.True(t, r.IsOK())
+	assert.Equal(t, "v1", r.Value().Second)
+
+	assert.False(t, tr.LongestPrefixMatch("").IsOK())
+	tr.Put("", "empty")
+	assert.Equal(t, tuple.Make2("", "empty"), tr.LongestPrefixMatch("x").Value())
+}
+
+func TestWalkPrefix(t *testing.T) {
+	tr := New[string, int]()
+	for i, k := range []string{"a", "ab", "abc", "b"} {
+		tr.Put(k, i)
+	}
+	var keys []string
+	var values []int
+	tr.WalkPrefix("a", func(k string, v int) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []string{"a", "ab"}, keys)
+	assert.Equal(t, []int{0, 1}, values)
+}
+
+func TestTrieBytes(t *testing.T) {
+	tr := New[[]byte, int]()
+	tr.Put([]byte("foo"), 1)
+	tr.Put([]byte("foobar"), 2)
+	tr.Put([]byte{0, 1}, 3)
+
+	v, ok := tr.Get([]byte("foo"))
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	keys := tr.KeysWithPrefix(nil)
+	assert.Equal(t, [][]byte{{0, 1}, []byte("foo"), []byte("foobar")}, keys)
+	// Returned keys are not aliased.
+	keys[1][0] = 'x'
+	assert.Equal(t, [][]byte{[]byte("foo"), []byte("foobar")}, tr.KeysWithPrefix([]byte("f")))
+
+	assert.Equal(t, tuple.Make2([]byte("foobar"), 2), tr.LongestPrefixMatch([]byte("foobarbaz")).Value())
+}
+
+func TestTrieRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := New[string, int]()
+	m := map[string]int{}
+	randKey := func() string {
+		b := make([]byte, r.Intn(6))
+		for i := range b {
+			b[i] = "abc"[r.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 5000; i++ {
+		k := randKey()
+		if r.Intn(3) == 0 {
+			_, ok := m[k]
+			assert.Equal(t, ok, tr.Delete(k))
+			delete(m, k)
+		} else {
+			tr.Put(k, i)
+			m[k] = i
+		}
+		assert.Equal(t, len(m), tr.Len())
+	}
+	var keys []string
+	for k, v := range m {
+		keys = append(keys, k)
+		got, ok := tr.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+	sort.Strings(keys)
+	assert.Equal(t, keys, tr.KeysWithPrefix(""))
+
+	for i := 0; i < 100; i++ {
+		p := randKey()
+		var want []string
+		for _, k := range keys {
+			if len(k) >= len(p) && k[:len(p)] == p {
+				want = append(want, k)
+			}
+		}
+		assert.Equal(t, want, tr.KeysWithPrefix(p))
+
+		var lpm string
+		found := false
+		for k := range m {
+			if len(k) <= len(p) && p[:len(k)] == k && (!found || len(k) > len(lpm)) {
+				lpm, found = k, true
+			}
+		}
+		res := tr.LongestPrefixMatch(p)
+		assert.Equal(t, found, res.IsOK())
+		if found {
+			assert.Equal(t, lpm, res.Value().First)
+		}
+	}
+}
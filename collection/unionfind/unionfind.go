@@ -0,0 +1,194 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unionfind provides a disjoint-set data structure.
+//
+// It tracks a partition of elements into disjoint sets, and supports merging
+// two sets and finding the set of an element in nearly constant amortized
+// time, with path compression and union by rank.
+//
+// 💡 NOTE: DisjointSet is not concurrent-safe.
+package unionfind
+
+// DisjointSet is a collection of disjoint sets of comparable elements.
+// The zero value for DisjointSet is an empty collection ready to use.
+type DisjointSet[T comparable] struct {
+	index  map[T]int
+	elems  []T
+	parent []int
+	rank   []uint8
+	size   []int // size of set, only valid for roots
+	count  int   // number of sets
+}
+
+// New creates a collection where each of elements is a singleton set.
+func New[T comparable](elems ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{}
+	for _, e := range elems {
+		d.Add(e)
+	}
+	return d
+}
+
+func (d *DisjointSet[T]) lazyInit() {
+	if d.index == nil {
+		d.index = make(map[T]int)
+	}
+}
+
+// Len returns the number of elements.
+func (d *DisjointSet[T]) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.elems)
+}
+
+// Count returns the number of disjoint sets.
+func (d *DisjointSet[T]) Count() int {
+	if d == nil {
+		return 0
+	}
+	return d.count
+}
+
+// Add adds x as a singleton set.
+// It returns false if x is already present.
+func (d *DisjointSet[T]) Add(x T) bool {
+	_, ok := d.add(x)
+	return !ok
+}
+
+// add returns index of x, x is added if absent.
+// The ok result reports whether x was present.
+func (d *DisjointSet[T]) add(x T) (int, bool) {
+	d.lazyInit()
+	if i, ok := d.index[x]; ok {
+		return i, true
+	}
+	i := len(d.elems)
+	d.index[x] = i
+	d.elems = append(d.elems, x)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return i, false
+}
+
+// Contains returns whether x is present.
+func (d *DisjointSet[T]) Contains(x T) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.index[x]
+	return ok
+}
+
+// root returns the index of root of i, compressing the path.
+func (d *DisjointSet[T]) root(i int) int {
+	r := i
+	for d.parent[r] != r {
+		r = d.parent[r]
+	}
+	for d.parent[i] != r {
+		d.parent[i], i = r, d.parent[i]
+	}
+	return r
+}
+
+// Find returns the representative element of the set containing x.
+//
+// If x is not present, it is considered as a singleton set and x itself is
+// returned.
+func (d *DisjointSet[T]) Find(x T) T {
+	if d == nil {
+		return x
+	}
+	i, ok := d.index[x]
+	if !ok {
+		return x
+	}
+	return d.elems[d.root(i)]
+}
+
+// Union merges sets containing x and y, absent elements are added first.
+// It returns false if x and y are already in the same set.
+func (d *DisjointSet[T]) Union(x, y T) bool {
+	i, _ := d.add(x)
+	j, _ := d.add(y)
+	ri, rj := d.root(i), d.root(j)
+	if ri == rj {
+		return false
+	}
+	if d.rank[ri] < d.rank[rj] {
+		ri, rj = rj, ri
+	}
+	d.parent[rj] = ri
+	d.size[ri] += d.size[rj]
+	if d.rank[ri] == d.rank[rj] {
+		d.rank[ri]++
+	}
+	d.count--
+	return true
+}
+
+// Connected returns whether x and y are in the same set.
+//
+// 💡 HINT: An absent element is only connected to itself.
+func (d *DisjointSet[T]) Connected(x, y T) bool {
+	if d == nil {
+		return x == y
+	}
+	i, ok1 := d.index[x]
+	j, ok2 := d.index[y]
+	if !ok1 || !ok2 {
+		return x == y
+	}
+	return d.root(i) == d.root(j)
+}
+
+// SizeOf returns the size of the set containing x.
+//
+// If x is not present, 0 is returned.
+func (d *DisjointSet[T]) SizeOf(x T) int {
+	if d == nil {
+		return 0
+	}
+	i, ok := d.index[x]
+	if !ok {
+		return 0
+	}
+	return d.size[d.root(i)]
+}
+
+// Sets returns all disjoint sets.
+//
+// Sets are ordered by their earliest added element, and elements of each set
+// are in the order they were added.
+func (d *DisjointSet[T]) Sets() [][]T {
+	sets := make([][]T, 0, d.Count())
+	pos := make(map[int]int, d.Count()) // root -> index of sets
+	for i := 0; i < d.Len(); i++ {
+		r := d.root(i)
+		p, ok := pos[r]
+		if !ok {
+			p = len(sets)
+			pos[r] = p
+			sets = append(sets, make([]T, 0, d.size[r]))
+		}
+		sets[p] = append(sets[p], d.elems[i])
+	}
+	return sets
+}
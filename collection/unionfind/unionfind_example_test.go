@@ -0,0 +1,37 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unionfind
+
+import (
+	"fmt"
+)
+
+func Example() {
+	d := New("a", "b", "c", "d", "e")
+	d.Union("a", "b")
+	d.Union("c", "d")
+	d.Union("b", "d")
+
+	fmt.Println(d.Connected("a", "c"))
+	fmt.Println(d.Connected("a", "e"))
+	fmt.Println(d.Count())
+	fmt.Println(d.Sets())
+
+	// Output:
+	// true
+	// false
+	// 2
+	// [[a b c d] [e]]
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unionfind
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestDisjointSet(t *testing.T) {
+	var d DisjointSet[string]
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, 0, d.Count())
+	assert.Equal(t, "x", d.Find("x"))
+	assert.True(t, d.Connected("x", "x"))
+	assert.False(t, d.Connected("x", "y"))
+	assert.Equal(t, 0, d.SizeOf("x"))
+
+	assert.True(t, d.Add("a"))
+	assert.False(t, d.Add("a"))
+	assert.True(t, d.Contains("a"))
+	assert.False(t, d.Contains("b"))
+
+	assert.True(t, d.Union("a", "b"))
+	assert.True(t, d.Union("c", "d"))
+	assert.False(t, d.Union("b", "a"))
+	assert.Equal(t, 4, d.Len())
+	assert.Equal(t, 2, d.Count())
+	assert.True(t, d.Connected("a", "b"))
+	assert.False(t, d.Connected("a", "c"))
+	assert.False(t, d.Connected("a", "z"))
+	assert.Equal(t, d.Find("a"), d.Find("b"))
+	assert.Equal(t, 2, d.SizeOf("c"))
+
+	assert.True(t, d.Union("b", "d"))
+	assert.Equal(t, 1, d.Count())
+	assert.Equal(t, 4, d.SizeOf("a"))
+	assert.True(t, d.Connected("a", "c"))
+
+	d.Add("e")
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}, {"e"}}, d.Sets())
+
+	var nilSet *DisjointSet[int]
+	assert.Equal(t, 0, nilSet.Len())
+	assert.Equal(t, 0, nilSet.Count())
+	assert.False(t, nilSet.Contains(1))
+	assert.Equal(t, 1, nilSet.Find(1))
+	assert.True(t, nilSet.Connected(1, 1))
+	assert.False(t, nilSet.Connected(1, 2))
+	assert.Equal(t, 0, nilSet.SizeOf(1))
+	assert.Equal(t, [][]int{}, nilSet.Sets())
+}
+
+func TestDisjointSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 500
+	d := New[int]()
+	for i := 0; i < n; i++ {
+		d.Add(i)
+	}
+	// Reference implementation: label of each element.
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+	}
+	for k := 0; k < 400; k++ {
+		x, y := r.Intn(n), r.Intn(n)
+		merged := label[x] != label[y]
+		assert.Equal(t, merged, d.Union(x, y))
+		if merged {
+			old := label[y]
+			for i := range label {
+				if label[i] == old {
+					label[i] = label[x]
+				}
+			}
+		}
+	}
+	count := map[int]int{}
+	for i := 0; i < n; i++ {
+		count[label[i]]++
+	}
+	assert.Equal(t, len(count), d.Count())
+	for k := 0; k < 1000; k++ {
+		x, y := r.Intn(n), r.Intn(n)
+		assert.Equal(t, label[x] == label[y], d.Connected(x, y))
+		assert.Equal(t, count[label[x]], d.SizeOf(x))
+	}
+}
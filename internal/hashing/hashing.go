@@ -0,0 +1,153 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashing provides deterministic hash functions for comparable values.
+//
+// The results are stable across processes and platforms, so that they can be
+// used for sharding and persisting.
+package hashing
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+const (
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+// String returns the 64-bit FNV-1a hash of string s, mixed with seed.
+func String(s string, seed uint64) uint64 {
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return Uint64(h, seed)
+}
+
+// Bytes is a variant of [String], returns the hash of byte slice b.
+func Bytes(b []byte, seed uint64) uint64 {
+	h := uint64(offset64)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= prime64
+	}
+	return Uint64(h, seed)
+}
+
+// Uint64 returns the hash of integer x, mixed with seed.
+func Uint64(x, seed uint64) uint64 {
+	return Mix(x ^ Mix(seed+0x9e3779b97f4a7c15))
+}
+
+// Mix is the finalizer of SplitMix64, it scrambles the bits of x.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Sum64 returns the deterministic 64-bit hash of comparable value v.
+//
+// Values that are equal (==) always have the same hash, NaN is never equal to
+// itself, but all NaNs have the same hash.
+//
+// Structs and arrays are hashed field by field (element by element), and
+// interfaces are hashed by their dynamic values.
+// Pointers and channels are hashed by address, so their hashes are not stable
+// across processes.
+//
+// It panics if v is an interface holding a value of incomparable type, just
+// like using such a value as a map key.
+func Sum64[T comparable](v T, seed uint64) uint64 {
+	switch x := any(v).(type) {
+	case string:
+		return String(x, seed)
+	case int:
+		return Uint64(uint64(x), seed)
+	case int64:
+		return Uint64(uint64(x), seed)
+	case int32:
+		return Uint64(uint64(x), seed)
+	case uint:
+		return Uint64(uint64(x), seed)
+	case uint64:
+		return Uint64(x, seed)
+	case uint32:
+		return Uint64(uint64(x), seed)
+	}
+	return value(reflect.ValueOf(&v).Elem(), seed)
+}
+
+// value returns the hash of rv, mixed with seed.
+func value(rv reflect.Value, seed uint64) uint64 {
+	switch rv.Kind() {
+	case reflect.String:
+		return String(rv.String(), seed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Uint64(uint64(rv.Int()), seed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Uint64(rv.Uint(), seed)
+	case reflect.Bool:
+		if rv.Bool() {
+			return Uint64(1, seed)
+		}
+		return Uint64(0, seed)
+	case reflect.Float32, reflect.Float64:
+		return float(rv.Float(), seed)
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		return float(imag(c), float(real(c), seed))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return Uint64(uint64(rv.Pointer()), seed)
+	case reflect.Struct:
+		h := Uint64(0, seed)
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).Name == "_" {
+				continue // blank fields are ignored by ==
+			}
+			h = value(rv.Field(i), h)
+		}
+		return h
+	case reflect.Array:
+		h := Uint64(0, seed)
+		for i := 0; i < rv.Len(); i++ {
+			h = value(rv.Index(i), h)
+		}
+		return h
+	case reflect.Interface:
+		if rv.IsNil() {
+			return Uint64(0, seed)
+		}
+		return value(rv.Elem(), seed)
+	default:
+		panic(fmt.Errorf("hashing: unhashable type %s", rv.Type()))
+	}
+}
+
+// float returns the hash of float f, mixed with seed.
+func float(f float64, seed uint64) uint64 {
+	if f == 0 {
+		f = 0 // -0 == +0
+	} else if f != f {
+		f = math.NaN()
+	}
+	return Uint64(math.Float64bits(f), seed)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestSum64(t *testing.T) {
+	type myString string
+	type myInt int16
+	type point struct{ X, Y int }
+
+	// Deterministic.
+	assert.Equal(t, Sum64("hello", 0), Sum64("hello", 0))
+	assert.Equal(t, String("hello", 1), Sum64("hello", 1))
+	assert.Equal(t, Bytes([]byte("hello"), 1), Sum64("hello", 1))
+	assert.Equal(t, Sum64("hello", 0), Sum64(myString("hello"), 0))
+	assert.Equal(t, Sum64(12, 0), Sum64(myInt(12), 0))
+	assert.Equal(t, Sum64(int64(12), 0), Sum64(uint8(12), 0))
+	assert.Equal(t, Sum64(point{1, 2}, 0), Sum64(point{1, 2}, 0))
+	assert.Equal(t, Sum64(0.0, 0), Sum64(math.Copysign(0, -1), 0))
+	assert.Equal(t, Sum64(math.NaN(), 0), Sum64(-math.NaN(), 0))
+
+	// Different values or seeds.
+	assert.NotEqual(t, Sum64("hello", 0), Sum64("hello", 1))
+	assert.NotEqual(t, Sum64("hello", 0), Sum64("world", 0))
+	assert.NotEqual(t, Sum64(1, 0), Sum64(2, 0))
+	assert.NotEqual(t, Sum64(true, 0), Sum64(false, 0))
+	assert.NotEqual(t, Sum64(1.5, 0), Sum64(2.5, 0))
+	assert.NotEqual(t, Sum64(point{1, 2}, 0), Sum64(point{2, 1}, 0))
+	// Seeds are not simply combined with the first byte: 'a'^'b' == 0^3.
+	assert.NotEqual(t, String("a", 0), String("b", 3))
+	assert.NotEqual(t, Bytes([]byte("a"), 0), Bytes([]byte("b"), 3))
+
+	// All integer types.
+	for _, h := range []uint64{
+		Sum64(int(7), 3), Sum64(int8(7), 3), Sum64(int16(7), 3), Sum64(int32(7), 3), Sum64(int64(7), 3),
+		Sum64(uint(7), 3), Sum64(uint8(7), 3), Sum64(uint16(7), 3), Sum64(uint32(7), 3), Sum64(uint64(7), 3),
+		Sum64(uintptr(7), 3),
+	} {
+		assert.Equal(t, Uint64(7, 3), h)
+	}
+}
+
+func TestSum64Composite(t *testing.T) {
+	type point struct{ X float64 }
+	type blank struct {
+		X int
+		_ int
+	}
+	negZero := math.Copysign(0, -1)
+
+	// Floats in structs, arrays and complex numbers are normalized.
+	assert.Equal(t, Sum64(point{0}, 0), Sum64(point{negZero}, 0))
+	assert.Equal(t, Sum64([2]float64{0, 1}, 0), Sum64([2]float64{negZero, 1}, 0))
+	assert.Equal(t, Sum64(complex(0, 1), 0), Sum64(complex(negZero, 1), 0))
+	assert.NotEqual(t, Sum64([2]int{1, 2}, 0), Sum64([2]int{2, 1}, 0))
+	assert.Equal(t, Sum64(blank{X: 1}, 0), Sum64(blank{X: 1}, 0))
+
+	// Pointers are hashed by address, not by the pointee.
+	p := &point{1}
+	h := Sum64(p, 0)
+	p.X = 2
+	assert.Equal(t, h, Sum64(p, 0))
+	assert.NotEqual(t, h, Sum64(&point{1}, 0))
+	ch := make(chan int)
+	assert.Equal(t, Sum64(ch, 0), Sum64(ch, 0))
+
+	// Interfaces are hashed by the dynamic values.
+	iface := func(v any) uint64 { return value(reflect.ValueOf(&v).Elem(), 0) }
+	assert.Equal(t, iface(point{0}), iface(point{negZero}))
+	assert.Equal(t, Sum64(p, 0), iface(p))
+	assert.Equal(t, iface(nil), iface(nil))
+	assert.NotEqual(t, iface(nil), iface(1))
+	assert.Panic(t, func() { iface([]int{1}) })
+}
+
+func TestDistribution(t *testing.T) {
+	const buckets = 16
+	var counts [buckets]int
+	for i := 0; i < 16000; i++ {
+		counts[Sum64(i, 0)%buckets]++
+	}
+	for _, c := range counts {
+		assert.True(t, c > 800 && c < 1200)
+	}
+}
@@ -18,7 +18,7 @@ import (
 	"github.com/bytedance/gg/internal/constraints"
 )
 
-func siftDown[T constraints.Ordered](v []T, lo, hi, first int, less func(i, j int) bool) {
+func siftDown[T any](v []T, lo, hi, first int, less func(i, j int) bool) {
 	root := lo
 	for {
 		child := 2*root + 1
@@ -36,7 +36,7 @@ func siftDown[T constraints.Ordered](v []T, lo, hi, first int, less func(i, j in
 	}
 }
 
-func heapify[T constraints.Ordered](v []T, a, b int, less func(i, j int) bool) {
+func heapify[T any](v []T, a, b int, less func(i, j int) bool) {
 	first := a
 	hi := b - a
 	for i := (hi - 1) / 2; i >= 0; i-- {
@@ -44,7 +44,7 @@ func heapify[T constraints.Ordered](v []T, a, b int, less func(i, j int) bool) {
 	}
 }
 
-func heapSort[T constraints.Ordered](v []T, a, b int, less func(i, j int) bool) {
+func heapSort[T any](v []T, a, b int, less func(i, j int) bool) {
 	first := a
 	lo := 0
 	hi := b - a
@@ -57,7 +57,7 @@ func heapSort[T constraints.Ordered](v []T, a, b int, less func(i, j int) bool)
 	}
 }
 
-func partialSort[T constraints.Ordered](v []T, k int, less func(i, j int) bool) {
+func partialSort[T any](v []T, k int, less func(i, j int) bool) {
 	n := len(v)
 	if k <= 0 || n <= 1 {
 		return
@@ -87,7 +87,7 @@ func PartialSort[T constraints.Ordered](v []T, k int) {
 	PartialSortBy(v, k, func(a, b T) bool { return a < b })
 }
 
-func PartialSortBy[T constraints.Ordered](v []T, k int, less func(a, b T) bool) {
+func PartialSortBy[T any](v []T, k int, less func(a, b T) bool) {
 	n := len(v)
 	if k <= 0 || n <= 1 {
 		return
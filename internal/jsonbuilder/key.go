@@ -0,0 +1,110 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonbuilder
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// KeyUnmarshaler returns a function that converts the key of JSON object to
+// type K, it follows the same rules as [encoding/json] does for map keys.
+//
+// See also: [encoding/json.(*decodeState).object]
+func KeyUnmarshaler[K any]() (func(string) (K, error), error) {
+	var zk K
+	if _, ok := any(&zk).(encoding.TextUnmarshaler); ok {
+		return func(s string) (K, error) {
+			var key K
+			// TODO: Unsafe conv
+			err := any(&key).(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return key, err
+		}, nil
+	}
+
+	rk := reflect.ValueOf(&zk).Elem()
+	kt := rk.Type()
+	switch rk.Kind() {
+	case reflect.String:
+		return func(s string) (K, error) {
+			return reflect.ValueOf(s).Convert(kt).Interface().(K), nil
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(s string) (K, error) {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return zk, err
+			}
+			if rk.OverflowInt(n) {
+				return zk, fmt.Errorf("%s overflows type %T", s, zk)
+			}
+			return reflect.ValueOf(n).Convert(kt).Interface().(K), nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(s string) (K, error) {
+			n, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return zk, err
+			}
+			if rk.OverflowUint(n) {
+				return zk, fmt.Errorf("%s overflows type %T", s, zk)
+			}
+			return reflect.ValueOf(n).Convert(kt).Interface().(K), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected key type: %T", zk)
+	}
+}
+
+// ParseDict parses JSON dictionary data and calls f for each key and raw value
+// in the order they appear in data.
+func ParseDict(data []byte, f func(key string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expect JSON object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expect string key, got %v", tok)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // consume the closing '}'
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data after JSON object")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Bytedance Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonbuilder
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/bytedance/gg/internal/assert"
+)
+
+func TestKeyUnmarshaler(t *testing.T) {
+	{
+		f, err := KeyUnmarshaler[string]()
+		assert.Nil(t, err)
+		k, err := f("a")
+		assert.Nil(t, err)
+		assert.Equal(t, "a", k)
+	}
+	{
+		type myString string
+		f, err := KeyUnmarshaler[myString]()
+		assert.Nil(t, err)
+		k, err := f("a")
+		assert.Nil(t, err)
+		assert.Equal(t, myString("a"), k)
+	}
+	{
+		f, err := KeyUnmarshaler[int8]()
+		assert.Nil(t, err)
+		k, err := f("-12")
+		assert.Nil(t, err)
+		assert.Equal(t, int8(-12), k)
+		_, err = f("128")
+		assert.NotNil(t, err)
+		_, err = f("x")
+		assert.NotNil(t, err)
+	}
+	{
+		f, err := KeyUnmarshaler[uint16]()
+		assert.Nil(t, err)
+		k, err := f("65535")
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(65535), k)
+		_, err = f("65536")
+		assert.NotNil(t, err)
+		_, err = f("-1")
+		assert.NotNil(t, err)
+	}
+	{
+		f, err := KeyUnmarshaler[netip.Addr]()
+		assert.Nil(t, err)
+		k, err := f("127.0.0.1")
+		assert.Nil(t, err)
+		assert.Equal(t, netip.MustParseAddr("127.0.0.1"), k)
+		_, err = f("x")
+		assert.NotNil(t, err)
+	}
+	{
+		_, err := KeyUnmarshaler[float64]()
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseDict(t *testing.T) {
+	{
+		var keys []string
+		var values []string
+		err := ParseDict([]byte(` {"b": 1, "a": [1, 2], "c": {"x": null}} `), func(k string, v json.RawMessage) error {
+			keys = append(keys, k)
+			values = append(values, string(v))
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"b", "a", "c"}, keys)
+		assert.Equal(t, []string{"1", "[1, 2]", `{"x": null}`}, values)
+	}
+	{
+		n := 0
+		err := ParseDict([]byte(`{}`), func(string, json.RawMessage) error {
+			n++
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, n)
+	}
+	{
+		f := func(string, json.RawMessage) error { return nil }
+		assert.NotNil(t, ParseDict([]byte(``), f))
+		assert.NotNil(t, ParseDict([]byte(`[]`), f))
+		assert.NotNil(t, ParseDict([]byte(`{"a":}`), f))
+		assert.NotNil(t, ParseDict([]byte(`{"a":1`), f))
+		assert.NotNil(t, ParseDict([]byte(`{"a":1} 1`), f))
+	}
+	{
+		err := ParseDict([]byte(`{"a":1}`), func(string, json.RawMessage) error {
+			return json.Unmarshal([]byte(`x`), new(int))
+		})
+		assert.NotNil(t, err)
+	}
+}